@@ -0,0 +1,127 @@
+package httpc
+
+import "strings"
+
+// AuthChallenge is one challenge of a WWW-Authenticate header (RFC 9110
+// section 11.6.1), such as `Digest realm="api", nonce="abc", qop="auth"`.
+type AuthChallenge struct {
+	// Scheme is the authentication scheme as sent, e.g. "Digest" or "Bearer".
+	// Schemes are case-insensitive; compare with strings.EqualFold.
+	Scheme string
+	// Params holds the auth-params keyed by lowercased name, with quoted
+	// values unquoted and unescaped.
+	Params map[string]string
+	// Token68 is the single token some schemes send instead of params,
+	// e.g. "YII..." in "Negotiate YII...".
+	Token68 string
+}
+
+// AuthChallenges parses the response's WWW-Authenticate headers into their
+// challenges, in order. A header may carry several challenges, e.g.
+// `Basic realm="x", Bearer realm="y"`, and several headers may be present.
+// Malformed input is parsed leniently rather than rejected.
+// Returns nil if there is no WWW-Authenticate header.
+func (r *Result) AuthChallenges() []AuthChallenge {
+	if r == nil || r.Response == nil {
+		return nil
+	}
+	var challenges []AuthChallenge
+	for _, v := range r.Response.Headers.Values("WWW-Authenticate") {
+		challenges = appendAuthChallenges(challenges, v)
+	}
+	return challenges
+}
+
+// appendAuthChallenges parses the challenges in header value s and appends
+// them to out. A bare token after a comma starts a new challenge; one
+// directly following the scheme is its token68.
+func appendAuthChallenges(out []AuthChallenge, s string) []AuthChallenge {
+	cur := -1
+	afterComma := true
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case ' ', '\t':
+			i++
+			continue
+		case ',':
+			afterComma = true
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !strings.ContainsRune(" \t,=", rune(s[i])) {
+			i++
+		}
+		tok := s[start:i]
+		if tok == "" {
+			i++ // stray '='
+			continue
+		}
+
+		bare := cur >= 0 && !afterComma && out[cur].Token68 == "" && len(out[cur].Params) == 0
+		afterComma = false
+		j := skipSpace(s, i)
+		if cur >= 0 && j < len(s) && s[j] == '=' {
+			if k := skipSpace(s, j+1); k < len(s) && s[k] != ',' && s[k] != '=' {
+				var value string
+				value, i = readAuthParamValue(s, k)
+				out[cur].Params[strings.ToLower(tok)] = value
+				continue
+			}
+			// A token with '=' padding is a token68.
+			end := j
+			for end < len(s) && s[end] == '=' {
+				end++
+			}
+			if bare {
+				out[cur].Token68 = tok + s[j:end]
+			}
+			i = end
+			continue
+		}
+		if bare {
+			out[cur].Token68 = tok
+			continue
+		}
+		out = append(out, AuthChallenge{Scheme: tok, Params: make(map[string]string)})
+		cur = len(out) - 1
+	}
+	return out
+}
+
+// readAuthParamValue reads a token or quoted-string starting at s[i],
+// returning the unescaped value and the index just past it.
+func readAuthParamValue(s string, i int) (string, int) {
+	if s[i] != '"' {
+		start := i
+		for i < len(s) && s[i] != ',' && s[i] != ' ' && s[i] != '\t' {
+			i++
+		}
+		return s[start:i], i
+	}
+	var b strings.Builder
+	for i++; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), i + 1
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), i // unterminated
+}
+
+// skipSpace returns the index of the first non-space, non-tab byte at or
+// after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
@@ -224,6 +224,42 @@ func BenchmarkClient_MemoryAllocation_LargeResponse(b *testing.B) {
 	}
 }
 
+func BenchmarkClient_RetainResponseHeaders(b *testing.B) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := range 20 {
+			w.Header().Set(fmt.Sprintf("X-Header-%d", i), "some header value")
+		}
+		_, _ = w.Write([]byte("OK"))
+	}))
+	defer server.Close()
+
+	for _, bc := range []struct {
+		name   string
+		retain []string
+	}{
+		{"All", nil},
+		{"Retained", []string{"Content-Type"}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			config := DefaultConfig()
+			config.Security.AllowPrivateIPs = true
+			config.Retry.MaxRetries = 0
+			config.Connection.RetainResponseHeaders = bc.retain
+			client, _ := New(config)
+			defer client.Close()
+
+			b.ResetTimer()
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := client.Get(server.URL); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
 // ============================================================================
 // RETRY AND TIMEOUT BENCHMARKS
 // ============================================================================
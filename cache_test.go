@@ -0,0 +1,177 @@
+package httpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// ============================================================================
+// RESPONSE CACHE TESTS
+// ============================================================================
+
+func newCachingTestClient(t *testing.T) Client {
+	t.Helper()
+	cfg := testConfig()
+	cfg.Connection.EnableCache = true
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestCache_HitAndMiss(t *testing.T) {
+	var hits int32
+	var redirectTarget atomic.Value
+	redirectTarget.Store("/fresh?b")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, redirectTarget.Load().(string), http.StatusFound)
+			return
+		case "/fresh":
+			w.Header().Set("Cache-Control", "max-age=60")
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store, max-age=60")
+		case "/private":
+			w.Header().Set("Cache-Control", "private, max-age=60")
+		}
+		w.Write([]byte("payload " + r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	client := newCachingTestClient(t)
+
+	get := func(path string, opts ...RequestOption) *Result {
+		t.Helper()
+		result, err := client.Get(server.URL+path, opts...)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		return result
+	}
+
+	t.Run("fresh response served from cache", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		first := get("/fresh")
+		second := get("/fresh")
+		if n := atomic.LoadInt32(&hits); n != 1 {
+			t.Errorf("Expected 1 server hit, got %d", n)
+		}
+		if second.Body() != first.Body() || second.StatusCode() != http.StatusOK {
+			t.Errorf("Cached response mismatch: %d %q", second.StatusCode(), second.Body())
+		}
+		if second.Meta.Attempts != 0 {
+			t.Errorf("Expected 0 attempts for cached response, got %d", second.Meta.Attempts)
+		}
+	})
+
+	t.Run("query params are part of the key", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		a := get("/fresh", WithQuery("page", 1))
+		b := get("/fresh", WithQuery("page", 2))
+		if n := atomic.LoadInt32(&hits); n != 2 {
+			t.Errorf("Expected 2 server hits, got %d", n)
+		}
+		if a.Body() == b.Body() {
+			t.Errorf("Different queries returned the same body %q", a.Body())
+		}
+	})
+
+	t.Run("no-store and private are not cached", func(t *testing.T) {
+		for _, path := range []string{"/no-store", "/private"} {
+			atomic.StoreInt32(&hits, 0)
+			get(path)
+			get(path)
+			if n := atomic.LoadInt32(&hits); n != 2 {
+				t.Errorf("%s: expected 2 server hits, got %d", path, n)
+			}
+		}
+	})
+
+	t.Run("WithNoCache bypasses cache", func(t *testing.T) {
+		get("/fresh")
+		atomic.StoreInt32(&hits, 0)
+		get("/fresh", WithNoCache())
+		if n := atomic.LoadInt32(&hits); n != 1 {
+			t.Errorf("Expected WithNoCache to hit the server, got %d hits", n)
+		}
+	})
+
+	t.Run("request Cache-Control forces revalidation", func(t *testing.T) {
+		get("/fresh")
+		for _, cc := range []string{"no-cache", "max-age=0"} {
+			atomic.StoreInt32(&hits, 0)
+			get("/fresh", WithHeader("Cache-Control", cc))
+			if n := atomic.LoadInt32(&hits); n != 1 {
+				t.Errorf("Cache-Control %s: expected 1 server hit, got %d", cc, n)
+			}
+		}
+		atomic.StoreInt32(&hits, 0)
+		get("/fresh", WithHeader("Cache-Control", "max-age=3600"))
+		if n := atomic.LoadInt32(&hits); n != 0 {
+			t.Errorf("Cache-Control max-age=3600: expected a cache hit, got %d server hits", n)
+		}
+	})
+
+	t.Run("redirected response is not stored under the original URL", func(t *testing.T) {
+		if body := get("/redirect").Body(); body != "payload b" {
+			t.Fatalf("Expected the redirect target body, got %q", body)
+		}
+		redirectTarget.Store("/fresh?c")
+		if body := get("/redirect").Body(); body != "payload c" {
+			t.Errorf("Expected the changed redirect to be followed, got %q", body)
+		}
+	})
+
+	t.Run("POST is not cached", func(t *testing.T) {
+		atomic.StoreInt32(&hits, 0)
+		client.Post(server.URL + "/fresh")
+		client.Post(server.URL + "/fresh")
+		if n := atomic.LoadInt32(&hits); n != 2 {
+			t.Errorf("Expected 2 server hits for POST, got %d", n)
+		}
+	})
+}
+
+func TestCache_Revalidation(t *testing.T) {
+	var hits, notModified int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		const etag = `"v1"`
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("versioned body"))
+	}))
+	defer server.Close()
+
+	client := newCachingTestClient(t)
+
+	first, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	second, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Second request failed: %v", err)
+	}
+
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("Expected 2 server hits (fetch + revalidate), got %d", n)
+	}
+	if n := atomic.LoadInt32(&notModified); n != 1 {
+		t.Errorf("Expected 1 conditional request answered with 304, got %d", n)
+	}
+	if second.StatusCode() != http.StatusOK || second.Body() != first.Body() {
+		t.Errorf("Expected 304 to be served as cached 200, got %d %q", second.StatusCode(), second.Body())
+	}
+}
@@ -0,0 +1,44 @@
+package httpc
+
+import (
+	"io"
+	"mime"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// charsetDecoder returns a decoder from the charset declared in the response
+// Content-Type to UTF-8. It returns nil when no charset is declared, the
+// charset is UTF-8, or it is not recognized; the body is then decoded as is.
+func (r *Result) charsetDecoder() *encoding.Decoder {
+	_, params, err := mime.ParseMediaType(r.Response.Headers.Get("Content-Type"))
+	if err != nil || params["charset"] == "" {
+		return nil
+	}
+	enc, err := htmlindex.Get(params["charset"])
+	if err != nil {
+		return nil
+	}
+	if name, _ := htmlindex.Name(enc); name == "utf-8" {
+		return nil
+	}
+	return enc.NewDecoder()
+}
+
+// xmlCharsetReader converts XML declared with a non-UTF-8 encoding, e.g.
+// <?xml version="1.0" encoding="Shift_JIS"?>, to UTF-8.
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, err
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+// utf8CharsetReader passes input through unchanged. It is used once the
+// body has been transcoded from the Content-Type charset, which takes
+// precedence over the encoding declared in the document.
+func utf8CharsetReader(_ string, input io.Reader) (io.Reader, error) {
+	return input, nil
+}
@@ -2,12 +2,17 @@ package httpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net"
 	"net/http"
+	"net/url"
+	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/cybergodev/httpc/internal/engine"
 )
@@ -42,12 +47,28 @@ type Client interface {
 	Head(url string, options ...RequestOption) (*Result, error)
 	Options(url string, options ...RequestOption) (*Result, error)
 
+	// Exists reports whether url answers a HEAD request with 2xx or 3xx
+	Exists(url string, options ...RequestOption) (bool, error)
+
 	// File download methods
 	DownloadFile(url string, filePath string, options ...RequestOption) (*DownloadResult, error)
 	DownloadWithOptions(url string, downloadOpts *DownloadConfig, options ...RequestOption) (*DownloadResult, error)
 	DownloadFileWithContext(ctx context.Context, url string, filePath string, options ...RequestOption) (*DownloadResult, error)
 	DownloadWithOptionsWithContext(ctx context.Context, url string, downloadOpts *DownloadConfig, options ...RequestOption) (*DownloadResult, error)
 
+	// CookieHeader returns the Cookie header value the client would send for url
+	CookieHeader(url string) string
+
+	// SaveCookies and LoadCookies persist the client's cookie jar
+	SaveCookies(w io.Writer) error
+	LoadCookies(r io.Reader) error
+
+	// CancelGroup cancels all in-flight requests sent with WithCancelGroup(name)
+	CancelGroup(name string)
+
+	// Stats returns a snapshot of the client's request counters
+	Stats() Stats
+
 	// Close releases resources held by the client
 	Close() error
 }
@@ -69,6 +90,18 @@ type DomainClienter interface {
 	ClearHeaders()
 	GetHeaders() map[string]string
 
+	// Session authentication
+	SetBasicAuth(username, password string) error
+	SetBearerToken(token string) error
+	ClearAuth()
+
+	// Request defaults
+	SetTimeout(timeout time.Duration) error
+	SetMaxRetries(maxRetries int) error
+
+	// Additional hosts that share the session
+	AddTrustedDomain(host string) error
+
 	// Session cookie management
 	SetCookie(cookie *http.Cookie) error
 	SetCookies(cookies []*http.Cookie) error
@@ -76,6 +109,7 @@ type DomainClienter interface {
 	ClearCookies()
 	GetCookies() []*http.Cookie
 	GetCookie(name string) *http.Cookie
+	LoadCookiesNetscape(path string) error
 
 	// Session access
 	Session() *SessionManager
@@ -87,6 +121,9 @@ type engineClient interface {
 	Request(ctx context.Context, method, url string, opts ...engine.RequestOption) (*engine.Response, error)
 	Close() error
 	IsClosed() bool
+	CancelGroup(name string)
+	Stats() Stats
+	Warmup(ctx context.Context, url string) error
 }
 
 // Compile-time check that engine.Client satisfies engineClient.
@@ -96,6 +133,7 @@ type clientImpl struct {
 	engine          engineClient
 	middlewareChain Handler
 	hasMiddlewares  bool
+	cookieJar       http.CookieJar // nil unless Connection.EnableCookies is set
 }
 
 // New creates a new HTTP client with the given configuration.
@@ -159,6 +197,7 @@ func newFromPreparedConfig(cfg *Config) (Client, error) {
 	client := &clientImpl{
 		engine:         engineClient,
 		hasMiddlewares: cfg.Middleware != nil && len(cfg.Middleware.Middlewares) > 0,
+		cookieJar:      engineConfig.CookieJar,
 	}
 
 	// Build middleware chain if middlewares are configured
@@ -166,9 +205,32 @@ func newFromPreparedConfig(cfg *Config) (Client, error) {
 		client.middlewareChain = client.buildMiddlewareChain(cfg.Middleware.Middlewares)
 	}
 
+	if cfg.Connection != nil {
+		client.warmup(cfg.Connection.WarmupURLs)
+	}
+
 	return client, nil
 }
 
+// warmupTimeout bounds how long New waits for warmup requests.
+const warmupTimeout = 2 * time.Second
+
+// warmup sends a HEAD request to each URL concurrently, leaving an idle
+// connection in the pool for each host. Errors are ignored, and requests
+// still running after warmupTimeout are abandoned.
+func (c *clientImpl) warmup(urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(backgroundCtx, warmupTimeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, u := range urls {
+		wg.Go(func() { _ = c.engine.Warmup(ctx, u) })
+	}
+	wg.Wait()
+}
+
 // deepCopyConfig creates a deep copy of the configuration to prevent
 // accidental mutation of shared config state. This is called internally
 // when creating a new client to ensure each client has its own
@@ -214,6 +276,16 @@ func deepCopyConfig(src *Config) *Config {
 		copy(dst.Middleware.Middlewares, src.Middleware.Middlewares)
 	}
 
+	if src.Connection != nil {
+		dst.Connection.WarmupURLs = slices.Clone(src.Connection.WarmupURLs)
+	}
+
+	// Deep copy retryable status codes and methods
+	if src.Retry != nil {
+		dst.Retry.RetryableStatusCodes = slices.Clone(src.Retry.RetryableStatusCodes)
+		dst.Retry.RetryableMethods = slices.Clone(src.Retry.RetryableMethods)
+	}
+
 	// Deep copy redirect whitelist
 	if src.Security != nil && len(src.Security.RedirectWhitelist) > 0 {
 		dst.Security.RedirectWhitelist = make([]string, len(src.Security.RedirectWhitelist))
@@ -288,7 +360,48 @@ func (c *clientImpl) buildMiddlewareChain(middlewares []MiddlewareFunc) Handler
 		// a type assertion on each invocation — only once at chain entry.
 		var onRequest func(*engine.Request) error
 		var onResponse func(*engine.Response) error
+		var sameHostOnly bool
+		var omitCookies []string
+		var returnLastRedirect bool
+		var rateLimit float64
+		var rateBurst int
+		var noCache bool
+		var earlyHints bool
+		var retryStatuses []int
+		var retryMethods []string
+		var retryIf RetryIfFunc
+		var cancelGroup string
+		var bodyTee io.Writer
+		var rawHeaders map[string]string
+		var checkRedirect CheckRedirectFunc
+		var allowPrivateIP bool
+		var readProgress func(read, total int64)
+		var rawRequest func(*http.Request) error
+		var host string
+		var authChallenge func(*engine.Response) (string, bool)
+		var signer func(*http.Request) error
+		var deadline time.Time
 		if engReq, ok := req.(*engine.Request); ok {
+			sameHostOnly = engReq.SameHostRedirectsOnly()
+			omitCookies = engReq.OmittedCookies()
+			returnLastRedirect = engReq.ReturnLastRedirect()
+			rateLimit, rateBurst = engReq.RateLimit()
+			noCache = engReq.NoCache()
+			earlyHints = engReq.EarlyHints()
+			retryStatuses = engReq.RetryableStatusCodes()
+			retryMethods = engReq.RetryableMethods()
+			retryIf = engReq.RetryIf()
+			cancelGroup = engReq.CancelGroup()
+			bodyTee = engReq.BodyTee()
+			rawHeaders = engReq.RawHeaders()
+			checkRedirect = engReq.CheckRedirect()
+			allowPrivateIP = engReq.AllowPrivateIP()
+			readProgress = engReq.ReadProgress()
+			rawRequest = engReq.RawRequest()
+			host = engReq.Host()
+			authChallenge = engReq.AuthChallenge()
+			signer = engReq.Signer()
+			deadline = engReq.Deadline()
 			if cb := engReq.OnRequest(); cb != nil {
 				onRequest = cb
 			}
@@ -313,6 +426,26 @@ func (c *clientImpl) buildMiddlewareChain(middlewares []MiddlewareFunc) Handler
 					r.SetMaxRedirects(mr)
 				}
 				r.SetStreamBody(req.StreamBody())
+				r.SetSameHostRedirectsOnly(sameHostOnly)
+				r.SetOmittedCookies(omitCookies)
+				r.SetReturnLastRedirect(returnLastRedirect)
+				r.SetRateLimit(rateLimit, rateBurst)
+				r.SetNoCache(noCache)
+				r.SetEarlyHints(earlyHints)
+				r.SetRetryableStatusCodes(retryStatuses)
+				r.SetRetryableMethods(retryMethods)
+				r.SetRetryIf(retryIf)
+				r.SetCancelGroup(cancelGroup)
+				r.SetBodyTee(bodyTee)
+				r.SetRawHeaders(rawHeaders)
+				r.SetCheckRedirect(checkRedirect)
+				r.SetAllowPrivateIP(allowPrivateIP)
+				r.SetReadProgress(readProgress)
+				r.SetRawRequest(rawRequest)
+				r.SetHost(host)
+				r.SetAuthChallenge(authChallenge)
+				r.SetSigner(signer)
+				r.SetDeadline(deadline)
 				// Forward pre-extracted callbacks
 				if onRequest != nil {
 					r.SetOnRequest(onRequest)
@@ -361,6 +494,46 @@ func (c *clientImpl) Head(url string, options ...RequestOption) (*Result, error)
 	return c.doRequest("HEAD", url, options)
 }
 
+// Exists reports whether url exists, using a HEAD request that does not
+// follow redirects: 2xx and 3xx responses report true, 4xx responses report
+// false. It suits link checkers, where a redirect means the resource is
+// there. Pass WithFollowRedirects(true) to judge the redirect target instead.
+//
+// Returns a *ClientError of type ErrorTypeHTTP for 5xx responses, and the
+// request error for network failures.
+func (c *clientImpl) Exists(url string, options ...RequestOption) (bool, error) {
+	return headExists(c.Head(url, existsOptions(options)...))
+}
+
+// existsOptions disables redirect following ahead of the caller's options.
+func existsOptions(options []RequestOption) []RequestOption {
+	return append([]RequestOption{WithFollowRedirects(false)}, options...)
+}
+
+// headExists interprets the result of an Exists HEAD request.
+func headExists(result *Result, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	switch code := result.StatusCode(); {
+	case code < 400:
+		return true, nil
+	case code < 500:
+		return false, nil
+	default:
+		clientErr := &ClientError{
+			Type:       ErrorTypeHTTP,
+			Message:    fmt.Sprintf("server error %d", code),
+			Method:     "HEAD",
+			StatusCode: code,
+		}
+		if result.Request != nil {
+			clientErr.URL = result.Request.URL
+		}
+		return false, clientErr
+	}
+}
+
 // Options makes an OPTIONS request to the specified URL using the client's configuration.
 func (c *clientImpl) Options(url string, options ...RequestOption) (*Result, error) {
 	return c.doRequest("OPTIONS", url, options)
@@ -453,6 +626,75 @@ func (c *clientImpl) executeRequest(ctx context.Context, method, url string, opt
 	return resp, err
 }
 
+// CookieHeader returns the Cookie header value the client's cookie jar would
+// send for rawURL, e.g. "session=abc; theme=dark". It is the inverse of
+// WithCookieString and is useful for handing cookies to another tool.
+//
+// Returns an empty string if cookies are disabled, the URL is invalid,
+// or no cookies match.
+func (c *clientImpl) CookieHeader(rawURL string) string {
+	if c.cookieJar == nil {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return formatCookieHeader(c.cookieJar.Cookies(u))
+}
+
+// SaveCookies writes the cookies in the client's jar to w as JSON, for
+// LoadCookies to restore in a later process. See CookieJar.Save.
+//
+// Returns ErrCookiesDisabled if Connection.EnableCookies is not set.
+func (c *clientImpl) SaveCookies(w io.Writer) error {
+	jar, ok := c.cookieJar.(*CookieJar)
+	if !ok {
+		return ErrCookiesDisabled
+	}
+	return jar.Save(w)
+}
+
+// LoadCookies adds cookies written by SaveCookies to the client's jar.
+// See CookieJar.Load.
+//
+// Returns ErrCookiesDisabled if Connection.EnableCookies is not set.
+func (c *clientImpl) LoadCookies(r io.Reader) error {
+	jar, ok := c.cookieJar.(*CookieJar)
+	if !ok {
+		return ErrCookiesDisabled
+	}
+	return jar.Load(r)
+}
+
+// CancelGroup cancels every in-flight request sent with WithCancelGroup(name),
+// including streamed responses whose bodies are still being read. The
+// cancelled calls return errors wrapping context.Canceled. Requests started
+// afterwards with the same name are unaffected.
+//
+// Example:
+//
+//	// On navigation, abandon the previous page's fetches
+//	client.CancelGroup("page")
+//	result, err := client.Get(url, httpc.WithCancelGroup("page"))
+func (c *clientImpl) CancelGroup(name string) {
+	if c.engine != nil {
+		c.engine.CancelGroup(name)
+	}
+}
+
+// Stats returns a snapshot of the client's request counters for dashboards
+// and health checks. Requests that returned a response count as successful
+// whatever their status code; requests that returned an error count as
+// failed. A streamed request leaves InFlight once its response is returned,
+// before the body is read.
+func (c *clientImpl) Stats() Stats {
+	if c.engine == nil {
+		return Stats{}
+	}
+	return c.engine.Stats()
+}
+
 // Close releases resources held by the client including connection pools and transport.
 // After calling Close, the client must not be used for further requests.
 func (c *clientImpl) Close() error {
@@ -554,11 +796,52 @@ func Head(url string, options ...RequestOption) (*Result, error) {
 	return doPackage(Client.Head, url, options...)
 }
 
+// Exists reports whether url exists using a HEAD request with the default
+// client. See Client.Exists.
+func Exists(url string, options ...RequestOption) (bool, error) {
+	client, err := getDefaultClient()
+	if err != nil {
+		return false, err
+	}
+	return client.Exists(url, options...)
+}
+
 // Options makes an OPTIONS request to the specified URL using the default client. Results are pooled; GC handles cleanup automatically.
 func Options(url string, options ...RequestOption) (*Result, error) {
 	return doPackage(Client.Options, url, options...)
 }
 
+// GetJSON makes a GET request using the default client and decodes the JSON
+// response body into a T. For non-2xx responses the body is not decoded: the
+// zero T is returned with a nil error and the Result, so callers can inspect
+// the status. A 2xx response with an empty body also yields the zero T.
+//
+// Example:
+//
+//	user, result, err := httpc.GetJSON[User]("https://api.example.com/users/1")
+func GetJSON[T any](url string, options ...RequestOption) (T, *Result, error) {
+	return decodeJSONResult[T](Get(url, options...))
+}
+
+// PostJSON makes a POST request with body encoded as JSON using the default
+// client and decodes the JSON response into a T, following the same rules
+// as GetJSON.
+func PostJSON[T any](url string, body any, options ...RequestOption) (T, *Result, error) {
+	return decodeJSONResult[T](Post(url, append([]RequestOption{WithJSON(body)}, options...)...))
+}
+
+// decodeJSONResult decodes a successful result's body into a T.
+func decodeJSONResult[T any](result *Result, err error) (T, *Result, error) {
+	var v T
+	if err != nil || !result.IsSuccess() {
+		return v, result, err
+	}
+	if err := result.Unmarshal(&v); err != nil && !errors.Is(err, ErrResponseBodyEmpty) {
+		return v, result, err
+	}
+	return v, result, nil
+}
+
 // doPackageRequest is a helper for the package-level Request function.
 // Unlike doPackage, it accepts a context parameter for timeout and cancellation control.
 func doPackageRequest(ctx context.Context, method, url string, options ...RequestOption) (*Result, error) {
@@ -654,6 +937,21 @@ func convertResponseToResult(resp ResponseMutator) *Result {
 
 	if engineResp, ok := resp.(*engine.Response); ok {
 		result.Response.Headers = engineResp.TransferHeaders()
+		result.Response.CompressedRawBody = engineResp.CompressedRawBody()
+		result.Meta.TLS = newTLSInfo(engineResp.TLS())
+		result.Meta.EarlyHints = engineResp.EarlyHints()
+		result.Meta.BytesSent = engineResp.BytesSent()
+		result.Meta.BytesReceived = engineResp.BytesReceived()
+		result.Meta.ConnectionID = engineResp.ConnectionID()
+		result.Meta.Timing = engineResp.Timing()
+		result.Meta.RetryHistory = engineResp.RetryHistory()
+		result.Meta.RedirectHops = engineResp.RedirectHops()
+		// Streaming mode: hand the unread body to the Result so it survives
+		// releasing the engine Response.
+		if body := engineResp.DetachBody(); body != nil {
+			result.stream = body
+			result.streamCtx = engineResp.StreamContext()
+		}
 	} else {
 		result.Response.Headers = cloneHeaders(resp.Headers())
 	}
@@ -691,7 +989,7 @@ func createCookieJar(enableCookies bool) (http.CookieJar, error) {
 	if !enableCookies {
 		return nil, nil
 	}
-	jar, err := newCookieJar()
+	jar, err := NewCookieJar()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
 	}
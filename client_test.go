@@ -1,11 +1,28 @@
 package httpc
 
 import (
+	"bufio"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -210,6 +227,92 @@ func TestClient_Concurrency(t *testing.T) {
 	})
 }
 
+func TestClient_RateLimit(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("ConcurrentThroughputCapped", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Connection.RateLimit = 50
+		cfg.Connection.RateLimitBurst = 5
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		// 20 requests at 50 rps with a burst of 5 need at least (20-5)/50 = 300ms.
+		const numRequests = 20
+		var wg sync.WaitGroup
+		start := time.Now()
+		for i := 0; i < numRequests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Get(server.URL); err != nil {
+					t.Errorf("Request failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+			t.Errorf("Expected throughput capped to ~50 rps (>=300ms), took %v", elapsed)
+		}
+	})
+
+	t.Run("PerRequestOverride", func(t *testing.T) {
+		client, _ := newTestClient()
+		defer client.Close()
+
+		// 5 requests at 20 rps with a burst of 1 need at least 200ms.
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			if _, err := client.Get(server.URL, WithRateLimit(20, 1)); err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+		}
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("Expected WithRateLimit to cap throughput (>=200ms), took %v", elapsed)
+		}
+	})
+
+	t.Run("WaitBoundedByTimeout", func(t *testing.T) {
+		client, _ := newTestClient()
+		defer client.Close()
+
+		if _, err := client.Get(server.URL, WithRateLimit(0.1, 1)); err != nil {
+			t.Fatalf("First request failed: %v", err)
+		}
+		_, err := client.Get(server.URL, WithRateLimit(0.1, 1), WithTimeout(50*time.Millisecond))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded while waiting for a token, got %v", err)
+		}
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		client, _ := newTestClient()
+		defer client.Close()
+
+		if _, err := client.Get(server.URL, WithRateLimit(0, 1)); err == nil {
+			t.Error("Expected error for zero rate")
+		}
+		if _, err := client.Get(server.URL, WithRateLimit(10, 0)); err == nil {
+			t.Error("Expected error for zero burst")
+		}
+
+		cfg := testConfig()
+		cfg.Connection.RateLimit = -1
+		if _, err := New(cfg); err == nil {
+			t.Error("Expected error for negative Connection.RateLimit")
+		}
+	})
+}
+
 // ----------------------------------------------------------------------------
 // Package-Level Function Tests
 // ----------------------------------------------------------------------------
@@ -317,6 +420,960 @@ func TestClient_ErrorHandling(t *testing.T) {
 	})
 }
 
+func TestClient_Stats(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			started <- struct{}{}
+			<-release
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if stats := client.Stats(); stats != (Stats{}) {
+		t.Errorf("Expected zero stats for a new client, got %+v", stats)
+	}
+
+	const ok, notFound, failed = 5, 2, 3
+	for range ok {
+		if _, err := client.Get(server.URL); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	for range notFound {
+		if _, err := client.Get(server.URL + "/missing"); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	}
+	for range failed {
+		if _, err := client.Get("ftp://invalid"); err == nil {
+			t.Fatal("Expected invalid URL to fail")
+		}
+	}
+
+	stats := client.Stats()
+	if stats.TotalRequests != ok+notFound+failed {
+		t.Errorf("Expected %d total requests, got %d", ok+notFound+failed, stats.TotalRequests)
+	}
+	if stats.SuccessfulRequests != ok+notFound || stats.FailedRequests != failed {
+		t.Errorf("Expected %d successful and %d failed, got %+v", ok+notFound, failed, stats)
+	}
+	if stats.SuccessfulRequests+stats.FailedRequests != stats.TotalRequests {
+		t.Errorf("Counters do not reconcile: %+v", stats)
+	}
+	if stats.AverageLatency <= 0 || stats.InFlight != 0 {
+		t.Errorf("Expected positive latency and nothing in flight, got %+v", stats)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = client.Get(server.URL + "/slow")
+	}()
+	<-started
+	if got := client.Stats().InFlight; got != 1 {
+		t.Errorf("Expected 1 request in flight, got %d", got)
+	}
+	close(release)
+	<-done
+	if got := client.Stats().InFlight; got != 0 {
+		t.Errorf("Expected 0 requests in flight after completion, got %d", got)
+	}
+}
+
+// writeClientCert writes a self-signed client certificate and its key as PEM
+// files in dir, returning their paths and the parsed certificate.
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "httpc test client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cert, _ = x509.ParseCertificate(der)
+	return certFile, keyFile, cert
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCert(t, dir)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.TLS.PeerCertificates[0].Subject.CommonName)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	// Trust the server through RootCAFile rather than InsecureSkipVerify.
+	rootCAFile := filepath.Join(dir, "ca.crt")
+	serverPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(rootCAFile, serverPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newClient := func(mutate func(sec *SecurityConfig)) (Client, error) {
+		cfg := testConfig()
+		cfg.Security.InsecureSkipVerify = false
+		cfg.Security.RootCAFile = rootCAFile
+		mutate(cfg.Security)
+		return New(cfg)
+	}
+
+	t.Run("CertFiles", func(t *testing.T) {
+		client, err := newClient(func(sec *SecurityConfig) {
+			sec.ClientCertFile, sec.ClientKeyFile = certFile, keyFile
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.Body() != "httpc test client" {
+			t.Errorf("Expected server to see the client certificate, got %q", resp.Body())
+		}
+	})
+
+	t.Run("ClientCertificates", func(t *testing.T) {
+		pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := newClient(func(sec *SecurityConfig) {
+			sec.ClientCertificates = []tls.Certificate{pair}
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.Get(server.URL); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+	})
+
+	t.Run("NoCertificateRejected", func(t *testing.T) {
+		client, err := newClient(func(sec *SecurityConfig) {})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		if _, err := client.Get(server.URL); err == nil {
+			t.Error("Expected handshake without a client certificate to fail")
+		}
+	})
+
+	t.Run("InvalidFiles", func(t *testing.T) {
+		for name, mutate := range map[string]func(sec *SecurityConfig){
+			"KeyWithoutCert": func(sec *SecurityConfig) { sec.ClientKeyFile = keyFile },
+			"MissingCert":    func(sec *SecurityConfig) { sec.ClientCertFile, sec.ClientKeyFile = filepath.Join(dir, "none"), keyFile },
+			"BadRootCA":      func(sec *SecurityConfig) { sec.RootCAFile = keyFile },
+		} {
+			if _, err := newClient(mutate); !errors.Is(err, ErrInvalidSecurity) {
+				t.Errorf("%s: expected ErrInvalidSecurity, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestClient_MaxStatusLineLength(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = bufio.NewReader(conn).ReadString('\n')
+				_, _ = conn.Write([]byte("HTTP/1.1 200 "))
+				chunk := []byte(strings.Repeat("A", 4096))
+				for range 256 { // 1MB status line
+					if _, err := conn.Write(chunk); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	cfg := testConfig()
+	cfg.Connection.MaxStatusLineLength = 1024
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	_, err = client.Get("http://" + ln.Addr().String())
+	if !errors.Is(err, ErrStatusLineTooLong) {
+		t.Fatalf("Expected ErrStatusLineTooLong, got %v", err)
+	}
+
+	// Well-formed responses are unaffected, over cleartext and TLS.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 8192)))
+	})
+	for _, server := range []*httptest.Server{httptest.NewServer(handler), httptest.NewTLSServer(handler)} {
+		defer server.Close()
+		for range 2 { // the second request reuses the connection
+			resp, err := client.Post(server.URL, WithBody(strings.Repeat("y", 8192)))
+			if err != nil {
+				t.Fatalf("Request to %s failed: %v", server.URL, err)
+			}
+			if len(resp.Body()) != 8192 {
+				t.Errorf("Expected 8192-byte body, got %d", len(resp.Body()))
+			}
+		}
+	}
+
+	if err := ValidateConfig(&Config{Connection: &ConnectionConfig{MaxStatusLineLength: -1}}); !errors.Is(err, ErrInvalidConnection) {
+		t.Errorf("Expected ErrInvalidConnection for negative limit, got %v", err)
+	}
+}
+
+func TestClient_StrictFraming(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	const chunkedBody = "5\r\nhello\r\n0\r\n\r\n"
+	responses := map[string]string{
+		"/ambiguous": "HTTP/1.1 200 OK\r\nContent-Length: 3\r\nTransfer-Encoding: chunked\r\n\r\n" + chunkedBody,
+		"/interim": "HTTP/1.1 103 Early Hints\r\nLink: </a.css>; rel=preload\r\n\r\n" +
+			"HTTP/1.1 200 OK\r\ncontent-length: 3\r\ntransfer-encoding: chunked\r\n\r\n" + chunkedBody,
+		"/chunked": "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n" + chunkedBody,
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				for {
+					req, err := http.ReadRequest(br)
+					if err != nil {
+						return
+					}
+					if _, err := conn.Write([]byte(responses[req.URL.Path])); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	base := "http://" + ln.Addr().String()
+
+	newClient := func(strict bool) Client {
+		cfg := testConfig()
+		cfg.Security.StrictFraming = strict
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client
+	}
+
+	strict := newClient(true)
+	defer strict.Close()
+	for _, path := range []string{"/ambiguous", "/interim"} {
+		if _, err := strict.Get(base + path); !errors.Is(err, ErrAmbiguousFraming) {
+			t.Errorf("%s: expected ErrAmbiguousFraming, got %v", path, err)
+		}
+	}
+	for range 2 { // the second request reuses the connection
+		resp, err := strict.Get(base + "/chunked")
+		if err != nil {
+			t.Fatalf("Unambiguous response rejected: %v", err)
+		}
+		if resp.Body() != "hello" {
+			t.Errorf("Expected body hello, got %q", resp.Body())
+		}
+	}
+
+	// Without StrictFraming, Transfer-Encoding wins as in net/http.
+	lenient := newClient(false)
+	defer lenient.Close()
+	resp, err := lenient.Get(base + "/ambiguous")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.Body() != "hello" {
+		t.Errorf("Expected body hello, got %q", resp.Body())
+	}
+}
+
+func TestClient_TimeoutJitter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	const timeout, jitter = 10 * time.Second, 2 * time.Second
+	cfg := testConfig()
+	cfg.Timeouts.Request = timeout
+	cfg.Timeouts.Jitter = jitter
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	captureTimeout := func(opts ...RequestOption) time.Duration {
+		var remaining time.Duration
+		opts = append(opts, WithOnRequest(func(req RequestMutator) error {
+			deadline, ok := req.Context().Deadline()
+			if !ok {
+				t.Fatal("Expected request context to have a deadline")
+			}
+			remaining = time.Until(deadline)
+			return nil
+		}))
+		if _, err := client.Get(server.URL, opts...); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return remaining
+	}
+
+	lo, hi := time.Duration(1<<62), time.Duration(0)
+	for range 20 {
+		got := captureTimeout()
+		// Allow slack for the time elapsed before the callback runs.
+		if got < timeout-jitter-time.Second || got > timeout+jitter {
+			t.Fatalf("Effective timeout %v outside %v ± %v", got, timeout, jitter)
+		}
+		lo, hi = min(lo, got), max(hi, got)
+	}
+	if hi-lo < 100*time.Millisecond {
+		t.Errorf("Expected effective timeouts to vary, spread was %v", hi-lo)
+	}
+
+	// The jittered deadline holds across retries, like the fixed one.
+	if got := captureTimeout(WithMaxRetries(2)); got < timeout-jitter-time.Second || got > timeout+jitter {
+		t.Errorf("Effective timeout with retries %v outside %v ± %v", got, timeout, jitter)
+	}
+}
+
+func TestClient_AllowH2C(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.Config.Protocols = new(http.Protocols)
+	server.Config.Protocols.SetHTTP1(true)
+	server.Config.Protocols.SetUnencryptedHTTP2(true)
+	server.Start()
+	defer server.Close()
+
+	for _, tc := range []struct {
+		allowH2C bool
+		want     string
+	}{
+		{false, "HTTP/1.1"},
+		{true, "HTTP/2.0"},
+	} {
+		cfg := testConfig()
+		cfg.Connection.AllowH2C = tc.allowH2C
+		cfg.Security.StrictFraming = true // must not inspect HTTP/2 frames
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		for range 2 {
+			result, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("AllowH2C=%v: request failed: %v", tc.allowH2C, err)
+			}
+			if result.Body() != tc.want || result.Proto() != tc.want {
+				t.Errorf("AllowH2C=%v: expected %s, server saw %q and client %q", tc.allowH2C, tc.want, result.Body(), result.Proto())
+			}
+		}
+		client.Close()
+	}
+}
+
+func TestClient_CustomDialer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var dialed []string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		mu.Lock()
+		dialed = append(dialed, addr)
+		mu.Unlock()
+		var d net.Dialer
+		return d.DialContext(ctx, network, server.Listener.Addr().String())
+	}
+	cfg := testConfig()
+	cfg.Connection.DialContext = dial
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Get("http://api.internal.test:8080/status")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Body() != "api.internal.test:8080" {
+		t.Errorf("Expected the request Host to be kept, got %q", result.Body())
+	}
+	mu.Lock()
+	if len(dialed) != 1 || dialed[0] != "api.internal.test:8080" {
+		t.Errorf("Expected one dial to api.internal.test:8080, got %v", dialed)
+	}
+	mu.Unlock()
+
+	// With SSRF protection on, the host is resolved through Resolver for
+	// validation, and a failed lookup stops the request before DialContext.
+	var lookups atomic.Int32
+	cfg = DefaultConfig()
+	cfg.Connection.DialContext = dial
+	cfg.Connection.Resolver = &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			lookups.Add(1)
+			return nil, errors.New("no DNS in tests")
+		},
+	}
+	guarded, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer guarded.Close()
+	if _, err := guarded.Get("http://api.internal.test/status"); err == nil {
+		t.Error("Expected resolution through the failing resolver to fail")
+	}
+	if lookups.Load() == 0 {
+		t.Error("Expected the custom resolver to be used")
+	}
+	mu.Lock()
+	if len(dialed) != 1 {
+		t.Errorf("Expected no dial after failed validation, got %v", dialed)
+	}
+	mu.Unlock()
+}
+
+// stubDNSResolver returns a resolver answering every A query with v4 and
+// every AAAA query with v6, speaking DNS over an in-memory stream.
+func stubDNSResolver(v4, v6 net.IP) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				for {
+					var size [2]byte
+					if _, err := io.ReadFull(server, size[:]); err != nil {
+						return
+					}
+					query := make([]byte, int(size[0])<<8|int(size[1]))
+					if _, err := io.ReadFull(server, query); err != nil {
+						return
+					}
+					// The question follows the 12-byte header: labels, then
+					// QTYPE and QCLASS.
+					end := 12
+					for query[end] != 0 {
+						end += int(query[end]) + 1
+					}
+					end += 5
+					rdata := v4.To4()
+					if qtype := int(query[end-4])<<8 | int(query[end-3]); qtype == 28 {
+						rdata = v6.To16()
+					}
+					resp := append([]byte{}, query[:2]...)
+					resp = append(resp, 0x81, 0x80, 0, 1, 0, 1, 0, 0, 0, 0)
+					resp = append(resp, query[12:end]...)
+					resp = append(resp, 0xc0, 12) // name: pointer to the question
+					resp = append(resp, query[end-4:end]...)
+					resp = append(resp, 0, 0, 0, 60, 0, byte(len(rdata)))
+					resp = append(resp, rdata...)
+					if _, err := server.Write(append([]byte{byte(len(resp) >> 8), byte(len(resp))}, resp...)); err != nil {
+						return
+					}
+				}
+			}()
+			return client, nil
+		},
+	}
+}
+
+func TestClient_DialPreference(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	v4 := net.ParseIP("93.184.216.34")
+	v6 := net.ParseIP("2606:2800:220:1::1")
+	for _, tc := range []struct {
+		pref DialPreference
+		want net.IP
+	}{
+		{DialPreferIPv4, v4},
+		{DialPreferIPv6, v6},
+	} {
+		var dialed []string
+		cfg := DefaultConfig() // SSRF checks on: the dialer resolves the host itself
+		cfg.Connection.DialPreference = tc.pref
+		cfg.Connection.Resolver = stubDNSResolver(v4, v6)
+		cfg.Connection.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			var d net.Dialer
+			return d.DialContext(ctx, "tcp", server.Listener.Addr().String())
+		}
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		if _, err := client.Get("http://dual.example.test/"); err != nil {
+			t.Fatalf("pref %d: request failed: %v", tc.pref, err)
+		}
+		client.Close()
+		if want := net.JoinHostPort(tc.want.String(), "80"); len(dialed) == 0 || dialed[0] != want {
+			t.Errorf("pref %d: expected %s dialed first, got %v", tc.pref, want, dialed)
+		}
+	}
+}
+
+func TestClient_UnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "httpc") // short path: socket names are length-limited
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("Unix sockets unavailable: %v", err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s", r.Host, r.URL.Path)
+	})}
+	go server.Serve(ln)
+	defer server.Close()
+
+	// SSRF protection stays on: the socket is configured, not requested.
+	cfg := DefaultConfig()
+	cfg.Connection.UnixSocket = socket
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for range 2 { // the second request reuses the connection
+		resp, err := client.Get("http://docker/v1.43/info")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.Body() != "docker /v1.43/info" {
+			t.Errorf("Expected host and path from the URL, got %q", resp.Body())
+		}
+	}
+
+	cfg = testConfig()
+	cfg.Connection.UnixSocket = filepath.Join(dir, "missing.sock")
+	missing, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer missing.Close()
+	if _, err := missing.Get("http://docker/info"); err == nil {
+		t.Error("Expected error dialing a missing socket")
+	}
+}
+
+func TestClient_RetainResponseHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc")
+		w.Header().Set("X-Debug", "noise")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1"})
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Connection.EnableCookies = true
+	cfg.Connection.RetainResponseHeaders = []string{"x-request-id", "Content-Type"}
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for _, stream := range []bool{false, true} {
+		resp, err := client.Get(server.URL, WithStreamBody(stream))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		headers := resp.Response.Headers
+		if len(headers) != 2 || headers.Get("X-Request-Id") != "abc" || headers.Get("Content-Type") != "text/plain" {
+			t.Errorf("stream=%v: expected only allowlisted headers, got %v", stream, headers)
+		}
+		if stream {
+			_ = resp.Stream().Close()
+		}
+	}
+
+	// Set-Cookie was dropped from the headers but still reached the jar.
+	if got := client.CookieHeader(server.URL); got != "session=s1" {
+		t.Errorf("Expected the cookie jar to keep the session cookie, got %q", got)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Cancellation Group Tests
+// ----------------------------------------------------------------------------
+
+func TestClient_ProxyFunc(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "example.test" {
+			t.Errorf("Expected absolute request for example.test, got %q", r.URL.String())
+		}
+		_, _ = w.Write([]byte("via-proxy"))
+	}))
+	defer proxy.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("direct"))
+	}))
+	defer server.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	config := testConfig()
+	config.Connection.ProxyURL = "http://unused.invalid:1"
+	config.Connection.ProxyFunc = func(u *url.URL) (*url.URL, error) {
+		if h := u.Hostname(); h == "127.0.0.1" || strings.HasSuffix(h, ".internal") {
+			return nil, nil
+		}
+		return proxyURL, nil
+	}
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{server.URL, "direct"},
+		{"http://example.test/x", "via-proxy"},
+	}
+	for _, tt := range tests {
+		result, err := client.Get(tt.url)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", tt.url, err)
+		}
+		if result.Body() != tt.want {
+			t.Errorf("GET %s: expected %q, got %q", tt.url, tt.want, result.Body())
+		}
+	}
+}
+
+func TestClient_WarmupURLs(t *testing.T) {
+	var mu sync.Mutex
+	remotes := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		remotes[r.Method] = r.RemoteAddr
+		mu.Unlock()
+	}))
+	defer server.Close()
+	hung := make(chan struct{})
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-hung:
+		case <-r.Context().Done():
+		}
+	}))
+	defer stalled.Close()
+	defer close(hung)
+
+	config := testConfig()
+	config.Timeouts.Request = time.Minute
+	config.Connection.WarmupURLs = []string{server.URL + "/health", "http://127.0.0.1:1/unreachable", stalled.URL}
+	start := time.Now()
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Expected warmup failures to be non-fatal, got %v", err)
+	}
+	defer client.Close()
+	if elapsed := time.Since(start); elapsed > warmupTimeout+time.Second {
+		t.Errorf("Expected New to give up on a stalled host after %v, took %v", warmupTimeout, elapsed)
+	}
+	if stats := client.Stats(); stats.TotalRequests != 0 {
+		t.Errorf("Expected warmup requests to be left out of Stats, got %d requests", stats.TotalRequests)
+	}
+
+	mu.Lock()
+	warm := remotes[http.MethodHead]
+	mu.Unlock()
+	if warm == "" {
+		t.Fatal("Expected a HEAD request during New")
+	}
+	result, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if want := warm + "->" + server.Listener.Addr().String(); result.Meta.ConnectionID != want {
+		t.Errorf("Expected the warmed connection %s to be reused, got %q", warm, result.Meta.ConnectionID)
+	}
+
+	config.Connection.WarmupURLs = []string{"ftp://example.com"}
+	if _, err := New(config); !errors.Is(err, ErrInvalidConnection) {
+		t.Errorf("Expected ErrInvalidConnection for a non-HTTP URL, got %v", err)
+	}
+}
+
+// rotatingTokenSource hands out its tokens in order, repeating the last.
+type rotatingTokenSource struct {
+	mu     sync.Mutex
+	tokens []string
+	calls  int
+}
+
+func (s *rotatingTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if len(s.tokens) == 0 {
+		return "", errors.New("no token")
+	}
+	tok := s.tokens[0]
+	if len(s.tokens) > 1 {
+		s.tokens = s.tokens[1:]
+	}
+	return tok, nil
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	var seen []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Authorization"))
+		mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	source := &rotatingTokenSource{tokens: []string{"expired", "fresh"}}
+	config := testConfig()
+	config.TokenSource = source
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.StatusCode() != http.StatusOK || !slices.Equal(seen, []string{"Bearer expired", "Bearer fresh"}) {
+		t.Errorf("Expected 200 after a refresh, got %d with %q", result.StatusCode(), seen)
+	}
+
+	// A request with its own credentials bypasses the source.
+	seen = nil
+	result, err = client.Get(server.URL, WithBearerToken("fresh"))
+	if err != nil || result.StatusCode() != http.StatusOK || source.calls != 2 {
+		t.Errorf("Expected explicit token to be used as is, got %v, %d calls", err, source.calls)
+	}
+
+	// The same token again is not resent.
+	seen = nil
+	source.tokens = []string{"revoked"}
+	result, err = client.Get(server.URL)
+	if err != nil || result.StatusCode() != http.StatusUnauthorized || len(seen) != 1 {
+		t.Errorf("Expected a single 401, got %v, %q", err, seen)
+	}
+
+	source.tokens = nil
+	if _, err := client.Get(server.URL); err == nil || !strings.Contains(err.Error(), "no token") {
+		t.Errorf("Expected token source error, got %v", err)
+	}
+}
+
+func TestClient_Exists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("Expected HEAD, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/missing", http.StatusMovedPermanently)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	tests := []struct {
+		path string
+		opts []RequestOption
+		want bool
+	}{
+		{"/ok", nil, true},
+		{"/moved", nil, true},
+		{"/moved", []RequestOption{WithFollowRedirects(true)}, false},
+		{"/missing", nil, false},
+	}
+	for _, tt := range tests {
+		got, err := client.Exists(server.URL+tt.path, tt.opts...)
+		if err != nil || got != tt.want {
+			t.Errorf("Exists(%s) = %v, %v; want %v, nil", tt.path, got, err, tt.want)
+		}
+	}
+
+	got, err := client.Exists(server.URL + "/broken")
+	var clientErr *ClientError
+	if got || !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Exists(/broken) = %v, %v; want false and a 500 ClientError", got, err)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	if got, err := client.Exists("http://" + addr + "/"); got || err == nil {
+		t.Errorf("Exists(closed port) = %v, %v; want false and an error", got, err)
+	}
+}
+
+func TestClient_CancelGroup(t *testing.T) {
+	release := make(chan struct{})
+	var inFlight sync.WaitGroup
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Done()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	const grouped = 4
+	inFlight.Add(grouped + 1)
+	errs := make(chan error, grouped)
+	for range grouped {
+		go func() {
+			_, err := client.Get(server.URL, WithCancelGroup("page"))
+			errs <- err
+		}()
+	}
+	other := make(chan error, 1)
+	go func() {
+		_, err := client.Get(server.URL, WithCancelGroup("sidebar"))
+		other <- err
+	}()
+
+	inFlight.Wait()
+	client.CancelGroup("page")
+
+	for range grouped {
+		select {
+		case err := <-errs:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Grouped request was not cancelled")
+		}
+	}
+
+	select {
+	case err := <-other:
+		t.Fatalf("Request in another group finished early: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	release <- struct{}{}
+	if err := <-other; err != nil {
+		t.Errorf("Expected other group to succeed, got %v", err)
+	}
+
+	// The group is reusable after cancellation.
+	inFlight.Add(1)
+	go func() { release <- struct{}{} }()
+	if _, err := client.Get(server.URL, WithCancelGroup("page")); err != nil {
+		t.Errorf("Expected new request in cancelled group to succeed, got %v", err)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Result Pool Tests
 // ----------------------------------------------------------------------------
@@ -490,6 +1547,78 @@ func TestPackageLevel_Request(t *testing.T) {
 	}
 }
 
+func TestPackageLevel_JSONHelpers(t *testing.T) {
+	config := DefaultConfig()
+	config.Security.AllowPrivateIPs = true
+	client, _ := New(config)
+	_ = SetDefaultClient(client)
+	defer CloseDefaultClient()
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1":
+			_, _ = w.Write([]byte(`{"id":1,"name":"ann"}`))
+		case "/users":
+			var in user
+			_ = json.NewDecoder(r.Body).Decode(&in)
+			in.ID = 2
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(in)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("GetJSON", func(t *testing.T) {
+		u, result, err := GetJSON[user](server.URL + "/users/1")
+		if err != nil {
+			t.Fatalf("GetJSON failed: %v", err)
+		}
+		if u.ID != 1 || u.Name != "ann" {
+			t.Errorf("Unexpected user: %+v", u)
+		}
+		if result.StatusCode() != http.StatusOK {
+			t.Errorf("Expected 200, got %d", result.StatusCode())
+		}
+	})
+
+	t.Run("PostJSON", func(t *testing.T) {
+		u, result, err := PostJSON[user](server.URL+"/users", user{Name: "bob"})
+		if err != nil {
+			t.Fatalf("PostJSON failed: %v", err)
+		}
+		if u.ID != 2 || u.Name != "bob" {
+			t.Errorf("Unexpected user: %+v", u)
+		}
+		if result.StatusCode() != http.StatusCreated {
+			t.Errorf("Expected 201, got %d", result.StatusCode())
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		u, result, err := GetJSON[user](server.URL + "/missing")
+		if err != nil {
+			t.Fatalf("GetJSON failed: %v", err)
+		}
+		if u != (user{}) {
+			t.Errorf("Expected zero value, got %+v", u)
+		}
+		if result == nil || result.StatusCode() != http.StatusNotFound {
+			t.Fatalf("Expected 404 result, got %v", result)
+		}
+		if !strings.Contains(result.Body(), "not found") {
+			t.Errorf("Expected error body on result, got %q", result.Body())
+		}
+	})
+}
+
 func TestSetDefaultClient_Boundaries(t *testing.T) {
 	t.Run("nil client", func(t *testing.T) {
 		if err := SetDefaultClient(nil); err == nil {
@@ -2,6 +2,9 @@ package httpc
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
 	"time"
 
 	"github.com/cybergodev/httpc/internal/engine"
@@ -61,6 +64,33 @@ func calculateMaxRetryDelay(cfg *Config) time.Duration {
 	return 30 * time.Second
 }
 
+// loadTLSFiles reads the client certificate and root CA files named in sec,
+// returning the certificates to present (file first, then
+// ClientCertificates) and the CA pool, nil when RootCAFile is unset.
+func loadTLSFiles(sec *SecurityConfig) ([]tls.Certificate, *x509.CertPool, error) {
+	certs := sec.ClientCertificates
+	if sec.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(sec.ClientCertFile, sec.ClientKeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: loading client certificate: %w", ErrInvalidSecurity, err)
+		}
+		certs = append([]tls.Certificate{cert}, certs...)
+	}
+
+	if sec.RootCAFile == "" {
+		return certs, nil, nil
+	}
+	pemData, err := os.ReadFile(sec.RootCAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading RootCAFile: %w", ErrInvalidSecurity, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, nil, fmt.Errorf("%w: RootCAFile %s contains no PEM certificates", ErrInvalidSecurity, sec.RootCAFile)
+	}
+	return certs, pool, nil
+}
+
 // convertToEngineConfig converts public Config to engine Config.
 // It uses helper functions for cleaner separation of concerns.
 func convertToEngineConfig(cfg *Config) (*engine.Config, error) {
@@ -72,10 +102,16 @@ func convertToEngineConfig(cfg *Config) (*engine.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	clientCerts, rootCAs, err := loadTLSFiles(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
 
 	engineConfig := &engine.Config{
 		// Timeout settings
 		Timeout:               cfg.Timeouts.Request,
+		TimeoutPerMB:          cfg.Timeouts.PerMB,
+		TimeoutJitter:         cfg.Timeouts.Jitter,
 		DialTimeout:           cfg.Timeouts.Dial,
 		KeepAlive:             defaultKeepAlive,
 		TLSHandshakeTimeout:   cfg.Timeouts.TLSHandshake,
@@ -87,19 +123,37 @@ func convertToEngineConfig(cfg *Config) (*engine.Config, error) {
 		MaxIdleConnsPerHost:    idleConnsPerHost,
 		MaxConnsPerHost:        cfg.Connection.MaxConnsPerHost,
 		MaxResponseHeaderBytes: cfg.Connection.MaxResponseHeaderBytes,
+		MaxStatusLineLength:    cfg.Connection.MaxStatusLineLength,
 		ProxyURL:               cfg.Connection.ProxyURL,
+		ProxyFunc:              cfg.Connection.ProxyFunc,
+		UnixSocket:             cfg.Connection.UnixSocket,
+		Resolver:               cfg.Connection.Resolver,
+		DialContext:            cfg.Connection.DialContext,
+		DialPreference:         cfg.Connection.DialPreference,
 		EnableSystemProxy:      cfg.Connection.EnableSystemProxy,
 		EnableHTTP2:            cfg.Connection.EnableHTTP2,
+		AllowH2C:               cfg.Connection.AllowH2C,
 		CookieJar:              cookieJar,
 		EnableCookies:          cfg.Connection.EnableCookies,
 		EnableDoH:              cfg.Connection.EnableDoH,
 		DoHCacheTTL:            cfg.Connection.DoHCacheTTL,
+		KeepCompressedBody:     cfg.Connection.KeepCompressedBody,
+		SniffCompression:       cfg.Connection.SniffCompression,
+		RateLimit:              cfg.Connection.RateLimit,
+		RateLimitBurst:         cfg.Connection.RateLimitBurst,
+		EnableCache:            cfg.Connection.EnableCache,
+		CacheMaxEntries:        cfg.Connection.CacheMaxEntries,
+		CacheMaxBytes:          cfg.Connection.CacheMaxBytes,
+		RetainResponseHeaders:  cfg.Connection.RetainResponseHeaders,
 
 		// Security settings
 		TLSConfig:               cfg.Security.TLSConfig,
+		ClientCertificates:      clientCerts,
+		RootCAs:                 rootCAs,
 		MinTLSVersion:           minTLSVersion,
 		MaxTLSVersion:           maxTLSVersion,
 		InsecureSkipVerify:      cfg.Security.InsecureSkipVerify,
+		RequireOCSPStapling:     cfg.Security.RequireOCSPStapling,
 		MaxResponseBodySize:     cfg.Security.MaxResponseBodySize,
 		MaxRequestBodySize:      cfg.Security.MaxRequestBodySize,
 		MaxDecompressedBodySize: cfg.Security.MaxDecompressedBodySize,
@@ -107,20 +161,39 @@ func convertToEngineConfig(cfg *Config) (*engine.Config, error) {
 		ValidateHeaders:         cfg.Security.ValidateHeaders,
 		AllowPrivateIPs:         cfg.Security.AllowPrivateIPs,
 		StrictContentLength:     cfg.Security.StrictContentLength,
+		StrictEncoding:          cfg.Security.StrictEncoding,
+		AllowHopByHopHeaders:    cfg.Security.AllowHopByHopHeaders,
+		AllowGetBody:            cfg.Security.AllowGetBody,
+		StrictFraming:           cfg.Security.StrictFraming,
 
 		// Retry settings
-		MaxRetries:        cfg.Retry.MaxRetries,
-		RetryDelay:        cfg.Retry.Delay,
-		MaxRetryDelay:     maxRetryDelay,
-		BackoffFactor:     cfg.Retry.BackoffFactor,
-		Jitter:            cfg.Retry.EnableJitter,
-		CustomRetryPolicy: cfg.Retry.CustomPolicy,
+		MaxRetries:           cfg.Retry.MaxRetries,
+		RetryDelay:           cfg.Retry.Delay,
+		MaxRetryDelay:        maxRetryDelay,
+		RetryBudget:          cfg.Retry.Budget,
+		BackoffFactor:        cfg.Retry.BackoffFactor,
+		Jitter:               cfg.Retry.EnableJitter,
+		JitterStrategy:       cfg.Retry.JitterStrategy,
+		JitterRand:           cfg.Retry.JitterRand,
+		RetryableStatusCodes: cfg.Retry.RetryableStatusCodes,
+		RetryableMethods:     cfg.Retry.RetryableMethods,
+		CustomRetryPolicy:    cfg.Retry.CustomPolicy,
+		RetryIf:              cfg.Retry.RetryIf,
+		RetryJSONField:       cfg.Retry.RetryJSONField,
+		RetryJSONValue:       cfg.Retry.RetryJSONValue,
 
 		// Middleware settings
-		UserAgent:       cfg.Middleware.UserAgent,
-		Headers:         cfg.Middleware.Headers,
-		FollowRedirects: cfg.Middleware.FollowRedirects,
-		MaxRedirects:    cfg.Middleware.MaxRedirects,
+		UserAgent:                cfg.Middleware.UserAgent,
+		Headers:                  cfg.Middleware.Headers,
+		FollowRedirects:          cfg.Middleware.FollowRedirects,
+		MaxRedirects:             cfg.Middleware.MaxRedirects,
+		MaxRedirectTime:          cfg.Middleware.MaxRedirectTime,
+		CheckRedirect:            cfg.Middleware.CheckRedirect,
+		DisableRefererOnRedirect: cfg.Middleware.DisableRefererOnRedirect,
+		Tracer:                   cfg.Middleware.Tracer,
+
+		Clock:       cfg.Clock,
+		TokenSource: cfg.TokenSource,
 	}
 
 	if len(cfg.Security.RedirectWhitelist) > 0 {
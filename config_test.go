@@ -653,6 +653,8 @@ func TestValidateConfig_AdditionalBoundaries(t *testing.T) {
 		{"backoff factor at minimum", func(c *Config) { c.Retry.BackoffFactor = 1.0 }, false},
 		{"backoff factor at maximum", func(c *Config) { c.Retry.BackoffFactor = 10.0 }, false},
 		{"backoff factor over maximum", func(c *Config) { c.Retry.BackoffFactor = 11.0 }, true},
+		{"decorrelated jitter", func(c *Config) { c.Retry.JitterStrategy = JitterDecorrelated }, false},
+		{"unknown jitter strategy", func(c *Config) { c.Retry.JitterStrategy = JitterDecorrelated + 1 }, true},
 	}
 
 	for _, tt := range tests {
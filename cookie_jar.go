@@ -0,0 +1,200 @@
+package httpc
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxSavedCookiesSize caps the cookie data read by CookieJar.Load.
+const maxSavedCookiesSize = 10 * 1024 * 1024 // 10MB
+
+// CookieJar is the http.CookieJar used by clients with EnableCookies. It
+// wraps net/http/cookiejar and also keeps a record of the stored cookies, so
+// the jar can be saved and loaded again, e.g. by a CLI tool that keeps a
+// login session across runs. It is safe for concurrent use.
+type CookieJar struct {
+	jar *cookiejar.Jar
+
+	mu      sync.Mutex
+	entries map[savedCookieKey]savedCookie
+}
+
+// savedCookieKey identifies a stored cookie, as in RFC 6265 section 5.3.
+type savedCookieKey struct {
+	domain, path, name string
+}
+
+// savedCookie is the JSON form of a stored cookie. Expires is zero for
+// session cookies.
+type savedCookie struct {
+	Name     string        `json:"name"`
+	Value    string        `json:"value"`
+	Domain   string        `json:"domain"`
+	Path     string        `json:"path"`
+	HostOnly bool          `json:"host_only,omitempty"`
+	Secure   bool          `json:"secure,omitempty"`
+	HttpOnly bool          `json:"http_only,omitempty"`
+	SameSite http.SameSite `json:"same_site,omitempty"`
+	Expires  time.Time     `json:"expires,omitzero"`
+}
+
+// NewCookieJar creates an empty CookieJar.
+func NewCookieJar() (*CookieJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CookieJar{jar: jar, entries: make(map[savedCookieKey]savedCookie)}, nil
+}
+
+// SetCookies implements http.CookieJar. Cookies whose Domain attribute does
+// not domain-match the host of u are rejected, and are not saved either.
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.jar.SetCookies(u, cookies)
+
+	now := time.Now()
+	host := strings.ToLower(u.Hostname())
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for _, c := range cookies {
+		if c.Domain != "" && !cookieDomainAllowed(host, c.Domain) {
+			continue
+		}
+		sc := savedCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+			SameSite: c.SameSite,
+		}
+		if c.Domain != "" {
+			sc.Domain = strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+		} else {
+			sc.Domain = strings.ToLower(u.Hostname())
+			sc.HostOnly = true
+		}
+		if !strings.HasPrefix(sc.Path, "/") {
+			sc.Path = defaultCookiePath(u.Path)
+		}
+		key := savedCookieKey{sc.Domain, sc.Path, sc.Name}
+
+		switch {
+		case c.MaxAge < 0:
+			delete(j.entries, key)
+			continue
+		case c.MaxAge > 0:
+			sc.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+		case !c.Expires.IsZero():
+			if !c.Expires.After(now) {
+				delete(j.entries, key)
+				continue
+			}
+			sc.Expires = c.Expires
+		}
+		j.entries[key] = sc
+	}
+}
+
+// Cookies implements http.CookieJar.
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return j.jar.Cookies(u)
+}
+
+// Save writes the jar's unexpired cookies to w as JSON, including session
+// cookies, with their domains, paths and expiry times.
+func (j *CookieJar) Save(w io.Writer) error {
+	now := time.Now()
+	j.mu.Lock()
+	saved := make([]savedCookie, 0, len(j.entries))
+	for _, sc := range j.entries {
+		if sc.Expires.IsZero() || sc.Expires.After(now) {
+			saved = append(saved, sc)
+		}
+	}
+	j.mu.Unlock()
+
+	slices.SortFunc(saved, func(a, b savedCookie) int {
+		return cmp.Or(cmp.Compare(a.Domain, b.Domain), cmp.Compare(a.Path, b.Path), cmp.Compare(a.Name, b.Name))
+	})
+	return json.NewEncoder(w).Encode(saved)
+}
+
+// Load reads cookies written by Save from r and adds them to the jar,
+// replacing cookies with the same domain, path and name. Expired cookies
+// are skipped.
+//
+// Returns an error if r cannot be read or is not valid Save output; in that
+// case no cookies are added.
+func (j *CookieJar) Load(r io.Reader) error {
+	var saved []savedCookie
+	if err := json.NewDecoder(io.LimitReader(r, maxSavedCookiesSize)).Decode(&saved); err != nil {
+		return fmt.Errorf("failed to decode cookies: %w", err)
+	}
+
+	now := time.Now()
+	for _, sc := range saved {
+		if !sc.Expires.IsZero() && !sc.Expires.After(now) {
+			continue
+		}
+		scheme := "http"
+		if sc.Secure {
+			scheme = "https"
+		}
+		c := &http.Cookie{
+			Name:     sc.Name,
+			Value:    sc.Value,
+			Path:     sc.Path,
+			Secure:   sc.Secure,
+			HttpOnly: sc.HttpOnly,
+			SameSite: sc.SameSite,
+			Expires:  sc.Expires,
+		}
+		if !sc.HostOnly {
+			c.Domain = sc.Domain
+		}
+		host := sc.Domain
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]" // IPv6 literal
+		}
+		j.SetCookies(&url.URL{Scheme: scheme, Host: host, Path: sc.Path}, []*http.Cookie{c})
+	}
+	return nil
+}
+
+// cookieDomainAllowed reports whether a response from host may set a cookie
+// with the given Domain attribute: host must domain-match it, as in RFC 6265
+// section 5.1.3, and an IP address only matches itself.
+func cookieDomainAllowed(host, domain string) bool {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	if host == domain {
+		return true
+	}
+	if net.ParseIP(host) != nil {
+		return false
+	}
+	return strings.HasSuffix(host, "."+domain)
+}
+
+// defaultCookiePath returns the default cookie path for a request path, as
+// in RFC 6265 section 5.1.4.
+func defaultCookiePath(p string) string {
+	if p == "" || p[0] != '/' {
+		return "/"
+	}
+	i := strings.LastIndexByte(p, '/')
+	if i == 0 {
+		return "/"
+	}
+	return p[:i]
+}
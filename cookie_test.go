@@ -1,8 +1,13 @@
 package httpc
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -794,3 +799,302 @@ func TestParseCookieHeader_BoundaryConditions(t *testing.T) {
 		})
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Netscape Cookie File Parsing
+// ----------------------------------------------------------------------------
+
+func TestParseNetscapeCookies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fields", func(t *testing.T) {
+		input := ".example.com\tTRUE\t/app\tTRUE\t4102444800\tid\t42\r\n"
+		cookies, err := ParseNetscapeCookies(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(cookies) != 1 {
+			t.Fatalf("expected 1 cookie, got %d", len(cookies))
+		}
+		c := cookies[0]
+		if c.Domain != ".example.com" || c.Path != "/app" || !c.Secure || c.Name != "id" || c.Value != "42" {
+			t.Errorf("unexpected cookie: %+v", c)
+		}
+		if c.Expires.Unix() != 4102444800 {
+			t.Errorf("unexpected expiry: %v", c.Expires)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		tests := []string{
+			"example.com\tFALSE\t/\tFALSE\t0\tname",
+			"example.com\tFALSE\t/\tFALSE\tsoon\tname\tvalue",
+		}
+		for _, input := range tests {
+			if _, err := ParseNetscapeCookies(strings.NewReader(input)); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		}
+	})
+
+	t.Run("domain matching", func(t *testing.T) {
+		tests := []struct {
+			domain, host string
+			want         bool
+		}{
+			{"example.com", "example.com", true},
+			{".example.com", "api.example.com", true},
+			{".example.com", "example.com", true},
+			{"example.com", "api.example.com", false},
+			{"", "any.example", true},
+			{"example.com", "badexample.com", false},
+			{"api.example.com", "example.com", false},
+		}
+		for _, tt := range tests {
+			if got := cookieDomainMatches(tt.domain, tt.host); got != tt.want {
+				t.Errorf("cookieDomainMatches(%q, %q) = %v, want %v", tt.domain, tt.host, got, tt.want)
+			}
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Cookie Header Export
+// ----------------------------------------------------------------------------
+
+func TestCookieHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "theme", Value: "dark", Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "admin", Value: "yes", Path: "/admin"})
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	t.Run("client jar", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Connection.EnableCookies = true
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Close()
+
+		if got := client.CookieHeader(server.URL + "/"); got != "" {
+			t.Errorf("expected empty header before login, got %q", got)
+		}
+		if _, err := client.Get(server.URL + "/login"); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+
+		header := client.CookieHeader(server.URL + "/profile")
+		cookies := parseCookieHeader(header)
+		got := make(map[string]string, len(cookies))
+		for _, c := range cookies {
+			got[c.Name] = c.Value
+		}
+		if len(got) != 2 || got["session"] != "abc123" || got["theme"] != "dark" {
+			t.Errorf("CookieHeader(/profile) = %q", header)
+		}
+
+		if header := client.CookieHeader(server.URL + "/admin/users"); !strings.Contains(header, "admin=yes") {
+			t.Errorf("CookieHeader(/admin/users) = %q, want admin cookie", header)
+		}
+		if got := client.CookieHeader("://bad"); got != "" {
+			t.Errorf("expected empty header for invalid URL, got %q", got)
+		}
+	})
+
+	t.Run("cookies disabled", func(t *testing.T) {
+		client, _ := newTestClient()
+		defer client.Close()
+		if got := client.CookieHeader(server.URL); got != "" {
+			t.Errorf("expected empty header without jar, got %q", got)
+		}
+	})
+
+	t.Run("domain client session overrides jar", func(t *testing.T) {
+		dc, err := NewDomain(server.URL, testConfig())
+		if err != nil {
+			t.Fatalf("NewDomain failed: %v", err)
+		}
+		defer dc.Close()
+
+		if _, err := dc.Get("/login"); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if err := dc.SetCookie(&http.Cookie{Name: "theme", Value: "light"}); err != nil {
+			t.Fatalf("SetCookie failed: %v", err)
+		}
+
+		got := make(map[string]string)
+		for _, c := range parseCookieHeader(dc.CookieHeader("/")) {
+			got[c.Name] = c.Value
+		}
+		if got["session"] != "abc123" || got["theme"] != "light" {
+			t.Errorf("DomainClient.CookieHeader = %v", got)
+		}
+	})
+}
+
+func TestClient_SaveLoadCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "remember", Value: "1", Path: "/", MaxAge: 3600})
+			http.SetCookie(w, &http.Cookie{Name: "admin", Value: "yes", Path: "/admin"})
+			http.SetCookie(w, &http.Cookie{Name: "stale", Value: "x", Path: "/", MaxAge: 1})
+		case "/logout-stale":
+			http.SetCookie(w, &http.Cookie{Name: "stale", Value: "", Path: "/", MaxAge: -1})
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Cookie")))
+	}))
+	defer server.Close()
+
+	newClient := func() Client {
+		cfg := testConfig()
+		cfg.Connection.EnableCookies = true
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		t.Cleanup(func() { _ = client.Close() })
+		return client
+	}
+
+	first := newClient()
+	if _, err := first.Get(server.URL + "/login"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if _, err := first.Get(server.URL + "/logout-stale"); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := first.SaveCookies(&buf); err != nil {
+		t.Fatalf("SaveCookies failed: %v", err)
+	}
+
+	second := newClient()
+	if err := second.LoadCookies(&buf); err != nil {
+		t.Fatalf("LoadCookies failed: %v", err)
+	}
+	for path, want := range map[string][]string{
+		"/profile":     {"remember=1", "session=abc123"},
+		"/admin/users": {"admin=yes", "remember=1", "session=abc123"},
+	} {
+		result, err := second.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		got := strings.Split(result.Body(), "; ")
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("%s: cookies sent = %v, want %v", path, got, want)
+		}
+	}
+
+	if err := second.LoadCookies(strings.NewReader("not json")); err == nil {
+		t.Error("expected error for invalid cookie data")
+	}
+
+	cfg := testConfig()
+	cfg.Connection.EnableCookies = false
+	client, _ := New(cfg)
+	defer client.Close()
+	if err := client.SaveCookies(io.Discard); !errors.Is(err, ErrCookiesDisabled) {
+		t.Errorf("expected ErrCookiesDisabled, got %v", err)
+	}
+}
+
+func TestCookieJar_SaveSkipsRejectedCookies(t *testing.T) {
+	jar, _ := NewCookieJar()
+	jar.SetCookies(&url.URL{Scheme: "http", Host: "attacker.example", Path: "/"}, []*http.Cookie{
+		{Name: "sess", Value: "planted", Domain: "bank.test", Path: "/"},
+	})
+	jar.SetCookies(&url.URL{Scheme: "http", Host: "www.bank.test", Path: "/"}, []*http.Cookie{
+		{Name: "theme", Value: "dark", Domain: ".bank.test", Path: "/"},
+	})
+	jar.SetCookies(&url.URL{Scheme: "http", Host: "127.0.0.1", Path: "/"}, []*http.Cookie{
+		{Name: "ip", Value: "1", Domain: "0.0.1", Path: "/"},
+	})
+
+	var buf bytes.Buffer
+	if err := jar.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, _ := NewCookieJar()
+	if err := loaded.Load(&buf); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	got := loaded.Cookies(&url.URL{Scheme: "http", Host: "bank.test", Path: "/"})
+	if len(got) != 1 || got[0].Name != "theme" {
+		t.Errorf("Expected only the cookie set by www.bank.test after Load, got %v", got)
+	}
+	if got := loaded.Cookies(&url.URL{Scheme: "http", Host: "127.0.0.1", Path: "/"}); len(got) != 0 {
+		t.Errorf("Expected no cookie for the IP host, got %v", got)
+	}
+}
+
+func TestWithoutCookie(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+			http.SetCookie(w, &http.Cookie{Name: "csrf", Value: "tok", Path: "/"})
+			return
+		}
+		names := make([]string, 0, 3)
+		for _, c := range r.Cookies() {
+			names = append(names, c.Name)
+		}
+		w.Write([]byte(strings.Join(names, ",")))
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Connection.EnableCookies = true
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Get(server.URL + "/login"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	t.Run("omits named jar cookie", func(t *testing.T) {
+		result, err := client.Get(server.URL+"/api",
+			WithoutCookie("csrf"),
+			WithCookie(http.Cookie{Name: "extra", Value: "1"}),
+		)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body := result.Body()
+		if strings.Contains(body, "csrf") {
+			t.Errorf("csrf cookie was sent: %q", body)
+		}
+		if !strings.Contains(body, "session") || !strings.Contains(body, "extra") {
+			t.Errorf("expected session and extra cookies, got %q", body)
+		}
+	})
+
+	t.Run("jar cookie kept for later requests", func(t *testing.T) {
+		result, err := client.Get(server.URL + "/api")
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if !strings.Contains(result.Body(), "csrf") {
+			t.Errorf("expected csrf cookie on next request, got %q", result.Body())
+		}
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		if _, err := client.Get(server.URL+"/api", WithoutCookie("")); err == nil {
+			t.Error("expected error for empty cookie name")
+		}
+	})
+}
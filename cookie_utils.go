@@ -1,9 +1,23 @@
 package httpc
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
+)
+
+const (
+	// netscapeHTTPOnlyPrefix marks HttpOnly cookies in Netscape cookie files (curl convention).
+	netscapeHTTPOnlyPrefix = "#HttpOnly_"
+	// maxNetscapeCookieFileSize caps cookie files read from disk.
+	maxNetscapeCookieFileSize = 10 * 1024 * 1024 // 10MB
 )
 
 // cookieSlicePool reduces allocations for cookie slices
@@ -107,3 +121,130 @@ func trimSpaceIndices(s string, low, high int) (int, int) {
 func isWhitespace(c byte) bool {
 	return c == ' ' || c == '\t'
 }
+
+// formatCookieHeader serializes cookies into a Cookie header value
+// ("a=1; b=2"). Only names and values are emitted; cookies with
+// invalid names are skipped.
+func formatCookieHeader(cookies []*http.Cookie) string {
+	var sb strings.Builder
+	for _, c := range cookies {
+		if c == nil {
+			continue
+		}
+		pair := (&http.Cookie{Name: c.Name, Value: c.Value}).String()
+		if pair == "" {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(pair)
+	}
+	return sb.String()
+}
+
+// ParseNetscapeCookies parses cookies in the Netscape cookie file format, as
+// exported by browsers and written by curl. Each non-comment line holds seven
+// tab-separated fields:
+//
+//	domain  include-subdomains  path  secure  expiry  name  value
+//
+// Lines prefixed with "#HttpOnly_" are parsed as HttpOnly cookies; other lines
+// starting with '#' and blank lines are ignored. An expiry of 0 denotes a
+// session cookie. Cookies that have already expired are skipped.
+//
+// The include-subdomains flag is kept in the cookie's Domain: TRUE yields a
+// leading dot (".example.com", the domain and its subdomains), FALSE the
+// bare host ("example.com", that host only).
+//
+// Returns an error identifying the line number of the first malformed entry.
+func ParseNetscapeCookies(r io.Reader) ([]*http.Cookie, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+
+	now := time.Now()
+	var cookies []*http.Cookie
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		httpOnly := false
+		if strings.HasPrefix(line, netscapeHTTPOnlyPrefix) {
+			httpOnly = true
+			line = line[len(netscapeHTTPOnlyPrefix):]
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 tab-separated fields, got %d", lineNum, len(fields))
+		}
+
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid expiry %q", lineNum, fields[4])
+		}
+
+		domain := strings.TrimPrefix(fields[0], ".")
+		if strings.EqualFold(fields[1], "TRUE") {
+			domain = "." + domain
+		}
+		cookie := &http.Cookie{
+			Domain:   domain,
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HttpOnly: httpOnly,
+			Name:     fields[5],
+			Value:    fields[6],
+		}
+		if expiry > 0 {
+			cookie.Expires = time.Unix(expiry, 0)
+			if cookie.Expires.Before(now) {
+				continue
+			}
+		}
+		cookies = append(cookies, cookie)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cookie file: %w", err)
+	}
+
+	return cookies, nil
+}
+
+// readNetscapeCookieFile opens and parses a Netscape-format cookie file.
+func readNetscapeCookieFile(path string) ([]*http.Cookie, error) {
+	if path == "" {
+		return nil, fmt.Errorf("cookie file path cannot be empty")
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cookie file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseNetscapeCookies(io.LimitReader(f, maxNetscapeCookieFileSize))
+}
+
+// cookieDomainMatches reports whether a cookie scoped to domain applies to host,
+// case-insensitively. A domain with a leading dot matches itself and any of its
+// subdomains; one without matches that exact host only. An empty domain
+// matches every host.
+func cookieDomainMatches(domain, host string) bool {
+	if domain == "" {
+		return true
+	}
+	domain, subdomains := strings.CutPrefix(domain, ".")
+	if strings.EqualFold(domain, host) {
+		return true
+	}
+	return subdomains && len(host) > len(domain) &&
+		host[len(host)-len(domain)-1] == '.' &&
+		strings.EqualFold(host[len(host)-len(domain):], domain)
+}
@@ -18,6 +18,10 @@
 //	}
 //	fmt.Println(result.Body())
 //
+// Decode a JSON response into a typed value in one call:
+//
+//	user, result, err := httpc.GetJSON[User]("https://api.example.com/users/1")
+//
 // # Client Creation
 //
 // Create a client with default configuration:
@@ -61,20 +65,28 @@
 //	// Headers
 //	httpc.WithHeader("Authorization", "Bearer token")
 //	httpc.WithHeaderMap(map[string]string{"X-Custom": "value"})
+//	httpc.WithRawHeaderName("x-legacy-TOKEN", "value")
 //	httpc.WithUserAgent("my-app/1.0")
+//	httpc.WithAcceptEncoding("gzip")
 //
 //	// Body
 //	httpc.WithJSON(data)
 //	httpc.WithXML(data)
+//	httpc.WithGraphQL(query, variables, "OperationName")
 //	httpc.WithForm(map[string]string{"key": "value"})
 //	httpc.WithFormData(multipartData)
+//	httpc.WithFormDataStreaming(multipartData)
 //	httpc.WithFile("file", "document.pdf", fileBytes)
+//	httpc.WithFileReader("file", "backup.tar", f, "application/x-tar")
+//	httpc.WithFormDataFromDir("./site", []string{"*.html"}, nil)
 //	httpc.WithBody(rawData)
 //	httpc.WithBinary(binaryData)
 //
 //	// Query parameters
 //	httpc.WithQuery("page", 1)
 //	httpc.WithQueryMap(map[string]any{"page": 1, "limit": 10})
+//	httpc.WithQuerySlice("tag", []string{"go", "http"})
+//	httpc.WithQueryStruct(listParams) // fields tagged `url:"name,omitempty"`
 //
 //	// Authentication
 //	httpc.WithBearerToken(token)
@@ -85,19 +97,35 @@
 //	httpc.WithCookieString("session=abc; token=xyz")
 //	httpc.WithCookieMap(map[string]string{"session": "abc"})
 //	httpc.WithSecureCookie(securityConfig)
+//	httpc.WithoutCookie("csrf_token")
 //
 //	// Request control
 //	httpc.WithContext(ctx)
 //	httpc.WithTimeout(30 * time.Second)
+//	httpc.WithCancelGroup("page")
+//	httpc.WithRequestTee(auditLog)
 //	httpc.WithMaxRetries(3)
+//	httpc.WithRetryPolicy([]int{503}, []string{"POST"})
+//	httpc.WithRetryIf(isPending)
+//	httpc.WithRateLimit(5, 1)
+//	httpc.WithNoCache()
+//	httpc.WithEarlyHints()
 //	httpc.WithFollowRedirects(false)
 //	httpc.WithMaxRedirects(5)
+//	httpc.WithSameHostRedirectsOnly()
+//	httpc.WithRedirectLimit(3, false)
+//	httpc.WithCheckRedirect(denyCrossHost)
+//	httpc.WithAllowPrivateIP() // this request only
 //	httpc.WithStreamBody(true)
+//	httpc.WithReadProgress(func(read, total int64) { ... })
 //
 //	// Callbacks
 //	httpc.WithOnRequest(callback)
 //	httpc.WithOnResponse(callback)
 //
+//	// Composition
+//	httpc.WithModifiers(opt1, opt2, opt3)
+//
 // # DomainClient
 //
 // For session management across requests to the same domain:
@@ -110,6 +138,17 @@
 //	// Headers automatically included
 //	result, err := dc.Request(ctx, "GET", "/users")
 //
+// # Server-Sent Events
+//
+// Consume a text/event-stream endpoint without buffering the body:
+//
+//	lastID, err := httpc.StreamEvents(ctx, client, url, func(ev httpc.SSEEvent) error {
+//	    fmt.Println(ev.Event, ev.Data)
+//	    return nil
+//	})
+//
+// Pass lastID back via WithHeader("Last-Event-ID", lastID) when reconnecting.
+//
 // # Context Handling
 //
 // Use context for timeout and cancellation:
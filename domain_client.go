@@ -3,9 +3,14 @@ package httpc
 import (
 	"context"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"net/url"
 	stdpath "path"
 	"strings"
+	"sync"
+	"time"
 )
 
 // DomainClient provides a client scoped to a specific domain with session management.
@@ -22,6 +27,12 @@ type DomainClient struct {
 	parsedURL *url.URL // Cached parsed URL for efficient URL building
 	domain    string
 	*SessionManager
+
+	// Settings changed after construction, guarded by settingsMu.
+	settingsMu sync.RWMutex
+	timeout    time.Duration   // 0 = client default; see SetTimeout
+	maxRetries int             // negative = client default; see SetMaxRetries
+	trusted    map[string]bool // extra session hosts; see AddTrustedDomain
 }
 
 // NewDomain creates a new DomainClient scoped to the specified base URL.
@@ -93,9 +104,83 @@ func NewDomain(baseURL string, config ...*Config) (DomainClienter, error) {
 		parsedURL:      parsedURL,
 		domain:         parsedURL.Hostname(),
 		SessionManager: session,
+		maxRetries:     -1,
 	}, nil
 }
 
+// SetTimeout sets the timeout for subsequent requests, overriding the
+// client's Timeouts.Request. WithTimeout on a request still takes
+// precedence. A timeout of 0 restores the client default.
+// Returns ErrInvalidTimeout if timeout is negative or exceeds 30 minutes.
+func (dc *DomainClient) SetTimeout(timeout time.Duration) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	if timeout < 0 || timeout > maxTimeout {
+		return fmt.Errorf("%w: must be 0-%v, got %v", ErrInvalidTimeout, maxTimeout, timeout)
+	}
+	dc.settingsMu.Lock()
+	defer dc.settingsMu.Unlock()
+	dc.timeout = timeout
+	return nil
+}
+
+// SetMaxRetries sets the maximum number of retries for subsequent requests,
+// overriding the client's Retry.MaxRetries. WithMaxRetries on a request
+// still takes precedence. A negative value restores the client default.
+// Returns ErrInvalidRetry if maxRetries exceeds 10.
+func (dc *DomainClient) SetMaxRetries(maxRetries int) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	if maxRetries > maxRetryAttempts {
+		return fmt.Errorf("%w: must be 0-%d, got %d", ErrInvalidRetry, maxRetryAttempts, maxRetries)
+	}
+	dc.settingsMu.Lock()
+	defer dc.settingsMu.Unlock()
+	dc.maxRetries = max(maxRetries, -1)
+	return nil
+}
+
+// AddTrustedDomain lets session cookies and headers be sent to, and
+// captured from, requests to host in addition to the base URL's host, e.g.
+// "auth.example.com" alongside "api.example.com". Requests to any other
+// host, made with a full URL, carry neither. host is a hostname without
+// scheme or port and matches exactly; subdomains must be added one by one.
+// Returns an error if host is empty or not a bare hostname.
+func (dc *DomainClient) AddTrustedDomain(host string) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" || (strings.ContainsAny(host, "/:@?# ") && net.ParseIP(host) == nil) {
+		return fmt.Errorf("invalid trusted domain %q: must be a bare hostname", host)
+	}
+	dc.settingsMu.Lock()
+	defer dc.settingsMu.Unlock()
+	if dc.trusted == nil {
+		dc.trusted = make(map[string]bool)
+	}
+	dc.trusted[host] = true
+	return nil
+}
+
+// sessionAllowed reports whether session state applies to fullURL: its
+// host is the base URL's host or was added with AddTrustedDomain.
+func (dc *DomainClient) sessionAllowed(fullURL string) bool {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == strings.ToLower(dc.domain) {
+		return true
+	}
+	dc.settingsMu.RLock()
+	defer dc.settingsMu.RUnlock()
+	return dc.trusted[host]
+}
+
 // Get makes a GET request to the specified path relative to the base URL.
 // If path is a full URL (with scheme), it is used directly.
 func (dc *DomainClient) Get(path string, options ...RequestOption) (*Result, error) {
@@ -138,6 +223,12 @@ func (dc *DomainClient) Options(path string, options ...RequestOption) (*Result,
 	return dc.request("OPTIONS", path, options...)
 }
 
+// Exists reports whether path answers a HEAD request with 2xx or 3xx,
+// without following redirects. See Client.Exists.
+func (dc *DomainClient) Exists(path string, options ...RequestOption) (bool, error) {
+	return headExists(dc.Head(path, existsOptions(options)...))
+}
+
 // Request makes an HTTP request with the specified method and path relative to the base URL.
 // If path is a full URL (with scheme), it is used directly.
 // The context parameter allows for timeout and cancellation control.
@@ -156,14 +247,15 @@ func (dc *DomainClient) Request(ctx context.Context, method, path string, option
 		return nil, err
 	}
 
-	allOptions := dc.prepareSessionOptions(options)
+	trusted := dc.sessionAllowed(fullURL)
+	allOptions := dc.prepareSessionOptions(options, trusted)
 
 	result, err := dc.client.Request(ctx, method, fullURL, allOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	if result != nil {
+	if result != nil && trusted {
 		dc.UpdateFromResult(result)
 	}
 
@@ -224,24 +316,42 @@ func (dc *DomainClient) downloadWithContext(ctx context.Context, path string, do
 		return nil, err
 	}
 
-	allOptions := dc.prepareSessionOptions(options)
+	trusted := dc.sessionAllowed(fullURL)
+	allOptions := dc.prepareSessionOptions(options, trusted)
 
 	result, err := doDownload(ctx, fullURL, downloadOpts, allOptions...)
 	if err != nil {
 		return nil, err
 	}
 
-	dc.captureDownloadCookies(result)
+	if trusted {
+		dc.captureDownloadCookies(result)
+	}
 	return result, nil
 }
 
 // prepareSessionOptions merges session state (headers, cookies) with user-provided options.
+// Session state is neither sent nor captured unless trusted (see sessionAllowed).
 // The read-then-write sequence is intentionally non-atomic: session state is eventually
 // consistent by design. A concurrent request may interleave, but each request captures
 // a consistent snapshot at prepareOptions() time.
-func (dc *DomainClient) prepareSessionOptions(options []RequestOption) []RequestOption {
+func (dc *DomainClient) prepareSessionOptions(options []RequestOption, trusted bool) []RequestOption {
+	// Defaults go first so the caller's options override them.
+	dc.settingsMu.RLock()
+	var allOptions []RequestOption
+	if dc.timeout > 0 {
+		allOptions = append(allOptions, WithTimeout(dc.timeout))
+	}
+	if dc.maxRetries >= 0 {
+		allOptions = append(allOptions, WithMaxRetries(dc.maxRetries))
+	}
+	dc.settingsMu.RUnlock()
+	if !trusted {
+		return append(allOptions, options...)
+	}
+
 	managedOptions := dc.prepareOptions()
-	allOptions := append(managedOptions, options...)
+	allOptions = append(append(allOptions, managedOptions...), options...)
 	dc.captureFromOptions(options)
 	return allOptions
 }
@@ -291,7 +401,11 @@ func (dc *DomainClient) buildURL(pathStr string) (string, error) {
 	// Clone the cached URL to avoid modifying the original
 	result := *dc.parsedURL
 
-	// Parse pathStr to separate path from query/fragment
+	// Parse pathStr to separate path from query/fragment. Collapse leading
+	// slashes first so "//users" is a path, not a host.
+	if strings.HasPrefix(pathStr, "//") {
+		pathStr = "/" + strings.TrimLeft(pathStr, "/")
+	}
 	parsed, err := url.Parse(pathStr)
 	if err != nil {
 		return "", fmt.Errorf("invalid path %q: %w", pathStr, err)
@@ -306,9 +420,8 @@ func (dc *DomainClient) buildURL(pathStr string) (string, error) {
 	// Use path-separator-aware comparison to block prefix collisions
 	// (e.g., base "/a" must not allow escape to "/ab").
 	// Skip check when base path is empty (no scope restriction needed).
-	if dc.parsedURL.Path != "" && dc.parsedURL.Path != "/" {
-		if result.Path != dc.parsedURL.Path &&
-			!strings.HasPrefix(result.Path, dc.parsedURL.Path+"/") {
+	if scope := strings.TrimSuffix(dc.parsedURL.Path, "/"); scope != "" {
+		if result.Path != scope && !strings.HasPrefix(result.Path, scope+"/") {
 			return "", fmt.Errorf("path %q escapes base URL scope", pathStr)
 		}
 	}
@@ -358,12 +471,113 @@ func (dc *DomainClient) Session() *SessionManager {
 	return dc.SessionManager
 }
 
+// LoadCookiesNetscape imports cookies from a Netscape-format cookie file
+// (as exported by browsers or curl) into the session. Only cookies whose domain
+// matches the client's domain are imported; expired entries are skipped.
+//
+// Returns an error if the file cannot be read or parsed, or if any matching
+// cookie fails validation; in that case no cookies are stored.
+func (dc *DomainClient) LoadCookiesNetscape(path string) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	cookies, err := readNetscapeCookieFile(path)
+	if err != nil {
+		return err
+	}
+
+	matching := cookies[:0]
+	for _, cookie := range cookies {
+		if cookieDomainMatches(cookie.Domain, dc.domain) {
+			matching = append(matching, cookie)
+		}
+	}
+	return dc.SessionManager.SetCookies(matching)
+}
+
+// CookieHeader returns the Cookie header value that would be sent for path
+// (relative to the base URL, or an absolute URL): cookies from the client's
+// jar, with session cookies taking precedence by name.
+//
+// Returns an empty string if the URL cannot be built or no cookies match.
+func (dc *DomainClient) CookieHeader(path string) string {
+	if dc.checkInit() != nil {
+		return ""
+	}
+	fullURL, err := dc.buildURL(path)
+	if err != nil {
+		return ""
+	}
+
+	var cookies []*http.Cookie
+	if header := dc.client.CookieHeader(fullURL); header != "" {
+		cookies = parseCookieHeader(header)
+	}
+	host := ""
+	if u, err := url.Parse(fullURL); err == nil {
+		host = u.Hostname()
+	}
+	for _, sc := range dc.GetCookies() {
+		if !cookieDomainMatches(sc.Domain, host) {
+			continue
+		}
+		replaced := false
+		for i, c := range cookies {
+			if c.Name == sc.Name {
+				cookies[i] = sc
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			cookies = append(cookies, sc)
+		}
+	}
+	return formatCookieHeader(cookies)
+}
+
+// SaveCookies writes the cookies in the underlying client's jar to w.
+// Session cookies are not included; see Client.SaveCookies.
+func (dc *DomainClient) SaveCookies(w io.Writer) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	return dc.client.SaveCookies(w)
+}
+
+// LoadCookies adds cookies written by SaveCookies to the underlying
+// client's jar. See Client.LoadCookies.
+func (dc *DomainClient) LoadCookies(r io.Reader) error {
+	if err := dc.checkInit(); err != nil {
+		return err
+	}
+	return dc.client.LoadCookies(r)
+}
+
 // Compile-time interface check to ensure DomainClient implements Client.
 var _ Client = (*DomainClient)(nil)
 
 // Compile-time interface check to ensure DomainClient implements DomainClienter.
 var _ DomainClienter = (*DomainClient)(nil)
 
+// CancelGroup cancels all in-flight requests sent with WithCancelGroup(name).
+// See Client.CancelGroup.
+func (dc *DomainClient) CancelGroup(name string) {
+	if dc == nil || dc.client == nil {
+		return
+	}
+	dc.client.CancelGroup(name)
+}
+
+// Stats returns a snapshot of the underlying client's request counters.
+// See Client.Stats.
+func (dc *DomainClient) Stats() Stats {
+	if dc == nil || dc.client == nil {
+		return Stats{}
+	}
+	return dc.client.Stats()
+}
+
 // Close closes the underlying HTTP client and releases resources.
 // Returns nil if the receiver or underlying client is nil.
 func (dc *DomainClient) Close() error {
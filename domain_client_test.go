@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cybergodev/httpc"
 )
@@ -311,6 +313,201 @@ func TestDomainClient_ClearHeaders(t *testing.T) {
 	}
 }
 
+func TestDomainClient_PersistentAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	cfg := httpc.TestingConfig()
+	cfg.Security.AllowPrivateIPs = true
+	client, err := httpc.NewDomain(server.URL, cfg)
+	if err != nil {
+		t.Fatalf("NewDomain() error = %v", err)
+	}
+	defer client.Close()
+
+	authOf := func(path string) string {
+		t.Helper()
+		result, err := client.Get(path)
+		if err != nil {
+			t.Fatalf("Request error = %v", err)
+		}
+		return result.Body()
+	}
+
+	if err := client.SetBearerToken("abc123"); err != nil {
+		t.Fatalf("SetBearerToken error = %v", err)
+	}
+	for _, path := range []string{"/first", "/second"} {
+		if got := authOf(path); got != "Bearer abc123" {
+			t.Errorf("%s: Authorization = %q, want Bearer abc123", path, got)
+		}
+	}
+
+	if err := client.SetHeader("authorization", "Custom x"); err != nil {
+		t.Fatalf("SetHeader error = %v", err)
+	}
+	if err := client.SetBasicAuth("user", "pass"); err != nil {
+		t.Fatalf("SetBasicAuth error = %v", err)
+	}
+	if got := authOf("/basic"); got != "Basic dXNlcjpwYXNz" {
+		t.Errorf("Authorization = %q, want Basic dXNlcjpwYXNz", got)
+	}
+
+	client.ClearAuth()
+	if got := authOf("/cleared"); got != "" {
+		t.Errorf("Authorization after ClearAuth = %q, want none", got)
+	}
+
+	if err := client.SetBearerToken(""); err == nil {
+		t.Error("Expected error for empty token")
+	}
+	if err := client.SetBasicAuth("", "pass"); err == nil {
+		t.Error("Expected error for empty username")
+	}
+}
+
+func TestDomainClient_RequestDefaults(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(300 * time.Millisecond)
+		case "/unavailable":
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := httpc.TestingConfig()
+	cfg.Security.AllowPrivateIPs = true
+	cfg.Retry.Delay = 10 * time.Millisecond
+	client, err := httpc.NewDomain(server.URL, cfg)
+	if err != nil {
+		t.Fatalf("NewDomain() error = %v", err)
+	}
+	defer client.Close()
+
+	t.Run("SetTimeout", func(t *testing.T) {
+		if err := client.SetTimeout(50 * time.Millisecond); err != nil {
+			t.Fatalf("SetTimeout error = %v", err)
+		}
+		if _, err := client.Get("/slow"); err == nil {
+			t.Error("Expected timeout with SetTimeout(50ms)")
+		}
+		if _, err := client.Get("/slow", httpc.WithTimeout(5*time.Second)); err != nil {
+			t.Errorf("WithTimeout should override SetTimeout: %v", err)
+		}
+		if err := client.SetTimeout(0); err != nil {
+			t.Fatalf("SetTimeout(0) error = %v", err)
+		}
+		if _, err := client.Get("/slow"); err != nil {
+			t.Errorf("Expected client default timeout after SetTimeout(0): %v", err)
+		}
+		if err := client.SetTimeout(-time.Second); err == nil {
+			t.Error("Expected error for negative timeout")
+		}
+	})
+
+	t.Run("SetMaxRetries", func(t *testing.T) {
+		for _, tt := range []struct {
+			retries int
+			want    int32
+		}{
+			{3, 4},
+			{0, 1},
+			{-1, 2}, // client default: TestingConfig retries once
+		} {
+			if err := client.SetMaxRetries(tt.retries); err != nil {
+				t.Fatalf("SetMaxRetries(%d) error = %v", tt.retries, err)
+			}
+			attempts.Store(0)
+			_, _ = client.Get("/unavailable")
+			if got := attempts.Load(); got != tt.want {
+				t.Errorf("SetMaxRetries(%d): %d attempts, want %d", tt.retries, got, tt.want)
+			}
+		}
+		if err := client.SetMaxRetries(11); err == nil {
+			t.Error("Expected error for too many retries")
+		}
+	})
+}
+
+func TestDomainClient_AddTrustedDomain(t *testing.T) {
+	type seen struct{ cookie, header string }
+	record := func(ch chan seen, setCookie bool) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s := seen{header: r.Header.Get("X-Session")}
+			if c, err := r.Cookie("session"); err == nil {
+				s.cookie = c.Value
+			}
+			if setCookie {
+				http.SetCookie(w, &http.Cookie{Name: "other", Value: "x"})
+			}
+			ch <- s
+		}))
+	}
+	baseSeen, otherSeen := make(chan seen, 1), make(chan seen, 1)
+	base := record(baseSeen, false)
+	defer base.Close()
+	other := record(otherSeen, true)
+	defer other.Close()
+	// Same listener address, but a different hostname than the base URL.
+	otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+
+	cfg := httpc.TestingConfig()
+	cfg.Security.AllowPrivateIPs = true
+	client, err := httpc.NewDomain(base.URL, cfg)
+	if err != nil {
+		t.Fatalf("NewDomain() error = %v", err)
+	}
+	defer client.Close()
+	if err := client.SetCookie(&http.Cookie{Name: "session", Value: "abc"}); err != nil {
+		t.Fatalf("SetCookie error = %v", err)
+	}
+	if err := client.SetHeader("X-Session", "on"); err != nil {
+		t.Fatalf("SetHeader error = %v", err)
+	}
+
+	if _, err := client.Get("/"); err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	if got := <-baseSeen; got != (seen{"abc", "on"}) {
+		t.Errorf("base host saw %+v, want session cookie and header", got)
+	}
+
+	if _, err := client.Get(otherURL + "/"); err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	if got := <-otherSeen; got != (seen{}) {
+		t.Errorf("untrusted host saw %+v, want no session state", got)
+	}
+	if client.GetCookie("other") != nil {
+		t.Error("cookie from untrusted host was captured into the session")
+	}
+
+	if err := client.AddTrustedDomain("LOCALHOST"); err != nil {
+		t.Fatalf("AddTrustedDomain error = %v", err)
+	}
+	if _, err := client.Get(otherURL + "/"); err != nil {
+		t.Fatalf("Get error = %v", err)
+	}
+	if got := <-otherSeen; got != (seen{"abc", "on"}) {
+		t.Errorf("trusted host saw %+v, want session cookie and header", got)
+	}
+	if client.GetCookie("other") == nil {
+		t.Error("cookie from trusted host was not captured into the session")
+	}
+
+	for _, bad := range []string{"", "https://auth.example.com", "auth.example.com:443", "auth.example.com/x"} {
+		if err := client.AddTrustedDomain(bad); err == nil {
+			t.Errorf("AddTrustedDomain(%q) should fail", bad)
+		}
+	}
+}
+
 func TestDomainClient_SetCookies(t *testing.T) {
 	client, err := httpc.NewDomain("https://api.example.com")
 	if err != nil {
@@ -407,34 +604,70 @@ func TestDomainClient_ClearCookies(t *testing.T) {
 func TestDomainClient_PathHandling(t *testing.T) {
 	tests := []struct {
 		name     string
-		baseURL  string
+		basePath string // appended to the server URL
 		path     string
 		wantPath string
 	}{
 		{
 			name:     "root path",
-			baseURL:  "https://api.example.com",
+			basePath: "",
 			path:     "/",
 			wantPath: "/",
 		},
 		{
 			name:     "path with leading slash",
-			baseURL:  "https://api.example.com",
+			basePath: "",
 			path:     "/users",
 			wantPath: "/users",
 		},
 		{
 			name:     "path without leading slash",
-			baseURL:  "https://api.example.com",
+			basePath: "",
 			path:     "users",
 			wantPath: "/users",
 		},
 		{
 			name:     "empty path",
-			baseURL:  "https://api.example.com",
+			basePath: "",
 			path:     "",
 			wantPath: "/",
 		},
+		{
+			name:     "base path without trailing slash",
+			basePath: "/v1",
+			path:     "users",
+			wantPath: "/v1/users",
+		},
+		{
+			name:     "base path with trailing slash",
+			basePath: "/v1/",
+			path:     "users",
+			wantPath: "/v1/users",
+		},
+		{
+			name:     "base path with root-relative path",
+			basePath: "/v1",
+			path:     "/users/42",
+			wantPath: "/v1/users/42",
+		},
+		{
+			name:     "duplicate slashes",
+			basePath: "/api/v1/",
+			path:     "//users//42",
+			wantPath: "/api/v1/users/42",
+		},
+		{
+			name:     "trailing slash kept",
+			basePath: "/v1",
+			path:     "users/",
+			wantPath: "/v1/users/",
+		},
+		{
+			name:     "empty path keeps base path",
+			basePath: "/v1/",
+			path:     "",
+			wantPath: "/v1/",
+		},
 	}
 
 	for _, tt := range tests {
@@ -449,7 +682,7 @@ func TestDomainClient_PathHandling(t *testing.T) {
 
 			cfg := httpc.TestingConfig()
 			cfg.Security.AllowPrivateIPs = true
-			client, err := httpc.NewDomain(server.URL, cfg)
+			client, err := httpc.NewDomain(server.URL+tt.basePath, cfg)
 			if err != nil {
 				t.Fatalf("NewDomain() error = %v", err)
 			}
@@ -1375,3 +1608,98 @@ func TestDomainClient_NilReceiver(t *testing.T) {
 		}
 	})
 }
+
+func TestDomainClient_LoadCookiesNetscape(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = make(map[string]string)
+		for _, c := range r.Cookies() {
+			got[c.Name] = c.Value
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cookieFile := strings.Join([]string{
+		"# Netscape HTTP Cookie File",
+		"# This is a generated file! Do not edit.",
+		"",
+		"127.0.0.1\tFALSE\t/\tFALSE\t0\tsession\tabc123",
+		"#HttpOnly_127.0.0.1\tFALSE\t/\tFALSE\t4102444800\tauth\ttoken-xyz",
+		"127.0.0.1\tFALSE\t/\tFALSE\t946684800\texpired\told",
+		".other.example.com\tTRUE\t/\tTRUE\t0\tforeign\tnope",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	if err := os.WriteFile(path, []byte(cookieFile), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	cfg := httpc.TestingConfig()
+	cfg.Security.AllowPrivateIPs = true
+	client, err := httpc.NewDomain(server.URL, cfg)
+	if err != nil {
+		t.Fatalf("NewDomain() error = %v", err)
+	}
+	defer client.Close()
+
+	if err := client.LoadCookiesNetscape(path); err != nil {
+		t.Fatalf("LoadCookiesNetscape() error = %v", err)
+	}
+
+	if _, err := client.Get("/"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	want := map[string]string{"session": "abc123", "auth": "token-xyz"}
+	if len(got) != len(want) {
+		t.Errorf("sent cookies = %v, want %v", got, want)
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("cookie %s = %q, want %q", name, got[name], value)
+		}
+	}
+
+	if c := client.GetCookie("auth"); c == nil || !c.HttpOnly {
+		t.Errorf("auth cookie should be stored as HttpOnly, got %+v", c)
+	}
+
+	if err := client.LoadCookiesNetscape(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected error for missing cookie file")
+	}
+}
+
+func TestDomainClient_LoadCookiesNetscape_HostOnly(t *testing.T) {
+	cookieFile := strings.Join([]string{
+		"example.com\tFALSE\t/\tFALSE\t0\thostonly\t1",
+		".example.com\tTRUE\t/\tFALSE\t0\tshared\t2",
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	if err := os.WriteFile(path, []byte(cookieFile), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	client, err := httpc.NewDomain("http://sub.example.com")
+	if err != nil {
+		t.Fatalf("NewDomain() error = %v", err)
+	}
+	defer client.Close()
+
+	if err := client.LoadCookiesNetscape(path); err != nil {
+		t.Fatalf("LoadCookiesNetscape() error = %v", err)
+	}
+	if client.GetCookie("hostonly") != nil {
+		t.Error("host-only cookie for example.com should not be loaded for sub.example.com")
+	}
+
+	// The session loader keeps every entry but still scopes sending.
+	if err := client.Session().LoadCookiesNetscape(path); err != nil {
+		t.Fatalf("Session().LoadCookiesNetscape() error = %v", err)
+	}
+	if got := client.CookieHeader("/"); got != "shared=2" {
+		t.Errorf("CookieHeader for sub.example.com = %q, want %q", got, "shared=2")
+	}
+	if got := client.CookieHeader("http://example.com/"); !strings.Contains(got, "hostonly=1") {
+		t.Errorf("CookieHeader for example.com = %q, want the host-only cookie", got)
+	}
+}
@@ -20,6 +20,9 @@ import (
 
 // DownloadProgressCallback is called during file download to report progress.
 // Parameters: downloaded bytes, total bytes, current speed in bytes/second.
+// total is -1 when the server does not send a Content-Length. The callback is
+// invoked at most once per 200ms while data arrives, plus once on completion
+// with the final byte count.
 type DownloadProgressCallback func(downloaded, total int64, speed float64)
 
 // ChecksumAlgorithm specifies the hash algorithm for download integrity verification.
@@ -42,12 +45,30 @@ type DownloadConfig struct {
 	// ResumeDownload attempts to resume a previously interrupted download.
 	ResumeDownload bool
 	// Checksum is the expected hex-encoded checksum of the downloaded file.
-	// When set, the file is verified after download completes.
-	// A mismatch causes the download to fail and the file to be removed.
+	// When set, the file is verified after download completes; for resumed
+	// downloads the checksum covers the whole file, not just the appended part.
+	// A mismatch fails the download with ErrChecksumMismatch and removes the
+	// file unless KeepCorruptFile is set.
 	Checksum string
 	// ChecksumAlgorithm specifies the hash algorithm for verification.
 	// Currently only "sha256" is supported. Default: "sha256".
 	ChecksumAlgorithm ChecksumAlgorithm
+	// KeepCorruptFile retains the file when checksum verification fails,
+	// e.g. for inspection. Default: false (the file is removed).
+	KeepCorruptFile bool
+	// Concurrency splits the download into up to this many byte ranges fetched
+	// in parallel. Requires the server to advertise "Accept-Ranges: bytes" and a
+	// Content-Length; otherwise the file is downloaded as a single stream.
+	// Ignored when resuming a partial file. Values <= 1 disable segmentation.
+	// Capped at 16. Default: 0.
+	Concurrency int
+	// MaxBytes caps the size of the downloaded file, including any resumed
+	// part. A download whose Content-Length exceeds it fails before anything
+	// is written; one that grows past it while streaming is aborted and the
+	// partial file removed (a resumed file is truncated back to its original
+	// size). Either way the error wraps ErrResponseBodyTooLarge.
+	// Default: 0 (no limit beyond the client's MaxResponseBodySize).
+	MaxBytes int64
 }
 
 // DefaultDownloadConfig returns a DownloadConfig with default settings.
@@ -99,6 +120,9 @@ type DownloadResult struct {
 	RequestMethod string
 	// RequestHeaders contains the request headers that were sent.
 	RequestHeaders http.Header
+	// Segments reports each byte range of a segmented download, in file order.
+	// Nil unless DownloadConfig.Concurrency caused the file to be split.
+	Segments []DownloadSegment
 }
 
 // doPackageDownload is a helper for package-level download functions.
@@ -173,11 +197,24 @@ func (c *clientImpl) downloadFile(ctx context.Context, url string, opts *Downloa
 		return nil, ErrEmptyFilePath
 	}
 
+	// Every request of a download (first attempt, resume, probe and range
+	// segments) carries the same headers. Asking for identity encoding keeps
+	// resume offsets and ranges in terms of the bytes on disk, since the
+	// streamed body is written as received; a caller's Accept-Encoding
+	// option still takes precedence on single-stream downloads.
+	options = append([]RequestOption{WithHeader("Accept-Encoding", "identity")}, options...)
+
 	filePath, resumeOffset, options, err := prepareResumeState(opts.FilePath, opts, options)
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.Concurrency > 1 && resumeOffset == 0 {
+		if result, ok, err := c.downloadSegmented(ctx, url, filePath, opts, options); ok {
+			return result, err
+		}
+	}
+
 	// Use streaming mode to avoid buffering the entire response body into memory.
 	streamOptions := make([]RequestOption, len(options), len(options)+1)
 	copy(streamOptions, options)
@@ -219,6 +256,15 @@ func (c *clientImpl) downloadFile(ctx context.Context, url string, opts *Downloa
 		return nil, fmt.Errorf("server does not support range requests (status %d); cannot resume download", df.statusCode)
 	}
 
+	// A 206 for a different range than requested would splice the wrong bytes
+	// onto the partial file.
+	if resumed {
+		if cr := df.responseHeaders.Get("Content-Range"); !strings.HasPrefix(cr, fmt.Sprintf("bytes %d-", resumeOffset)) {
+			_, _ = io.Copy(io.Discard, io.LimitReader(df.bodyReader, 1<<20))
+			return nil, fmt.Errorf("server returned Content-Range %q for resume offset %d; cannot resume download", cr, resumeOffset)
+		}
+	}
+
 	// Validate response status
 	if err := handleDownloadStatus(df.statusCode, df.bodyReader, resumeOffset); err != nil {
 		return nil, err
@@ -345,8 +391,22 @@ func handleDownloadStatus(statusCode int, bodyReader io.Reader, resumeOffset int
 	return nil
 }
 
+// errDownloadTooLarge reports a download exceeding DownloadConfig.MaxBytes.
+func errDownloadTooLarge(maxBytes int64) error {
+	return fmt.Errorf("%w: download exceeds MaxBytes (%d bytes)", ErrResponseBodyTooLarge, maxBytes)
+}
+
 // writeDownloadBody streams the response body to a file and returns download statistics.
 func writeDownloadBody(bodyReader io.Reader, filePath string, opts *DownloadConfig, resumed bool, resumeOffset int64, statusCode int, contentLength int64, downloadStart time.Time, responseCookies []*http.Cookie) (*DownloadResult, error) {
+	if opts.MaxBytes > 0 {
+		if contentLength > 0 && resumeOffset+contentLength > opts.MaxBytes {
+			return nil, errDownloadTooLarge(opts.MaxBytes)
+		}
+		// Read one byte past the limit so an oversized body is detected
+		// rather than silently truncated.
+		bodyReader = io.LimitReader(bodyReader, opts.MaxBytes-resumeOffset+1)
+	}
+
 	var file *os.File
 	var err error
 	if resumed {
@@ -360,18 +420,20 @@ func writeDownloadBody(bodyReader io.Reader, filePath string, opts *DownloadConf
 	// Stream body directly from network to file — no full-body buffering.
 	// When checksum verification is requested, hash the data as it passes through.
 	var writer io.Writer = file
-	var hasher hash.Hash
-	if opts.Checksum != "" {
-		switch opts.ChecksumAlgorithm {
-		case ChecksumSHA256, "":
-			hasher = sha256.New()
-		default:
-			_ = file.Close()
-			if !resumed {
-				_ = os.Remove(filePath)
-			}
-			return nil, fmt.Errorf("unsupported checksum algorithm: %s", opts.ChecksumAlgorithm)
+	hasher, err := newDownloadHasher(opts)
+	if err == nil && hasher != nil && resumed {
+		// The checksum covers the whole file, so hash the bytes already on disk
+		// before appending the remainder.
+		err = hashPartialFile(filePath, resumeOffset, hasher)
+	}
+	if err != nil {
+		_ = file.Close()
+		if !resumed {
+			_ = os.Remove(filePath)
 		}
+		return nil, err
+	}
+	if hasher != nil {
 		writer = io.MultiWriter(file, hasher)
 	}
 	if opts.ProgressCallback != nil {
@@ -390,6 +452,17 @@ func writeDownloadBody(bodyReader io.Reader, filePath string, opts *DownloadConf
 	}
 
 	bytesWritten, err := io.Copy(writer, bodyReader)
+	if err == nil && opts.MaxBytes > 0 && resumeOffset+bytesWritten > opts.MaxBytes {
+		err = errDownloadTooLarge(opts.MaxBytes)
+		if resumed {
+			_ = file.Truncate(resumeOffset) // keep the original partial file
+		}
+		_ = file.Close()
+		if !resumed {
+			_ = os.Remove(filePath)
+		}
+		return nil, err
+	}
 	if err != nil {
 		_ = file.Close() // best-effort cleanup on write failure
 		if !resumed {
@@ -414,9 +487,10 @@ func writeDownloadBody(bodyReader io.Reader, filePath string, opts *DownloadConf
 	}
 
 	// Verify checksum if expected value is provided
-	if opts.Checksum != "" && actualChecksum != strings.ToLower(opts.Checksum) {
-		_ = os.Remove(filePath) // remove corrupted download
-		return nil, fmt.Errorf("checksum mismatch: expected %s, got %s", strings.ToLower(opts.Checksum), actualChecksum)
+	if opts.Checksum != "" {
+		if err := verifyDownloadChecksum(filePath, opts, actualChecksum); err != nil {
+			return nil, err
+		}
 	}
 
 	duration := time.Since(downloadStart)
@@ -444,6 +518,59 @@ func writeDownloadBody(bodyReader io.Reader, filePath string, opts *DownloadConf
 	}, nil
 }
 
+// newDownloadHasher returns the hash for opts' checksum, or nil if no
+// checksum was requested.
+func newDownloadHasher(opts *DownloadConfig) (hash.Hash, error) {
+	if opts.Checksum == "" {
+		return nil, nil
+	}
+	switch opts.ChecksumAlgorithm {
+	case ChecksumSHA256, "":
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm: %s", opts.ChecksumAlgorithm)
+	}
+}
+
+// hashPartialFile feeds the first n bytes of path to h.
+func hashPartialFile(path string, n int64, h hash.Hash) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open file for checksum: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := io.CopyN(h, f, n); err != nil {
+		return fmt.Errorf("failed to read file for checksum: %w", err)
+	}
+	return nil
+}
+
+// verifyDownloadChecksum compares actual against opts.Checksum, removing the
+// file on mismatch unless opts.KeepCorruptFile is set.
+func verifyDownloadChecksum(filePath string, opts *DownloadConfig, actual string) error {
+	expected := strings.ToLower(opts.Checksum)
+	if actual == expected {
+		return nil
+	}
+	if !opts.KeepCorruptFile {
+		_ = os.Remove(filePath) // remove corrupted download
+	}
+	return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+}
+
+// hashFile feeds the contents of path to h and returns the hex digest.
+func hashFile(path string, h hash.Hash) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file for checksum: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read file for checksum: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 const (
 	maxFilePathLen  = 4096
 	dirPermissions  = 0755
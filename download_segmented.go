@@ -0,0 +1,286 @@
+package httpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cybergodev/httpc/internal/engine"
+)
+
+const (
+	// maxDownloadConcurrency caps DownloadConfig.Concurrency.
+	maxDownloadConcurrency = 16
+	// minSegmentSize is the smallest byte range worth a separate request.
+	// Smaller files use fewer segments, or a single stream.
+	minSegmentSize = 64 * 1024
+)
+
+// DownloadSegment describes one byte range of a segmented download.
+type DownloadSegment struct {
+	// Index is the segment's position in the file, starting at 0.
+	Index int
+	// Start and End are the inclusive byte offsets of the range.
+	Start, End int64
+	// BytesWritten is the number of bytes written for this range.
+	BytesWritten int64
+	// Duration is the time taken to fetch and write this range.
+	Duration time.Duration
+}
+
+// downloadSegmented downloads url into filePath as parallel byte ranges.
+// ok is false when the server does not support ranges (or the file is too
+// small to split); the caller then falls back to a single stream and nothing
+// has been written. When ok is true, result and err are final.
+func (c *clientImpl) downloadSegmented(ctx context.Context, url, filePath string, opts *DownloadConfig, options []RequestOption) (result *DownloadResult, ok bool, err error) {
+	// Ranges apply to the encoded representation, so ask for identity
+	// encoding throughout; otherwise offsets would refer to gzip bytes.
+	identity := WithHeader("Accept-Encoding", "identity")
+
+	size, probeOK := c.probeRanges(ctx, url, append(options[:len(options):len(options)], identity))
+	if !probeOK {
+		return nil, false, nil
+	}
+	if opts.MaxBytes > 0 && size > opts.MaxBytes {
+		return nil, true, errDownloadTooLarge(opts.MaxBytes)
+	}
+	segments := splitSegments(size, min(opts.Concurrency, maxDownloadConcurrency))
+	if len(segments) < 2 {
+		return nil, false, nil
+	}
+
+	hasher, err := newDownloadHasher(opts)
+	if err != nil {
+		return nil, true, err
+	}
+
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to open file: %w", err)
+	}
+	fail := func(err error) (*DownloadResult, bool, error) {
+		_ = file.Close()
+		_ = os.Remove(filePath) // best-effort cleanup of partial file
+		return nil, true, err
+	}
+	if err := file.Truncate(size); err != nil {
+		return fail(fmt.Errorf("failed to allocate file: %w", err))
+	}
+
+	segCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	downloadStart := time.Now()
+	progress := newSegmentProgress(opts.ProgressCallback, size, downloadStart)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		first    downloadFields
+	)
+	for i := range segments {
+		wg.Add(1)
+		go func(seg *DownloadSegment) {
+			defer wg.Done()
+			df, err := c.fetchSegment(segCtx, url, file, seg, progress, append(options[:len(options):len(options)], identity))
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("segment %d (bytes %d-%d): %w", seg.Index, seg.Start, seg.End, err)
+					cancel()
+				})
+				return
+			}
+			if seg.Index == 0 {
+				first = df
+			}
+		}(&segments[i])
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return fail(firstErr)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fail(fmt.Errorf("failed to sync file: %w", err))
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(filePath)
+		return nil, true, fmt.Errorf("failed to close file: %w", err)
+	}
+
+	var actualChecksum string
+	if hasher != nil {
+		if actualChecksum, err = hashFile(filePath, hasher); err != nil {
+			_ = os.Remove(filePath)
+			return nil, true, err
+		}
+		if err := verifyDownloadChecksum(filePath, opts, actualChecksum); err != nil {
+			return nil, true, err
+		}
+	}
+
+	duration := time.Since(downloadStart)
+	avgSpeed := calculateSpeed(size, duration)
+	if opts.ProgressCallback != nil {
+		opts.ProgressCallback(size, size, avgSpeed)
+	}
+
+	return &DownloadResult{
+		FilePath:        filePath,
+		BytesWritten:    size,
+		Duration:        duration,
+		AverageSpeed:    avgSpeed,
+		StatusCode:      first.statusCode,
+		ContentLength:   size,
+		ResponseCookies: first.responseCookies,
+		ActualChecksum:  actualChecksum,
+		Proto:           first.proto,
+		ResponseHeaders: first.responseHeaders,
+		RequestURL:      first.requestURL,
+		RequestMethod:   first.requestMethod,
+		RequestHeaders:  first.requestHeaders,
+		Segments:        segments,
+	}, true, nil
+}
+
+// probeRanges issues a HEAD request and reports the resource size if the
+// server advertises byte-range support. Any failure reports false so the
+// caller falls back to a plain GET, which surfaces errors the usual way.
+func (c *clientImpl) probeRanges(ctx context.Context, url string, options []RequestOption) (int64, bool) {
+	rawResp, err := c.executeRequest(ctx, "HEAD", url, options)
+	if err != nil || rawResp == nil {
+		return 0, false
+	}
+	defer releaseResponseMutator(rawResp)
+
+	if rawResp.StatusCode() != http.StatusOK {
+		return 0, false
+	}
+	headers := rawResp.Headers()
+	if !strings.EqualFold(strings.TrimSpace(headers.Get("Accept-Ranges")), "bytes") {
+		return 0, false
+	}
+	if enc := headers.Get("Content-Encoding"); enc != "" && !strings.EqualFold(enc, "identity") {
+		return 0, false
+	}
+	size := rawResp.ContentLength()
+	return size, size > 0
+}
+
+// splitSegments divides size bytes into at most n contiguous ranges of at
+// least minSegmentSize bytes each.
+func splitSegments(size int64, n int) []DownloadSegment {
+	if limit := size / minSegmentSize; int64(n) > limit {
+		n = int(limit)
+	}
+	if n < 1 {
+		return nil
+	}
+	segments := make([]DownloadSegment, n)
+	chunk := size / int64(n)
+	for i := range segments {
+		start := int64(i) * chunk
+		end := start + chunk - 1
+		if i == n-1 {
+			end = size - 1
+		}
+		segments[i] = DownloadSegment{Index: i, Start: start, End: end}
+	}
+	return segments
+}
+
+// fetchSegment downloads seg's byte range and writes it at the matching
+// offset in file, recording the bytes written and elapsed time in seg.
+func (c *clientImpl) fetchSegment(ctx context.Context, url string, file *os.File, seg *DownloadSegment, progress *segmentProgress, options []RequestOption) (downloadFields, error) {
+	start := time.Now()
+	rangeSpec := fmt.Sprintf("bytes=%d-%d", seg.Start, seg.End)
+	options = append(options, WithHeader("Range", rangeSpec), WithStreamBody(true))
+
+	rawResp, err := c.executeRequest(ctx, "GET", url, options)
+	if err != nil {
+		return downloadFields{}, err
+	}
+	engResp, ok := rawResp.(*engine.Response)
+	if !ok {
+		releaseResponseMutator(rawResp)
+		return downloadFields{}, fmt.Errorf("download is not compatible with middleware that wraps ResponseMutator")
+	}
+	df := extractDownloadFields(engResp)
+	defer engine.ReleaseResponse(engResp)
+	if df.bodyReader == nil {
+		return df, fmt.Errorf("download response has no body reader")
+	}
+	defer func() { _ = df.bodyReader.Close() }() // best-effort cleanup
+
+	if df.statusCode != http.StatusPartialContent {
+		_, _ = io.Copy(io.Discard, io.LimitReader(df.bodyReader, 1<<20))
+		return df, fmt.Errorf("expected status 206 for range request, got %d", df.statusCode)
+	}
+	if cr := df.responseHeaders.Get("Content-Range"); !strings.HasPrefix(cr, fmt.Sprintf("bytes %d-%d/", seg.Start, seg.End)) {
+		return df, fmt.Errorf("unexpected Content-Range %q for %s", cr, rangeSpec)
+	}
+
+	want := seg.End - seg.Start + 1
+	w := io.NewOffsetWriter(file, seg.Start)
+	n, err := io.Copy(progress.writer(w), io.LimitReader(df.bodyReader, want))
+	seg.BytesWritten = n
+	seg.Duration = time.Since(start)
+	if err != nil {
+		return df, fmt.Errorf("failed to write file: %w", err)
+	}
+	if n != want {
+		return df, fmt.Errorf("short range body: got %d of %d bytes", n, want)
+	}
+	return df, nil
+}
+
+// segmentProgress aggregates progress across concurrent segment writers and
+// invokes the callback at most once per progressInterval.
+type segmentProgress struct {
+	mu           sync.Mutex
+	callback     DownloadProgressCallback
+	total        int64
+	written      int64
+	startTime    time.Time
+	lastCallback time.Time
+}
+
+func newSegmentProgress(callback DownloadProgressCallback, total int64, start time.Time) *segmentProgress {
+	return &segmentProgress{callback: callback, total: total, startTime: start, lastCallback: start}
+}
+
+// writer wraps w so that bytes written through it count towards progress.
+func (p *segmentProgress) writer(w io.Writer) io.Writer {
+	if p.callback == nil {
+		return w
+	}
+	return segmentProgressWriter{w: w, p: p}
+}
+
+func (p *segmentProgress) add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.written += int64(n)
+	if now := time.Now(); now.Sub(p.lastCallback) >= progressInterval {
+		p.callback(p.written, p.total, calculateSpeed(p.written, now.Sub(p.startTime)))
+		p.lastCallback = now
+	}
+}
+
+type segmentProgressWriter struct {
+	w io.Writer
+	p *segmentProgress
+}
+
+func (sw segmentProgressWriter) Write(b []byte) (int, error) {
+	n, err := sw.w.Write(b)
+	if n > 0 {
+		sw.p.add(n)
+	}
+	return n, err
+}
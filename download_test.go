@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,6 +14,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -64,6 +67,57 @@ func TestDownload_Basic(t *testing.T) {
 	}
 }
 
+func TestDownload_MaxBytes(t *testing.T) {
+	content := bytes.Repeat([]byte("x"), 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("length") == "1" {
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+		} else {
+			w.(http.Flusher).Flush() // force chunked encoding
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.Security.AllowPrivateIPs = true
+	client, _ := New(config)
+	defer client.Close()
+
+	for _, tt := range []struct{ name, query string }{
+		{"Content-Length", "?length=1"},
+		{"chunked", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "big.bin")
+			opts := DefaultDownloadConfig()
+			opts.FilePath = filePath
+			opts.MaxBytes = 1000
+
+			_, err := client.DownloadWithOptions(server.URL+tt.query, opts)
+			if !errors.Is(err, ErrResponseBodyTooLarge) {
+				t.Fatalf("Expected ErrResponseBodyTooLarge, got %v", err)
+			}
+			if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+				t.Errorf("Expected partial file to be removed, stat err = %v", err)
+			}
+		})
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		opts := DefaultDownloadConfig()
+		opts.FilePath = filepath.Join(t.TempDir(), "ok.bin")
+		opts.MaxBytes = int64(len(content))
+		result, err := client.DownloadWithOptions(server.URL, opts)
+		if err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if result.BytesWritten != int64(len(content)) {
+			t.Errorf("Expected %d bytes, got %d", len(content), result.BytesWritten)
+		}
+	})
+}
+
 func TestDownload_EmptyFile(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
@@ -171,6 +225,52 @@ func TestDownload_WithProgress(t *testing.T) {
 	}
 }
 
+func TestDownload_ProgressUnknownLength(t *testing.T) {
+	chunk := []byte(strings.Repeat("y", 4096))
+	const chunks = 8
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		for range chunks {
+			_, _ = w.Write(chunk)
+			flusher.Flush() // chunked encoding: no Content-Length
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	type sample struct{ written, total int64 }
+	var samples []sample
+	opts := DefaultDownloadConfig()
+	opts.FilePath = filepath.Join(t.TempDir(), "unknown-length.bin")
+	opts.ProgressCallback = func(downloaded, total int64, speed float64) {
+		samples = append(samples, sample{downloaded, total})
+	}
+
+	result, err := client.DownloadWithOptions(server.URL, opts)
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if len(samples) == 0 {
+		t.Fatal("Progress callback was not called")
+	}
+	for _, s := range samples {
+		if s.total != -1 {
+			t.Errorf("Expected total -1 for unknown length, got %d", s.total)
+		}
+	}
+	last := samples[len(samples)-1]
+	info, err := os.Stat(opts.FilePath)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if last.written != info.Size() || last.written != result.BytesWritten {
+		t.Errorf("Final progress %d, file size %d, BytesWritten %d", last.written, info.Size(), result.BytesWritten)
+	}
+}
+
 func TestDownload_WithTimeout(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		time.Sleep(200 * time.Millisecond)
@@ -921,6 +1021,81 @@ func TestWriteDownloadBody_ChecksumVerification(t *testing.T) {
 	})
 }
 
+func TestDownload_ResumeChecksum(t *testing.T) {
+	full := []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+	partial := 10
+	sum := sha256.Sum256(full)
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(full))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	t.Run("whole file verified", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "resume.bin")
+		_ = os.WriteFile(filePath, full[:partial], 0644)
+
+		opts := &DownloadConfig{FilePath: filePath, ResumeDownload: true, Checksum: checksum}
+		result, err := client.DownloadWithOptions(server.URL, opts)
+		if err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if !result.Resumed || result.ActualChecksum != checksum {
+			t.Errorf("Expected resumed download with checksum %s, got resumed=%v %s", checksum, result.Resumed, result.ActualChecksum)
+		}
+		got, _ := os.ReadFile(filePath)
+		if !bytes.Equal(got, full) {
+			t.Errorf("Expected %q, got %q", full, got)
+		}
+	})
+
+	t.Run("corrupt partial file", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "corrupt.bin")
+		_ = os.WriteFile(filePath, []byte("XXXXXXXXXX"), 0644)
+
+		opts := &DownloadConfig{FilePath: filePath, ResumeDownload: true, Checksum: checksum, KeepCorruptFile: true}
+		_, err := client.DownloadWithOptions(server.URL, opts)
+		if !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("Expected ErrChecksumMismatch, got: %v", err)
+		}
+		if _, statErr := os.Stat(filePath); statErr != nil {
+			t.Errorf("Expected corrupt file to be kept, got: %v", statErr)
+		}
+
+		opts.KeepCorruptFile = false
+		_ = os.WriteFile(filePath, []byte("XXXXXXXXXX"), 0644)
+		if _, err := client.DownloadWithOptions(server.URL, opts); !errors.Is(err, ErrChecksumMismatch) {
+			t.Fatalf("Expected ErrChecksumMismatch, got: %v", err)
+		}
+		if _, statErr := os.Stat(filePath); !os.IsNotExist(statErr) {
+			t.Error("Expected corrupt file to be removed")
+		}
+	})
+
+	t.Run("server ignores range", func(t *testing.T) {
+		ignoring := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(full)
+		}))
+		defer ignoring.Close()
+
+		filePath := filepath.Join(t.TempDir(), "ignored.bin")
+		_ = os.WriteFile(filePath, full[:partial], 0644)
+
+		opts := &DownloadConfig{FilePath: filePath, ResumeDownload: true, Checksum: checksum}
+		if _, err := client.DownloadWithOptions(ignoring.URL, opts); err == nil {
+			t.Fatal("Expected error when server ignores Range")
+		}
+		got, _ := os.ReadFile(filePath)
+		if !bytes.Equal(got, full[:partial]) {
+			t.Errorf("Partial file was modified: %q", got)
+		}
+	})
+}
+
 // ============================================================================
 // Boundary condition tests for download helpers
 // ============================================================================
@@ -1115,3 +1290,149 @@ func TestCheckParentDirSymlinks_BoundaryConditions(t *testing.T) {
 		}
 	})
 }
+
+func TestDownload_ConsistentHeaders(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 16*1024) // 256KB
+
+	var mu sync.Mutex
+	var recorded []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := r.Header.Clone()
+		h.Del("Range")
+		mu.Lock()
+		recorded = append(recorded, h)
+		mu.Unlock()
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Middleware.Headers = map[string]string{"X-Client": "cfg"}
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Close()
+
+	filePath := filepath.Join(t.TempDir(), "file.bin")
+	download := func(mutate func(*DownloadConfig)) {
+		t.Helper()
+		opts := DefaultDownloadConfig()
+		opts.FilePath = filePath
+		mutate(opts)
+		if _, err := client.DownloadWithOptions(server.URL, opts, WithHeader("X-Trace", "abc")); err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		got, _ := os.ReadFile(filePath)
+		if !bytes.Equal(got, content) {
+			t.Fatalf("Downloaded content mismatch: %d bytes", len(got))
+		}
+	}
+
+	download(func(o *DownloadConfig) {})
+	if err := os.Truncate(filePath, 1000); err != nil {
+		t.Fatalf("Truncate failed: %v", err)
+	}
+	download(func(o *DownloadConfig) { o.ResumeDownload = true })
+	download(func(o *DownloadConfig) { o.Overwrite = true; o.Concurrency = 2 })
+
+	// First attempt, resume, then HEAD probe and two segments.
+	if len(recorded) != 5 {
+		t.Fatalf("Expected 5 requests, got %d", len(recorded))
+	}
+	for i, h := range recorded {
+		for _, key := range []string{"User-Agent", "Accept-Encoding", "X-Client", "X-Trace"} {
+			if got, want := h.Get(key), recorded[0].Get(key); got != want {
+				t.Errorf("request %d: %s = %q, want %q", i, key, got, want)
+			}
+		}
+	}
+	if got := recorded[0].Get("Accept-Encoding"); got != "identity" {
+		t.Errorf("Accept-Encoding = %q, want identity", got)
+	}
+}
+
+func TestDownload_Segmented(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 32*1024) // 512KB
+	sum := sha256.Sum256(content)
+
+	newServer := func(ranges bool, gets *atomic.Int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				gets.Add(1)
+			}
+			if !ranges {
+				w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+				if r.Method == http.MethodGet {
+					_, _ = w.Write(content)
+				}
+				return
+			}
+			http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(content))
+		}))
+	}
+
+	t.Run("range capable", func(t *testing.T) {
+		var gets atomic.Int32
+		server := newServer(true, &gets)
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		opts := DefaultDownloadConfig()
+		opts.FilePath = filepath.Join(t.TempDir(), "segmented.bin")
+		opts.Concurrency = 4
+		opts.Checksum = hex.EncodeToString(sum[:])
+
+		result, err := client.DownloadWithOptions(server.URL, opts)
+		if err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if result.BytesWritten != int64(len(content)) {
+			t.Errorf("Expected %d bytes, got %d", len(content), result.BytesWritten)
+		}
+		if len(result.Segments) != 4 || gets.Load() != 4 {
+			t.Fatalf("Expected 4 segments and 4 GETs, got %d segments and %d GETs", len(result.Segments), gets.Load())
+		}
+		var total int64
+		for i, seg := range result.Segments {
+			if seg.Index != i || seg.BytesWritten != seg.End-seg.Start+1 || seg.Duration <= 0 {
+				t.Errorf("Unexpected segment %d: %+v", i, seg)
+			}
+			total += seg.BytesWritten
+		}
+		if total != int64(len(content)) {
+			t.Errorf("Segments cover %d bytes, want %d", total, len(content))
+		}
+
+		got, _ := os.ReadFile(opts.FilePath)
+		if !bytes.Equal(got, content) {
+			t.Error("Reassembled file does not match content")
+		}
+	})
+
+	t.Run("falls back without range support", func(t *testing.T) {
+		var gets atomic.Int32
+		server := newServer(false, &gets)
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		opts := DefaultDownloadConfig()
+		opts.FilePath = filepath.Join(t.TempDir(), "single.bin")
+		opts.Concurrency = 4
+
+		result, err := client.DownloadWithOptions(server.URL, opts)
+		if err != nil {
+			t.Fatalf("Download failed: %v", err)
+		}
+		if result.Segments != nil || gets.Load() != 1 {
+			t.Errorf("Expected single stream, got %d segments and %d GETs", len(result.Segments), gets.Load())
+		}
+		if result.BytesWritten != int64(len(content)) {
+			t.Errorf("Expected %d bytes, got %d", len(content), result.BytesWritten)
+		}
+	})
+}
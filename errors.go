@@ -56,6 +56,18 @@ var (
 	// Use errors.Is(err, httpc.ErrClientClosed) to detect this condition.
 	ErrClientClosed = engine.ErrClientClosed
 
+	// ErrStatusLineTooLong is returned when a server's status line exceeds
+	// Connection.MaxStatusLineLength.
+	ErrStatusLineTooLong = engine.ErrStatusLineTooLong
+
+	// ErrAmbiguousFraming is returned under Security.StrictFraming when a
+	// response has both Content-Length and Transfer-Encoding headers.
+	ErrAmbiguousFraming = engine.ErrAmbiguousFraming
+
+	// ErrRedirectTimeout is returned when following redirects takes longer
+	// than Middleware.MaxRedirectTime. It wraps context.DeadlineExceeded.
+	ErrRedirectTimeout = engine.ErrRedirectTimeout
+
 	// ErrNilConfig is returned when a nil configuration is provided.
 	// Always provide a valid Config or use DefaultConfig().
 	ErrNilConfig = errors.New("config cannot be nil")
@@ -88,6 +100,10 @@ var (
 	// MaxRedirects must be 0-50, UserAgent must not exceed length limit.
 	ErrInvalidMiddleware = errors.New("invalid middleware configuration")
 
+	// ErrCookiesDisabled is returned by SaveCookies and LoadCookies when the
+	// client has no cookie jar. Set Connection.EnableCookies in Config.
+	ErrCookiesDisabled = errors.New("cookies are disabled")
+
 	// ErrEmptyFilePath is returned when file path is empty.
 	// Provide a valid file path for download operations.
 	ErrEmptyFilePath = errors.New("file path cannot be empty")
@@ -96,6 +112,10 @@ var (
 	// Set Overwrite=true or ResumeDownload=true in DownloadConfig.
 	ErrFileExists = errors.New("file already exists")
 
+	// ErrChecksumMismatch is returned when a downloaded file does not match
+	// DownloadConfig.Checksum. The file is removed unless KeepCorruptFile is set.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+
 	// ErrResponseBodyEmpty is returned when attempting to parse empty response body.
 	// Check response.RawBody before calling Unmarshal() or other parsing methods.
 	ErrResponseBodyEmpty = errors.New("response body is empty")
@@ -103,4 +123,13 @@ var (
 	// ErrResponseBodyTooLarge is returned when response body exceeds size limit.
 	// Increase MaxResponseBodySize in Config or reduce response size.
 	ErrResponseBodyTooLarge = errors.New("response body too large")
+
+	// ErrUnexpectedHTML is returned by Result.Unmarshal when the body is an
+	// HTML page rather than JSON, typically a login or error page from a
+	// proxy. The error includes the start of the body.
+	ErrUnexpectedHTML = errors.New("expected JSON but got HTML")
+
+	// ErrUnsupportedContentType is returned by Result.Into when the response
+	// Content-Type is neither JSON nor XML. Decode the body explicitly instead.
+	ErrUnsupportedContentType = errors.New("unsupported content type")
 )
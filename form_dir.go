@@ -0,0 +1,190 @@
+package httpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cybergodev/httpc/internal/engine"
+)
+
+// FormDataFromDir walks root and returns a FormData with one file part per
+// regular file. Each part's field name is the file's slash-separated path
+// relative to root and its Content-Type is detected with
+// http.DetectContentType. File contents are streamed when the request is sent,
+// not read into memory here.
+//
+// include and exclude are glob patterns (see path.Match). A pattern without a
+// slash matches the file name, e.g. "*.txt"; a pattern with a slash matches
+// the relative path, e.g. "docs/*.md". When include is non-empty only matching
+// files are added; files matching exclude are always skipped.
+//
+// Symlinks to files inside root are followed; symlinked directories are not
+// descended into. Returns an error if a symlink resolves outside root, a
+// pattern is malformed, or no files match.
+func FormDataFromDir(root string, include, exclude []string) (*FormData, error) {
+	for _, p := range append(include[:len(include):len(include)], exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	realRoot, err = filepath.Abs(realRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	form := &FormData{
+		Fields: make(map[string]string),
+		Files:  make(map[string]*FileData),
+	}
+	err = filepath.WalkDir(realRoot, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(realRoot, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := filepath.EvalSymlinks(p)
+			if err != nil {
+				return fmt.Errorf("failed to resolve symlink %s: %w", rel, err)
+			}
+			if within, err := filepath.Rel(realRoot, target); err != nil || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) {
+				return fmt.Errorf("symlink %s escapes directory %s", rel, root)
+			}
+			if info, err = os.Stat(target); err != nil {
+				return err
+			}
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if !matchDirFilter(rel, include, exclude) {
+			return nil
+		}
+
+		contentType, err := detectFileContentType(p)
+		if err != nil {
+			return err
+		}
+		form.Files[rel] = &FileData{
+			Filename:    path.Base(rel),
+			ContentType: contentType,
+			Reader:      &lazyFileReader{path: p},
+			Size:        info.Size(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+	if len(form.Files) == 0 {
+		return nil, fmt.Errorf("no files to upload in %s", root)
+	}
+	return form, nil
+}
+
+// WithFormDataFromDir sets the request body to a multipart form containing the
+// files under root, as built by FormDataFromDir.
+//
+// Example:
+//
+//	result, err := client.Post(url,
+//	    httpc.WithFormDataFromDir("./site", []string{"*.html", "*.css"}, []string{"drafts/*"}))
+func WithFormDataFromDir(root string, include, exclude []string) RequestOption {
+	return func(r *engine.Request) error {
+		form, err := FormDataFromDir(root, include, exclude)
+		if err != nil {
+			return err
+		}
+		r.SetBody(form)
+		return nil
+	}
+}
+
+// matchDirFilter applies the include and exclude patterns to rel.
+func matchDirFilter(rel string, include, exclude []string) bool {
+	matches := func(patterns []string) bool {
+		for _, p := range patterns {
+			name := rel
+			if !strings.Contains(p, "/") {
+				name = path.Base(rel)
+			}
+			if ok, _ := path.Match(p, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(include) > 0 && !matches(include) {
+		return false
+	}
+	return !matches(exclude)
+}
+
+// detectFileContentType sniffs the first 512 bytes of the file at p.
+func detectFileContentType(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// lazyFileReader opens its file on first Read and closes it at EOF or on
+// error, so a form built from many files holds at most one open descriptor
+// at a time while it is being sent.
+type lazyFileReader struct {
+	path string
+	f    *os.File
+	done bool
+}
+
+func (r *lazyFileReader) Read(p []byte) (int, error) {
+	if r.done {
+		return 0, io.EOF
+	}
+	if r.f == nil {
+		f, err := os.Open(r.path)
+		if err != nil {
+			r.done = true
+			return 0, err
+		}
+		r.f = f
+	}
+	n, err := r.f.Read(p)
+	if err != nil {
+		_ = r.f.Close()
+		r.f, r.done = nil, true
+	}
+	return n, err
+}
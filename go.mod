@@ -2,4 +2,8 @@ module github.com/cybergodev/httpc
 
 go 1.25.0
 
-require golang.org/x/sys v0.44.0
+require (
+	golang.org/x/crypto v0.51.0
+	golang.org/x/sys v0.44.0
+	golang.org/x/text v0.37.0
+)
@@ -0,0 +1,96 @@
+package httpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cybergodev/httpc/internal/engine"
+)
+
+// graphQLRequest is the JSON body of a GraphQL-over-HTTP request.
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// GraphQLLocation is a line and column in the query document.
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is a single entry of a GraphQL response's "errors" array.
+type GraphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []GraphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+// GraphQLErrors is the "errors" array of a GraphQL response. Result.GraphQL
+// returns it as an error when non-empty; use errors.As to inspect entries.
+type GraphQLErrors []GraphQLError
+
+// Error returns the first message, noting how many more errors there are.
+func (e GraphQLErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "graphql: no errors"
+	case 1:
+		return "graphql: " + e[0].Message
+	default:
+		return fmt.Sprintf("graphql: %s (and %d more errors)", e[0].Message, len(e)-1)
+	}
+}
+
+// WithGraphQL sets the request body to a GraphQL query as JSON,
+// {"query":...,"variables":...,"operationName":...}, and sets Content-Type
+// to application/json. variables and operationName are omitted when empty.
+// Send it with POST and decode the response with Result.GraphQL:
+//
+//	result, err := client.Post(url, httpc.WithGraphQL(
+//	    `query User($id: ID!) { user(id: $id) { name } }`,
+//	    map[string]any{"id": "42"}, "User"))
+//
+// Returns an error if query is empty.
+func WithGraphQL(query string, variables map[string]any, operationName string) RequestOption {
+	return func(r *engine.Request) error {
+		if query == "" {
+			return fmt.Errorf("GraphQL query cannot be empty")
+		}
+		r.SetBody(graphQLRequest{Query: query, Variables: variables, OperationName: operationName})
+		r.SetHeader("Content-Type", "application/json")
+		return nil
+	}
+}
+
+// GraphQL decodes a GraphQL response, unmarshaling its "data" field into
+// data and storing its "errors" array in errs. Either argument may be nil
+// to skip it. A null or missing "data" leaves data unchanged.
+//
+// The status code is not checked, since servers report GraphQL errors with
+// both 200 and 4xx statuses. Returns the GraphQLErrors when the "errors"
+// array is non-empty, even if data was also decoded (a partial result), or
+// the errors documented on Unmarshal.
+func (r *Result) GraphQL(data any, errs *GraphQLErrors) error {
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := r.Unmarshal(&envelope); err != nil {
+		return err
+	}
+	if errs != nil {
+		*errs = envelope.Errors
+	}
+	if data != nil && len(envelope.Data) > 0 && string(envelope.Data) != "null" {
+		if err := json.Unmarshal(envelope.Data, data); err != nil {
+			return err
+		}
+	}
+	if len(envelope.Errors) > 0 {
+		return envelope.Errors
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package httpc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ============================================================================
+// GRAPHQL TESTS
+// ============================================================================
+
+func TestGraphQL(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected application/json, got %q", ct)
+		}
+		var req struct {
+			Query         string         `json:"query"`
+			Variables     map[string]any `json:"variables"`
+			OperationName string         `json:"operationName"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Invalid request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if req.OperationName == "Broken" {
+			_, _ = w.Write([]byte(`{"data":{"user":null},"errors":[` +
+				`{"message":"user not found","path":["user"],"locations":[{"line":1,"column":3}]},` +
+				`{"message":"second"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"user":{"name":"` + req.Variables["id"].(string) + `-name"}}}`))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	type data struct {
+		User *struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := client.Post(server.URL, WithGraphQL(`query User($id: ID!) { user(id: $id) { name } }`,
+			map[string]any{"id": "42"}, "User"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var got data
+		var errs GraphQLErrors
+		if err := result.GraphQL(&got, &errs); err != nil {
+			t.Fatalf("GraphQL failed: %v", err)
+		}
+		if got.User == nil || got.User.Name != "42-name" || errs != nil {
+			t.Errorf("Unexpected result: data=%+v errors=%v", got.User, errs)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		result, err := client.Post(server.URL, WithGraphQL(`{ user { name } }`, map[string]any{"id": "x"}, "Broken"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var got data
+		var errs GraphQLErrors
+		err = result.GraphQL(&got, &errs)
+		var gqlErr GraphQLErrors
+		if !errors.As(err, &gqlErr) || len(gqlErr) != 2 {
+			t.Fatalf("Expected GraphQLErrors with 2 entries, got %v", err)
+		}
+		if err.Error() != "graphql: user not found (and 1 more errors)" {
+			t.Errorf("Unexpected message %q", err.Error())
+		}
+		if len(errs) != 2 || errs[0].Locations[0].Line != 1 || errs[0].Path[0] != "user" {
+			t.Errorf("Expected errs to be filled, got %+v", errs)
+		}
+		if got.User != nil {
+			t.Errorf("Expected null user, got %+v", got.User)
+		}
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		if _, err := client.Post(server.URL, WithGraphQL("", nil, "")); err == nil {
+			t.Error("Expected error for empty query")
+		}
+	})
+}
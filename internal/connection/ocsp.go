@@ -0,0 +1,94 @@
+package connection
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// ocspClockSkew tolerates small clock differences when checking the validity
+// window of a stapled OCSP response.
+const ocspClockSkew = 5 * time.Minute
+
+// requireOCSPStaple returns a tls.Config.VerifyConnection callback that rejects
+// connections without a valid stapled OCSP response. An existing callback, if
+// any, runs first.
+func requireOCSPStaple(next func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if next != nil {
+			if err := next(cs); err != nil {
+				return err
+			}
+		}
+		// Resumed sessions skip certificate exchange and carry no staple;
+		// the staple was checked on the full handshake.
+		if cs.DidResume {
+			return nil
+		}
+		return verifyOCSPStaple(cs, time.Now())
+	}
+}
+
+// verifyOCSPStaple checks that cs carries a stapled OCSP response for the
+// leaf certificate, signed by its issuer, reporting it as good and still
+// within its validity window at now.
+func verifyOCSPStaple(cs tls.ConnectionState, now time.Time) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("OCSP stapling required: no peer certificate")
+	}
+	if len(cs.OCSPResponse) == 0 {
+		return errors.New("OCSP stapling required: server did not staple an OCSP response")
+	}
+
+	leaf := cs.PeerCertificates[0]
+	issuer := ocspIssuer(cs)
+	if issuer == nil {
+		return errors.New("OCSP stapling required: issuer certificate unavailable")
+	}
+
+	resp, err := ocsp.ParseResponseForCert(cs.OCSPResponse, leaf, issuer)
+	if err != nil {
+		return fmt.Errorf("OCSP stapling required: invalid OCSP response: %w", err)
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+	case ocsp.Revoked:
+		return fmt.Errorf("OCSP stapling required: certificate revoked at %s", resp.RevokedAt.UTC().Format(time.RFC3339))
+	default:
+		return errors.New("OCSP stapling required: certificate status unknown")
+	}
+
+	if resp.ThisUpdate.After(now.Add(ocspClockSkew)) {
+		return errors.New("OCSP stapling required: OCSP response is not yet valid")
+	}
+	if !resp.NextUpdate.IsZero() && now.Add(-ocspClockSkew).After(resp.NextUpdate) {
+		return errors.New("OCSP stapling required: OCSP response has expired")
+	}
+	return nil
+}
+
+// ocspIssuer returns the certificate that issued the leaf, preferring the
+// verified chain. A self-signed leaf is its own issuer.
+func ocspIssuer(cs tls.ConnectionState) *x509.Certificate {
+	for _, chain := range cs.VerifiedChains {
+		if len(chain) > 1 {
+			return chain[1]
+		}
+		if len(chain) == 1 {
+			return chain[0]
+		}
+	}
+	if len(cs.PeerCertificates) > 1 {
+		return cs.PeerCertificates[1]
+	}
+	leaf := cs.PeerCertificates[0]
+	if leaf.CheckSignatureFrom(leaf) == nil {
+		return leaf
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package connection
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// ============================================================================
+// OCSP STAPLING TESTS
+// ============================================================================
+
+type ocspTestPKI struct {
+	caCert   *x509.Certificate
+	caKey    crypto.Signer
+	leafCert *x509.Certificate
+	leaf     tls.Certificate
+	roots    *x509.CertPool
+}
+
+func newOCSPTestPKI(t *testing.T) *ocspTestPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "httpc test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTmpl, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafCert, _ := x509.ParseCertificate(leafDER)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	return &ocspTestPKI{
+		caCert:   caCert,
+		caKey:    caKey,
+		leafCert: leafCert,
+		leaf:     tls.Certificate{Certificate: [][]byte{leafDER, caDER}, PrivateKey: leafKey, Leaf: leafCert},
+		roots:    roots,
+	}
+}
+
+func (p *ocspTestPKI) staple(t *testing.T, status int, nextUpdate time.Time) []byte {
+	t.Helper()
+	tmpl := ocsp.Response{
+		Status:       status,
+		SerialNumber: p.leafCert.SerialNumber,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   nextUpdate,
+	}
+	if status == ocsp.Revoked {
+		tmpl.RevokedAt = time.Now().Add(-time.Minute)
+	}
+	der, err := ocsp.CreateResponse(p.caCert, p.caCert, tmpl, p.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestPoolManager_RequireOCSPStapling(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TLS handshake test in short mode")
+	}
+
+	pki := newOCSPTestPKI(t)
+
+	newServer := func(staple []byte) *httptest.Server {
+		cert := pki.leaf
+		cert.OCSPStaple = staple
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+		server.StartTLS()
+		return server
+	}
+
+	tests := []struct {
+		name    string
+		staple  []byte
+		wantErr string
+	}{
+		{"good staple", pki.staple(t, ocsp.Good, time.Now().Add(time.Hour)), ""},
+		{"no staple", nil, "did not staple"},
+		{"revoked", pki.staple(t, ocsp.Revoked, time.Now().Add(time.Hour)), "revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newServer(tt.staple)
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.AllowPrivateIPs = true
+			config.RequireOCSPStapling = true
+			config.TLSConfig = &tls.Config{RootCAs: pki.roots, MinVersion: tls.VersionTLS12}
+			pm, err := NewPoolManager(config)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			defer func() { _ = pm.Close() }()
+
+			client := &http.Client{Transport: pm.GetTransport(), Timeout: 5 * time.Second}
+			resp, err := client.Get(server.URL)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				_ = resp.Body.Close()
+				return
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+				t.Fatal("Expected handshake error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOCSPStaple_Expired(t *testing.T) {
+	pki := newOCSPTestPKI(t)
+	cs := tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{pki.leafCert, pki.caCert},
+		OCSPResponse:     pki.staple(t, ocsp.Good, time.Now().Add(time.Minute)),
+	}
+
+	if err := verifyOCSPStaple(cs, time.Now()); err != nil {
+		t.Fatalf("Expected fresh staple to verify, got: %v", err)
+	}
+	err := verifyOCSPStaple(cs, time.Now().Add(time.Hour))
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Expected expired error, got: %v", err)
+	}
+}
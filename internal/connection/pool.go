@@ -18,6 +18,7 @@ import (
 
 	"github.com/cybergodev/httpc/internal/dns"
 	"github.com/cybergodev/httpc/internal/proxy"
+	"github.com/cybergodev/httpc/internal/types"
 	"github.com/cybergodev/httpc/internal/validation"
 )
 
@@ -39,9 +40,11 @@ const maxHostEntries = 10000
 type PoolManager struct {
 	config *Config
 
-	transport   *http.Transport
-	dohResolver *dns.DoHResolver
-	proxyAddrs  []string
+	transport    *http.Transport
+	h2cTransport *http.Transport // Handles http:// URLs when AllowH2C is set
+	dohResolver  *dns.DoHResolver
+	proxyAddrs   []string
+	funcProxies  sync.Map // Proxy addresses returned by ProxyFunc (host:port -> struct{})
 
 	activeConns   int64
 	totalConns    int64
@@ -79,14 +82,32 @@ type Config struct {
 	IdleConnTimeout        time.Duration
 	ExpectContinueTimeout  time.Duration
 	MaxResponseHeaderBytes int64
+	MaxStatusLineLength    int  // Limit on HTTP/1.x status lines over cleartext; 0 disables
+	StrictFraming          bool // Reject cleartext HTTP/1.x responses with both Content-Length and Transfer-Encoding
 
 	TLSConfig          *tls.Config
+	ClientCertificates []tls.Certificate // Used when TLSConfig has no Certificates
+	RootCAs            *x509.CertPool    // Used when TLSConfig has no RootCAs
 	MinTLSVersion      uint16
 	MaxTLSVersion      uint16
 	InsecureSkipVerify bool
 
+	// RequireOCSPStapling rejects handshakes without a valid stapled OCSP response.
+	RequireOCSPStapling bool
+
 	EnableHTTP2 bool
+	AllowH2C    bool // Use HTTP/2 cleartext with prior knowledge for http:// URLs
 	ProxyURL    string
+	ProxyFunc   func(*url.URL) (*url.URL, error) // Chooses the proxy per request URL; overrides ProxyURL
+	UnixSocket  string                           // When set, every connection dials this Unix socket path
+
+	// Resolver replaces the system DNS resolver; DialContext replaces the
+	// net.Dialer and receives addresses after SSRF validation.
+	Resolver    *net.Resolver
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// DialPreference orders resolved addresses by IP family.
+	DialPreference types.DialPreference
 
 	// System proxy configuration
 	EnableSystemProxy bool // Automatically detect and use system proxy settings
@@ -183,8 +204,14 @@ func NewPoolManager(config *Config) (*PoolManager, error) {
 		forceAttemptHTTP2 = false
 	}
 
+	rawDial := pm.createDialer()
+	dial := rawDial
+	if config.MaxStatusLineLength > 0 || config.StrictFraming {
+		dial = inspectResponseHeads(dial, config.MaxStatusLineLength, config.StrictFraming)
+	}
+
 	transport := &http.Transport{
-		DialContext:            pm.createDialer(),
+		DialContext:            dial,
 		TLSHandshakeTimeout:    config.TLSHandshakeTimeout,
 		ResponseHeaderTimeout:  config.ResponseHeaderTimeout,
 		IdleConnTimeout:        config.IdleConnTimeout,
@@ -203,10 +230,22 @@ func NewPoolManager(config *Config) (*PoolManager, error) {
 	transport.TLSClientConfig = pm.createTLSConfig()
 
 	// Configure proxy settings with priority:
-	// 1. Manual proxy URL (highest priority)
-	// 2. System proxy detection (if enabled)
-	// 3. Direct connection (no proxy)
-	if config.ProxyURL != "" {
+	// 1. Proxy function (highest priority)
+	// 2. Manual proxy URL
+	// 3. System proxy detection (if enabled)
+	// 4. Direct connection (no proxy)
+	if config.ProxyFunc != nil {
+		proxyFunc := config.ProxyFunc
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			pu, err := proxyFunc(req.URL)
+			if err == nil && pu != nil {
+				// Like ProxyURL, proxies chosen by the developer are exempt
+				// from SSRF validation when dialed.
+				pm.funcProxies.Store(canonicalProxyAddr(pu), struct{}{})
+			}
+			return pu, err
+		}
+	} else if config.ProxyURL != "" {
 		proxyURL, err := url.Parse(config.ProxyURL)
 		if err != nil {
 			return nil, fmt.Errorf("invalid proxy URL: %w", err)
@@ -244,6 +283,18 @@ func NewPoolManager(config *Config) (*PoolManager, error) {
 	}
 	// If neither condition is met, transport.Proxy remains nil (direct connection)
 
+	if config.AllowH2C {
+		// A transport speaking only unencrypted HTTP/2 takes over http://
+		// URLs. It dials without response-head inspection, which parses
+		// HTTP/1.x and would misread HTTP/2 frames.
+		h2c := transport.Clone()
+		h2c.DialContext = rawDial
+		h2c.Protocols = new(http.Protocols)
+		h2c.Protocols.SetUnencryptedHTTP2(true)
+		transport.RegisterProtocol("http", h2c)
+		pm.h2cTransport = h2c
+	}
+
 	pm.transport = transport
 	return pm, nil
 }
@@ -253,9 +304,14 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 	dialer := &net.Dialer{
 		Timeout:   pm.config.DialTimeout,
 		KeepAlive: pm.config.KeepAlive,
+		Resolver:  pm.config.Resolver,
 		// Note: Control is not used here due to cross-platform compatibility issues.
 		// SSRF protection is implemented directly in the dialer function instead.
 	}
+	dial := dialer.DialContext
+	if pm.config.DialContext != nil {
+		dial = pm.config.DialContext
+	}
 
 	return func(ctx context.Context, network, address string) (net.Conn, error) {
 		if atomic.LoadInt32(&pm.closed) == 1 {
@@ -273,10 +329,14 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 		}
 		startTime := time.Now()
 
-		// Proxy connections bypass SSRF validation and DoH resolution —
-		// the proxy address is explicitly configured by the user.
-		if pm.isProxyAddr(address) {
-			conn, err := dialer.DialContext(ctx, network, address)
+		// Proxy and Unix socket connections bypass SSRF validation and DoH
+		// resolution — their addresses are explicitly configured by the user.
+		if pm.config.UnixSocket != "" || pm.isProxyAddr(address) {
+			kind := "proxy"
+			if pm.config.UnixSocket != "" {
+				kind, network, address = "unix socket", "unix", pm.config.UnixSocket
+			}
+			conn, err := dial(ctx, network, address)
 			connTime := time.Since(startTime).Nanoseconds()
 			stats := pm.updateConnectionMetrics(address, connTime, err == nil)
 
@@ -285,7 +345,7 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 				if pm.config.MaxTotalConns > 0 {
 					atomic.AddInt64(&pm.totalConns, -1)
 				}
-				return nil, fmt.Errorf("proxy connection failed: %w", err)
+				return nil, fmt.Errorf("%s connection failed: %w", kind, err)
 			}
 
 			atomic.AddInt64(&pm.activeConns, 1)
@@ -320,7 +380,8 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 			for i, addr := range ips {
 				resolvedIPs[i] = addr.IP
 			}
-			if !pm.config.AllowPrivateIPs {
+			orderByFamily(resolvedIPs, pm.config.DialPreference)
+			if !pm.allowPrivateIPs(ctx) {
 				allowedIPs := validation.FilterAllowedIPs(resolvedIPs, pm.config.ExemptNets)
 				if len(allowedIPs) == 0 {
 					atomic.AddInt64(&pm.rejectedConns, 1)
@@ -337,7 +398,7 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 			for _, ip := range resolvedIPs {
 				ipAddress := net.JoinHostPort(ip.String(), port)
 				attemptStart := time.Now()
-				conn, err := dialer.DialContext(ctx, network, ipAddress)
+				conn, err := dial(ctx, network, ipAddress)
 				connTime := time.Since(attemptStart).Nanoseconds()
 				stats := pm.updateConnectionMetrics(address, connTime, err == nil)
 
@@ -364,7 +425,7 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 		// SECURITY: Resolve DNS, validate all IPs, then dial the validated IP directly
 		// to prevent DNS rebinding TOCTOU attacks where an attacker-controlled DNS
 		// server returns a different IP between validation and actual connection.
-		if !pm.config.AllowPrivateIPs {
+		if !pm.allowPrivateIPs(ctx) {
 			validatedAddr, err := pm.resolveAndValidateAddress(address)
 			if err != nil {
 				atomic.AddInt64(&pm.rejectedConns, 1)
@@ -376,7 +437,17 @@ func (pm *PoolManager) createDialer() func(context.Context, string, string) (net
 			address = validatedAddr
 		}
 
-		conn, err := dialer.DialContext(ctx, network, address)
+		var conn net.Conn
+		var err error
+		if first, second, ok := pm.familyNetworks(network, address); ok {
+			// The system dialer would race both families; dial the
+			// preferred one first and fall back to the other.
+			if conn, err = dial(ctx, first, address); err != nil {
+				conn, err = dial(ctx, second, address)
+			}
+		} else {
+			conn, err = dial(ctx, network, address)
+		}
 		connTime := time.Since(startTime).Nanoseconds()
 		stats := pm.updateConnectionMetrics(address, connTime, err == nil)
 
@@ -429,7 +500,11 @@ func (pm *PoolManager) resolveAndValidateAddress(address string) (string, error)
 	}
 	dnsCtx, dnsCancel := context.WithTimeout(context.Background(), dnsTimeout)
 	defer dnsCancel()
-	ipAddrs, err := net.DefaultResolver.LookupIPAddr(dnsCtx, host)
+	resolver := net.DefaultResolver
+	if pm.config.Resolver != nil {
+		resolver = pm.config.Resolver
+	}
+	ipAddrs, err := resolver.LookupIPAddr(dnsCtx, host)
 	if err != nil {
 		return "", fmt.Errorf("DNS resolution failed for SSRF validation of %s: %w", host, err)
 	}
@@ -444,13 +519,88 @@ func (pm *PoolManager) resolveAndValidateAddress(address string) (string, error)
 	if len(allowedIPs) == 0 {
 		return "", fmt.Errorf("domain %s resolves only to blocked addresses", host)
 	}
+	orderByFamily(allowedIPs, pm.config.DialPreference)
 
 	// Return the first allowed IP for direct dialing to prevent DNS rebinding
 	return net.JoinHostPort(allowedIPs[0].String(), port), nil
 }
 
+// orderByFamily stably moves the addresses of the family preferred by pref
+// to the front of ips. DialDualStack leaves the resolver order unchanged.
+func orderByFamily(ips []net.IP, pref types.DialPreference) {
+	if pref == types.DialDualStack {
+		return
+	}
+	wantV4 := pref == types.DialPreferIPv4
+	slices.SortStableFunc(ips, func(a, b net.IP) int {
+		aPref := (a.To4() != nil) == wantV4
+		bPref := (b.To4() != nil) == wantV4
+		switch {
+		case aPref && !bPref:
+			return -1
+		case bPref && !aPref:
+			return 1
+		}
+		return 0
+	})
+}
+
+// familyNetworks returns the TCP networks to dial address with, preferred
+// family first, when a DialPreference applies to a host name dialed over
+// "tcp". ok is false for IP literals and under DialDualStack.
+func (pm *PoolManager) familyNetworks(network, address string) (first, second string, ok bool) {
+	if pm.config.DialPreference == types.DialDualStack || network != "tcp" {
+		return "", "", false
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return "", "", false
+	}
+	if pm.config.DialPreference == types.DialPreferIPv6 {
+		return "tcp6", "tcp4", true
+	}
+	return "tcp4", "tcp6", true
+}
+
+// privateIPsAllowedKey marks a dial context whose request may connect to
+// private addresses regardless of Config.AllowPrivateIPs.
+type privateIPsAllowedKey struct{}
+
+// WithPrivateIPsAllowed returns a context under which the dialer skips SSRF
+// filtering of resolved addresses, for a single request that has opted in.
+// The caller must keep the resulting connection out of the idle pool.
+func WithPrivateIPsAllowed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, privateIPsAllowedKey{}, true)
+}
+
+// allowPrivateIPs reports whether a dial under ctx may reach private addresses.
+func (pm *PoolManager) allowPrivateIPs(ctx context.Context) bool {
+	if pm.config.AllowPrivateIPs {
+		return true
+	}
+	allowed, _ := ctx.Value(privateIPsAllowedKey{}).(bool)
+	return allowed
+}
+
 func (pm *PoolManager) isProxyAddr(address string) bool {
-	return slices.Contains(pm.proxyAddrs, address)
+	if slices.Contains(pm.proxyAddrs, address) {
+		return true
+	}
+	_, ok := pm.funcProxies.Load(address)
+	return ok
+}
+
+// canonicalProxyAddr returns the host:port the transport dials for proxy u,
+// adding the scheme's default port when u has none.
+func canonicalProxyAddr(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
 }
 
 func (pm *PoolManager) createTLSConfig() *tls.Config {
@@ -461,6 +611,15 @@ func (pm *PoolManager) createTLSConfig() *tls.Config {
 		if pm.config.certPinner != nil {
 			tlsConfig.VerifyPeerCertificate = pm.createVerifyPeerCertificate(tlsConfig)
 		}
+		if pm.config.RequireOCSPStapling {
+			tlsConfig.VerifyConnection = requireOCSPStaple(tlsConfig.VerifyConnection)
+		}
+		if len(tlsConfig.Certificates) == 0 {
+			tlsConfig.Certificates = pm.config.ClientCertificates
+		}
+		if tlsConfig.RootCAs == nil {
+			tlsConfig.RootCAs = pm.config.RootCAs
+		}
 		return tlsConfig
 	}
 
@@ -468,6 +627,8 @@ func (pm *PoolManager) createTLSConfig() *tls.Config {
 		MinVersion:         pm.config.MinTLSVersion,
 		MaxVersion:         pm.config.MaxTLSVersion,
 		InsecureSkipVerify: pm.config.InsecureSkipVerify,
+		Certificates:       pm.config.ClientCertificates,
+		RootCAs:            pm.config.RootCAs,
 		CipherSuites: []uint16{
 			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
 			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
@@ -490,6 +651,9 @@ func (pm *PoolManager) createTLSConfig() *tls.Config {
 	if pm.config.certPinner != nil {
 		tlsConfig.VerifyPeerCertificate = pm.createVerifyPeerCertificate(tlsConfig)
 	}
+	if pm.config.RequireOCSPStapling {
+		tlsConfig.VerifyConnection = requireOCSPStaple(nil)
+	}
 
 	return tlsConfig
 }
@@ -687,6 +851,9 @@ func (pm *PoolManager) Close() error {
 	if pm.transport != nil {
 		pm.transport.CloseIdleConnections()
 	}
+	if pm.h2cTransport != nil {
+		pm.h2cTransport.CloseIdleConnections()
+	}
 
 	// Clean up per-host connection tracking map to prevent memory leak
 	pm.hostConns.Range(func(key, _ any) bool {
@@ -0,0 +1,192 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// ErrStatusLineTooLong is returned when a server's HTTP/1.x status line
+// exceeds Config.MaxStatusLineLength.
+var ErrStatusLineTooLong = errors.New("response status line too long")
+
+// ErrAmbiguousFraming is returned under Config.StrictFraming when a response
+// carries both Content-Length and Transfer-Encoding, which leaves its length
+// open to interpretation and is a request smuggling vector.
+var ErrAmbiguousFraming = errors.New("ambiguous response framing")
+
+// tlsHandshakeRecord is the first byte of a TLS ClientHello record.
+const tlsHandshakeRecord = 0x16
+
+// maxInspectedHead bounds the bytes buffered per response head; longer heads
+// are left to the transport's MaxResponseHeaderBytes limit.
+const maxInspectedHead = 1 << 20
+
+// inspectResponseHeads wraps dial so connections check each cleartext
+// HTTP/1.x response head: the status line against statusLineLimit (0 for no
+// limit) and, when strictFraming is set, the framing headers.
+func inspectResponseHeads(dial func(context.Context, string, string) (net.Conn, error), statusLineLimit int, strictFraming bool) func(context.Context, string, string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		conn, err := dial(ctx, network, address)
+		if err != nil {
+			return nil, err
+		}
+		return &responseHeadConn{Conn: conn, statusLineLimit: statusLineLimit, strictFraming: strictFraming}, nil
+	}
+}
+
+// responseHeadConn inspects the head of each response read after a request
+// line is written, failing the read once the status line exceeds
+// statusLineLimit or, under strictFraming, once a head declares both
+// Content-Length and Transfer-Encoding. The dialer sits below TLS, so a
+// connection whose first write is a TLS handshake is passed through
+// unchecked; so is a proxy tunnel once its CONNECT response has been read.
+// Without strictFraming only the status line is read; with it, interim 1xx
+// heads are skipped so the final response is checked too.
+type responseHeadConn struct {
+	net.Conn
+	statusLineLimit int
+	strictFraming   bool
+
+	mu       sync.Mutex
+	decided  bool   // whether the connection has been classified
+	tls      bool   // the connection carries TLS; never inspect it
+	connect  bool   // the pending request is a proxy CONNECT
+	scanning bool   // reading the head of the pending response
+	head     []byte // head bytes read so far
+}
+
+func (c *responseHeadConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	if !c.decided && len(p) > 0 {
+		c.decided = true
+		c.tls = p[0] == tlsHandshakeRecord
+	}
+	if !c.tls && isRequestLine(p) {
+		c.scanning, c.head = true, c.head[:0]
+		c.connect = bytes.HasPrefix(p, []byte("CONNECT "))
+	}
+	c.mu.Unlock()
+	return c.Conn.Write(p)
+}
+
+func (c *responseHeadConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.scanning || n == 0 {
+		return n, err
+	}
+	if ierr := c.inspect(p[:n]); ierr != nil {
+		return 0, ierr
+	}
+	return n, err
+}
+
+// inspect consumes data read from the connection while a head is pending.
+func (c *responseHeadConn) inspect(data []byte) error {
+	for c.scanning && len(data) > 0 {
+		searchFrom := max(len(c.head)-3, 0)
+		c.head = append(c.head, data...)
+
+		lineEnd := bytes.IndexByte(c.head, '\n')
+		if c.statusLineLimit > 0 {
+			if line := lineEnd; line > c.statusLineLimit || (line < 0 && len(c.head) > c.statusLineLimit) {
+				return fmt.Errorf("%w: more than %d bytes", ErrStatusLineTooLong, c.statusLineLimit)
+			}
+		}
+		if !c.strictFraming {
+			if lineEnd >= 0 {
+				c.finishHead()
+			}
+			return nil
+		}
+
+		end := headEnd(c.head, searchFrom)
+		if end < 0 {
+			if len(c.head) > maxInspectedHead {
+				c.scanning, c.head = false, nil
+			}
+			return nil
+		}
+		head := c.head[:end]
+		if hasHeader(head, "Content-Length") && hasHeader(head, "Transfer-Encoding") {
+			return fmt.Errorf("%w: response has both Content-Length and Transfer-Encoding", ErrAmbiguousFraming)
+		}
+		consumed := end - (len(c.head) - len(data))
+		if !isInterimHead(head) {
+			c.finishHead()
+			return nil
+		}
+		// The final response follows the interim one.
+		c.head = c.head[:0]
+		data = data[consumed:]
+	}
+	return nil
+}
+
+// finishHead ends inspection of the pending response.
+func (c *responseHeadConn) finishHead() {
+	c.scanning, c.head = false, c.head[:0]
+	if c.connect {
+		// The tunnelled traffic that follows is classified afresh.
+		c.decided, c.connect = false, false
+	}
+}
+
+// headEnd returns the index just past the blank line ending the head in b,
+// searching from offset from, or -1 if the head is incomplete.
+func headEnd(b []byte, from int) int {
+	for i := from; i < len(b); i++ {
+		if b[i] != '\n' {
+			continue
+		}
+		if i+1 < len(b) && b[i+1] == '\n' {
+			return i + 2
+		}
+		if i+2 < len(b) && b[i+1] == '\r' && b[i+2] == '\n' {
+			return i + 3
+		}
+	}
+	return -1
+}
+
+// hasHeader reports whether head contains a header field named name.
+func hasHeader(head []byte, name string) bool {
+	lines := bytes.Split(head, []byte("\n"))
+	for _, line := range lines[1:] {
+		if k, _, ok := bytes.Cut(line, []byte(":")); ok && bytes.EqualFold(bytes.TrimSpace(k), []byte(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInterimHead reports whether head is a 1xx response other than
+// 101 Switching Protocols, which a final response follows.
+func isInterimHead(head []byte) bool {
+	_, rest, ok := bytes.Cut(head, []byte(" "))
+	return ok && len(rest) >= 3 && rest[0] == '1' && !bytes.HasPrefix(rest, []byte("101"))
+}
+
+// isRequestLine reports whether p begins with an HTTP/1.x request line,
+// i.e. the start of a new request rather than body bytes.
+func isRequestLine(p []byte) bool {
+	line := p
+	if i := bytes.IndexByte(p, '\n'); i >= 0 {
+		line = p[:i]
+	}
+	sp := bytes.IndexByte(line, ' ')
+	if sp <= 0 {
+		return false
+	}
+	for _, b := range line[:sp] {
+		if b < 'A' || b > 'Z' {
+			return false
+		}
+	}
+	return bytes.Contains(line[sp:], []byte(" HTTP/1."))
+}
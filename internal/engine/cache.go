@@ -0,0 +1,456 @@
+package engine
+
+import (
+	"container/list"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultCacheMaxEntries is used when Config.CacheMaxEntries is 0.
+	defaultCacheMaxEntries = 1000
+	// defaultCacheMaxBytes is used when Config.CacheMaxBytes is 0.
+	defaultCacheMaxBytes int64 = 32 * 1024 * 1024 // 32MB
+)
+
+// responseCache is an in-memory LRU cache of GET/HEAD responses, bounded by
+// entry count and total body+header bytes. Entries are immutable once stored;
+// updates replace the entry, so readers never need the lock after get returns.
+type responseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+// cacheEntry is a stored response plus the metadata needed to serve or
+// revalidate it.
+type cacheEntry struct {
+	key          string
+	statusCode   int
+	status       string
+	proto        string
+	header       http.Header
+	body         []byte
+	storedAt     time.Time
+	lifetime     time.Duration // Freshness lifetime; 0 means revalidate on every use
+	varyNames    []string      // Canonical request header names from the Vary header
+	varyValues   []string      // Request header values the response was selected with
+	etag         string
+	lastModified string
+	size         int64
+}
+
+func newResponseCache(maxEntries int, maxBytes int64) *responseCache {
+	if maxEntries <= 0 {
+		maxEntries = defaultCacheMaxEntries
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultCacheMaxBytes
+	}
+	return &responseCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// get returns the entry for key and marks it most recently used.
+func (c *responseCache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	c.ll.MoveToFront(el)
+	entry, _ := el.Value.(*cacheEntry)
+	return entry
+}
+
+// put stores e, replacing any entry with the same key and evicting least
+// recently used entries until both bounds are met. Entries larger than the
+// byte bound are not stored.
+func (c *responseCache) put(e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeLocked(e.key)
+	if e.size > c.maxBytes {
+		return
+	}
+	c.items[e.key] = c.ll.PushFront(e)
+	c.size += e.size
+	for c.ll.Len() > c.maxEntries || c.size > c.maxBytes {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		if entry, ok := oldest.Value.(*cacheEntry); ok {
+			c.removeLocked(entry.key)
+		}
+	}
+}
+
+// remove deletes the entry for key, if any.
+func (c *responseCache) remove(key string) {
+	c.mu.Lock()
+	c.removeLocked(key)
+	c.mu.Unlock()
+}
+
+func (c *responseCache) removeLocked(key string) {
+	el, ok := c.items[key]
+	if !ok {
+		return
+	}
+	if entry, ok := el.Value.(*cacheEntry); ok {
+		c.size -= entry.size
+	}
+	c.ll.Remove(el)
+	delete(c.items, key)
+}
+
+// len returns the number of stored entries.
+func (c *responseCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// fresh reports whether the entry can be served without revalidation.
+func (e *cacheEntry) fresh(now time.Time) bool {
+	return e.lifetime > 0 && now.Sub(e.storedAt) < e.lifetime
+}
+
+// acceptableFor reports whether req's Cache-Control allows serving the entry
+// without revalidation: no-cache never does, and max-age bounds the age of
+// the stored response (RFC 9111 Section 5.2.1).
+func (e *cacheEntry) acceptableFor(req *Request, now time.Time) bool {
+	cc := parseCacheControl(requestHeader(req, "Cache-Control"))
+	if _, ok := cc["no-cache"]; ok {
+		return false
+	}
+	if v, ok := cc["max-age"]; ok {
+		secs, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || now.Sub(e.storedAt) >= time.Duration(secs)*time.Second {
+			return false
+		}
+	}
+	return true
+}
+
+// canRevalidate reports whether the entry carries a validator.
+func (e *cacheEntry) canRevalidate() bool {
+	return e.etag != "" || e.lastModified != ""
+}
+
+// matches reports whether req selects the same variant as the stored response.
+func (e *cacheEntry) matches(req *Request) bool {
+	for i, name := range e.varyNames {
+		if requestHeader(req, name) != e.varyValues[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// response builds a new Response from the entry. Body and headers are
+// copied so callers cannot mutate the cached data.
+func (e *cacheEntry) response(req *Request, url string) *Response {
+	resp := getResponse()
+	resp.statusCode = e.statusCode
+	resp.status = e.status
+	resp.proto = e.proto
+	resp.headers = CloneHeader(e.header)
+	resp.rawBody = slices.Clone(e.body)
+	resp.contentLength = int64(len(e.body))
+	resp.requestURL = url
+	resp.requestMethod = req.Method()
+	if len(req.headers) > 0 {
+		h := make(http.Header, len(req.headers))
+		for k, v := range req.headers {
+			h.Set(k, v)
+		}
+		resp.requestHeaders = h
+	}
+	return resp
+}
+
+// refreshed returns a copy of the entry updated with the headers of a
+// 304 Not Modified response, as required by RFC 9111 Section 4.3.4.
+func (e *cacheEntry) refreshed(header http.Header, now time.Time) *cacheEntry {
+	updated := *e
+	updated.header = CloneHeader(e.header)
+	for k, v := range header {
+		if k == "Content-Length" {
+			continue
+		}
+		updated.header[k] = slices.Clone(v)
+	}
+	updated.storedAt = now
+	updated.lifetime = freshnessLifetime(updated.header, now)
+	if v := updated.header.Get("Etag"); v != "" {
+		updated.etag = v
+	}
+	if v := updated.header.Get("Last-Modified"); v != "" {
+		updated.lastModified = v
+	}
+	updated.size = entrySize(updated.header, updated.body)
+	return &updated
+}
+
+// newCacheEntry returns an entry for resp, or nil if the response must not
+// be stored. The cache behaves as a shared cache: responses marked private,
+// responses to authenticated requests, and responses setting cookies are not
+// stored.
+func newCacheEntry(key string, req *Request, resp *Response, now time.Time) *cacheEntry {
+	// A followed redirect's final response belongs to another URL; storing
+	// it under the original key would outlive a change of the redirect.
+	if resp.rawBodyReader != nil || resp.redirectCount > 0 {
+		return nil
+	}
+	switch resp.statusCode {
+	case http.StatusOK, http.StatusNonAuthoritativeInfo, http.StatusNoContent,
+		http.StatusMultipleChoices, http.StatusMovedPermanently, http.StatusPermanentRedirect,
+		http.StatusNotFound, http.StatusGone:
+	default:
+		return nil
+	}
+	if requestHeader(req, "Authorization") != "" {
+		return nil
+	}
+	if _, noStore := parseCacheControl(requestHeader(req, "Cache-Control"))["no-store"]; noStore {
+		return nil
+	}
+
+	header := resp.headers
+	cc := parseCacheControl(strings.Join(header.Values("Cache-Control"), ","))
+	if _, ok := cc["no-store"]; ok {
+		return nil
+	}
+	if _, ok := cc["private"]; ok {
+		return nil
+	}
+	if len(responseCookies(header)) > 0 {
+		return nil
+	}
+
+	var varyNames []string
+	for _, v := range header.Values("Vary") {
+		for name := range strings.SplitSeq(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return nil
+			}
+			if name != "" {
+				varyNames = append(varyNames, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	entry := &cacheEntry{
+		key:          key,
+		statusCode:   resp.statusCode,
+		status:       resp.status,
+		proto:        resp.proto,
+		header:       CloneHeader(header),
+		body:         slices.Clone(resp.rawBody),
+		storedAt:     now,
+		lifetime:     freshnessLifetime(header, now),
+		varyNames:    varyNames,
+		etag:         header.Get("Etag"),
+		lastModified: header.Get("Last-Modified"),
+	}
+	if entry.lifetime <= 0 && !entry.canRevalidate() {
+		return nil
+	}
+	entry.varyValues = make([]string, len(varyNames))
+	for i, name := range varyNames {
+		entry.varyValues[i] = requestHeader(req, name)
+	}
+	entry.size = entrySize(entry.header, entry.body)
+	return entry
+}
+
+// freshnessLifetime computes how long a response stays fresh from its
+// Cache-Control max-age (or Expires) minus its Age. no-cache forces
+// revalidation on every use.
+func freshnessLifetime(header http.Header, now time.Time) time.Duration {
+	cc := parseCacheControl(strings.Join(header.Values("Cache-Control"), ","))
+	if _, ok := cc["no-cache"]; ok {
+		return 0
+	}
+
+	var lifetime time.Duration
+	if v, ok := cc["max-age"]; ok {
+		secs, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || secs <= 0 {
+			return 0
+		}
+		lifetime = time.Duration(secs) * time.Second
+	} else if v := header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return 0
+		}
+		base := now
+		if date, err := http.ParseTime(header.Get("Date")); err == nil {
+			base = date
+		}
+		lifetime = expires.Sub(base)
+	}
+
+	if age, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && age > 0 {
+		lifetime -= time.Duration(age) * time.Second
+	}
+	return max(lifetime, 0)
+}
+
+// parseCacheControl splits a Cache-Control header value into lowercase
+// directive names mapped to their (unquoted) values.
+func parseCacheControl(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	directives := make(map[string]string, 4)
+	for part := range strings.SplitSeq(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, val, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(val), `"`)
+	}
+	return directives
+}
+
+// requestHeader looks up a request header case-insensitively.
+func requestHeader(req *Request, name string) string {
+	if v, ok := req.headers[name]; ok {
+		return v
+	}
+	for k, v := range req.headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// entrySize estimates the memory held by a cache entry.
+func entrySize(header http.Header, body []byte) int64 {
+	size := int64(len(body))
+	for k, vs := range header {
+		size += int64(len(k))
+		for _, v := range vs {
+			size += int64(len(v))
+		}
+	}
+	return size
+}
+
+// cacheRequestURL returns the request URL including query parameters added
+// via options, with parameters in sorted order so the key is deterministic.
+func cacheRequestURL(req *Request) string {
+	if len(req.queryParams) == 0 {
+		return req.url
+	}
+	keys := make([]string, 0, len(req.queryParams))
+	for k := range req.queryParams {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	sb := getQueryBuilder()
+	defer putQueryBuilder(sb)
+	sb.WriteString(req.url)
+	if strings.IndexByte(req.url, '?') >= 0 {
+		sb.WriteByte('&')
+	} else {
+		sb.WriteByte('?')
+	}
+	var numBuf [32]byte
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+		writeQueryParam(sb, k, req.queryParams[k], numBuf[:0])
+	}
+	return sb.String()
+}
+
+// cacheable reports whether req may be served from or stored in the cache.
+func (c *Client) cacheable(req *Request) bool {
+	if c.cache == nil || req.noCache || req.streamBody {
+		return false
+	}
+	method := req.method
+	return method == "" || method == http.MethodGet || method == http.MethodHead
+}
+
+// sendCached serves req from the cache when a fresh entry exists and the
+// request's Cache-Control accepts it. Otherwise
+// it fetches from the network, revalidating a stale entry with
+// If-None-Match/If-Modified-Since (a 304 refreshes the entry and is served
+// from it), and stores cacheable responses.
+//
+// Responses served from the cache report 0 attempts.
+func (c *Client) sendCached(req *Request) (*Response, error) {
+	reqURL := cacheRequestURL(req)
+	method := req.method
+	if method == "" {
+		method = http.MethodGet
+	}
+	key := method + " " + reqURL
+	now := time.Now()
+
+	entry := c.cache.get(key)
+	if entry != nil && !entry.matches(req) {
+		entry = nil
+	}
+	if entry != nil && entry.fresh(now) && entry.acceptableFor(req, now) {
+		return entry.response(req, reqURL), nil
+	}
+
+	// Revalidate a stale entry unless the caller sent its own conditions,
+	// in which case a 304 belongs to the caller.
+	revalidating := entry != nil && entry.canRevalidate() &&
+		requestHeader(req, "If-None-Match") == "" && requestHeader(req, "If-Modified-Since") == ""
+	if revalidating {
+		if entry.etag != "" {
+			req.SetHeader("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.SetHeader("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	resp, err := c.fetch(req)
+	if err != nil {
+		return nil, err
+	}
+
+	now = time.Now()
+	if revalidating && resp.statusCode == http.StatusNotModified {
+		updated := entry.refreshed(resp.headers, now)
+		c.cache.put(updated)
+		out := updated.response(req, reqURL)
+		out.attempts = resp.attempts
+		ReleaseResponse(resp)
+		return out, nil
+	}
+
+	if stored := newCacheEntry(key, req, resp, now); stored != nil {
+		c.cache.put(stored)
+	} else if entry != nil {
+		c.cache.remove(key)
+	}
+	return resp, nil
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// RESPONSE CACHE UNIT TESTS
+// ============================================================================
+
+func TestResponseCache_LRUBounds(t *testing.T) {
+	entry := func(key string, bodySize int) *cacheEntry {
+		e := &cacheEntry{key: key, body: make([]byte, bodySize)}
+		e.size = entrySize(e.header, e.body)
+		return e
+	}
+
+	t.Run("entry count", func(t *testing.T) {
+		c := newResponseCache(2, 1024)
+		c.put(entry("a", 1))
+		c.put(entry("b", 1))
+		c.get("a") // a becomes most recently used
+		c.put(entry("c", 1))
+
+		if c.get("b") != nil {
+			t.Error("least recently used entry b should be evicted")
+		}
+		if c.get("a") == nil || c.get("c") == nil {
+			t.Error("entries a and c should remain")
+		}
+	})
+
+	t.Run("total bytes", func(t *testing.T) {
+		c := newResponseCache(10, 100)
+		c.put(entry("a", 60))
+		c.put(entry("b", 60))
+		if c.len() != 1 || c.get("b") == nil {
+			t.Errorf("expected only b to remain, have %d entries", c.len())
+		}
+
+		c.put(entry("huge", 200))
+		if c.get("huge") != nil {
+			t.Error("entry larger than the byte bound should not be stored")
+		}
+	})
+}
+
+func TestFreshnessLifetime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"max-age", http.Header{"Cache-Control": {"public, max-age=120"}}, 120 * time.Second},
+		{"max-age minus age", http.Header{"Cache-Control": {"max-age=120"}, "Age": {"20"}}, 100 * time.Second},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache, max-age=120"}}, 0},
+		{"expires", http.Header{
+			"Date":    {now.Format(http.TimeFormat)},
+			"Expires": {now.Add(time.Minute).Format(http.TimeFormat)},
+		}, time.Minute},
+		{"invalid expires", http.Header{"Expires": {"0"}}, 0},
+		{"none", http.Header{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freshnessLifetime(tt.header, now); got != tt.want {
+				t.Errorf("freshnessLifetime = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"context"
+	"sync"
+)
+
+// cancelGroups tracks the in-flight requests of each named cancellation group.
+type cancelGroups struct {
+	mu     sync.Mutex
+	groups map[string]map[*groupMember]struct{}
+}
+
+// groupMember is one in-flight request in a cancellation group.
+type groupMember struct {
+	cancel context.CancelFunc
+}
+
+// join derives a cancellable context for req, registers it under req's
+// cancel group, and returns a function that leaves the group and releases
+// the context. The returned function is safe to call more than once.
+func (g *cancelGroups) join(req *Request) func() {
+	parent := req.Context()
+	if parent == nil {
+		parent = backgroundCtx
+	}
+	ctx, cancel := context.WithCancel(parent)
+	req.SetContext(ctx)
+
+	name := req.cancelGroup
+	m := &groupMember{cancel: cancel}
+	g.mu.Lock()
+	if g.groups == nil {
+		g.groups = make(map[string]map[*groupMember]struct{})
+	}
+	members := g.groups[name]
+	if members == nil {
+		members = make(map[*groupMember]struct{})
+		g.groups[name] = members
+	}
+	members[m] = struct{}{}
+	g.mu.Unlock()
+
+	return func() {
+		g.mu.Lock()
+		if members := g.groups[name]; members != nil {
+			delete(members, m)
+			if len(members) == 0 {
+				delete(g.groups, name)
+			}
+		}
+		g.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel cancels every in-flight request in the named group.
+func (g *cancelGroups) cancel(name string) {
+	g.mu.Lock()
+	members := g.groups[name]
+	delete(g.groups, name)
+	g.mu.Unlock()
+
+	for m := range members {
+		m.cancel()
+	}
+}
+
+// CancelGroup cancels every in-flight request that joined the named group
+// via SetCancelGroup, including streamed responses whose bodies are still
+// being read. Their calls fail with context.Canceled. Requests started
+// afterwards join the group afresh and are unaffected.
+func (c *Client) CancelGroup(name string) {
+	c.cancelGroups.cancel(name)
+}
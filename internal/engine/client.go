@@ -5,13 +5,20 @@ package engine
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"maps"
+	"math/rand/v2"
 	"net"
 	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -153,6 +160,7 @@ type Client struct {
 	responseProcessor *responseProcessor
 	retryEngine       *retryEngine
 	validator         *security.Validator
+	clock             types.Clock
 
 	connectionPool *connection.PoolManager
 
@@ -166,6 +174,15 @@ type Client struct {
 	// metrics tracks request statistics
 	metrics *metrics
 
+	// rateLimiter enforces per-host request rates (Config.RateLimit, WithRateLimit)
+	rateLimiter *hostRateLimiter
+
+	// cache stores GET/HEAD responses; nil unless Config.EnableCache is set
+	cache *responseCache
+
+	// cancelGroups tracks in-flight requests by cancellation group (SetCancelGroup)
+	cancelGroups cancelGroups
+
 	closed int32
 
 	closeOnce sync.Once
@@ -175,24 +192,35 @@ type Client struct {
 // Config should be treated as immutable after creation.
 type Config struct {
 	Timeout                time.Duration
+	TimeoutPerMB           time.Duration // Added to Timeout per megabyte of declared request body
+	TimeoutJitter          time.Duration // Each request's timeout is offset by a random amount in ±TimeoutJitter
 	DialTimeout            time.Duration
 	KeepAlive              time.Duration
 	TLSHandshakeTimeout    time.Duration
 	ResponseHeaderTimeout  time.Duration
 	IdleConnTimeout        time.Duration
 	MaxResponseHeaderBytes int64
+	MaxStatusLineLength    int
 	MaxIdleConns           int
 	MaxIdleConnsPerHost    int
 	MaxConnsPerHost        int
 	ProxyURL               string
+	ProxyFunc              func(*url.URL) (*url.URL, error) // Per-request proxy; overrides ProxyURL
+	UnixSocket             string                           // Dial this socket path instead of the request host
+	Resolver               *net.Resolver
+	DialContext            func(ctx context.Context, network, addr string) (net.Conn, error)
+	DialPreference         types.DialPreference
 
 	// System proxy configuration
 	EnableSystemProxy bool // Automatically detect and use system proxy settings
 
 	TLSConfig               *tls.Config
+	ClientCertificates      []tls.Certificate // Presented for mutual TLS
+	RootCAs                 *x509.CertPool    // Server verification roots; nil uses the system pool
 	MinTLSVersion           uint16
 	MaxTLSVersion           uint16
 	InsecureSkipVerify      bool
+	RequireOCSPStapling     bool
 	MaxResponseBodySize     int64
 	MaxRequestBodySize      int64
 	MaxDecompressedBodySize int64
@@ -201,22 +229,83 @@ type Config struct {
 	AllowPrivateIPs         bool
 	ExemptNets              []*net.IPNet
 	StrictContentLength     bool
+	StrictEncoding          bool // Reject Content-Encodings not advertised in Accept-Encoding
+	StrictFraming           bool // Reject responses with both Content-Length and Transfer-Encoding
+	AllowHopByHopHeaders    bool // Permit caller-set hop-by-hop headers such as Transfer-Encoding
+	AllowGetBody            bool // Permit a request body on GET and HEAD requests
+	KeepCompressedBody      bool // Retain the encoded body bytes alongside the decoded body
+	SniffCompression        bool // Decompress gzip-magic bodies sent without Content-Encoding
 
 	MaxRetries    int
 	RetryDelay    time.Duration
 	MaxRetryDelay time.Duration
+	RetryBudget   time.Duration // Total time across attempts and delays; 0 for none
 	BackoffFactor float64
 	Jitter        bool
+	JitterRand    *rand.Rand // Random source for jitter; nil uses the global source
+
+	// JitterStrategy selects the jitter applied to backoff delays;
+	// JitterAuto follows Jitter.
+	JitterStrategy types.JitterStrategy
+
+	// RetryableStatusCodes replaces the default retryable statuses
+	// (408, 429, 500, 502, 503, 504) when non-nil.
+	RetryableStatusCodes []int
+	// RetryableMethods lists the methods that may be retried when non-nil;
+	// nil allows the idempotent methods.
+	RetryableMethods []string
 
 	// CustomRetryPolicy allows providing a custom retry policy implementation.
 	// If set, it overrides the built-in retry logic.
 	CustomRetryPolicy types.RetryPolicy
 
+	// RetryIf, when set, replaces the retry policy's ShouldRetry decision.
+	RetryIf types.RetryIfFunc
+	// RetryJSONField and RetryJSONValue additionally retry buffered
+	// responses whose JSON body has the field set to the value.
+	RetryJSONField string
+	RetryJSONValue any
+
+	// Tracer receives request lifecycle callbacks. Nil disables tracing.
+	Tracer types.Tracer
+
+	// Clock times retry backoff and request durations. Nil uses real time.
+	Clock types.Clock
+
+	// TokenSource supplies bearer tokens for requests without their own
+	// Authorization header. Nil disables it.
+	TokenSource types.TokenSource
+
+	// RateLimit caps requests per second to each host (0 disables);
+	// RateLimitBurst is the bucket size (minimum 1).
+	RateLimit      float64
+	RateLimitBurst int
+
+	// EnableCache enables the in-memory HTTP response cache, bounded by
+	// CacheMaxEntries and CacheMaxBytes (0 uses the defaults).
+	EnableCache     bool
+	CacheMaxEntries int
+	CacheMaxBytes   int64
+
+	// RetainResponseHeaders, when non-empty, is the set of response headers
+	// kept on Response; the rest are dropped.
+	RetainResponseHeaders []string
+
 	UserAgent       string
 	Headers         map[string]string
 	FollowRedirects bool
 	MaxRedirects    int
-	EnableHTTP2     bool
+	MaxRedirectTime time.Duration // Cap on time spent following redirects; 0 for none
+	// CheckRedirect, when set, is consulted for each redirect after the
+	// built-in checks pass and sensitive headers have been stripped.
+	CheckRedirect types.CheckRedirectFunc
+	EnableHTTP2   bool
+	AllowH2C      bool // Send http:// requests over HTTP/2 cleartext with prior knowledge
+
+	// DisableRefererOnRedirect drops the Referer header http.Client adds on
+	// each redirect hop, so only a caller-set Referer is sent. Referer is
+	// never sent on an https to http downgrade.
+	DisableRefererOnRedirect bool
 
 	CookieJar     http.CookieJar
 	EnableCookies bool
@@ -238,6 +327,14 @@ type requestCallback func(req *Request) error
 // responseCallback is a callback function invoked after a response is received.
 type responseCallback func(resp *Response) error
 
+// rawRequestCallback is a callback function that mutates the built
+// *http.Request just before it is sent.
+type rawRequestCallback func(req *http.Request) error
+
+// authChallengeCallback answers a 401 response with the Authorization header
+// value to resend the request with, or ok=false to return the 401 as is.
+type authChallengeCallback func(resp *Response) (authorization string, ok bool)
+
 // Request represents an HTTP request with method, URL, headers, body, and options.
 type Request struct {
 	method          string
@@ -246,6 +343,7 @@ type Request struct {
 	queryParams     map[string]any
 	body            any
 	timeout         time.Duration
+	deadline        time.Time // Absolute end of all attempts; zero for none
 	maxRetries      int
 	context         context.Context
 	cookies         []http.Cookie
@@ -253,8 +351,29 @@ type Request struct {
 	maxRedirects    *int
 	onRequest       requestCallback
 	onResponse      responseCallback
-	streamBody      bool   // When true, skip buffering response body; caller reads via RawBodyReader
-	sanitizedURL    string // Cached per-request sanitized URL, set by middleware on first access
+	streamBody      bool                    // When true, skip buffering response body; caller reads via RawBodyReader
+	sameHostOnly    bool                    // When true, redirects leaving the original host are not followed
+	omitCookies     []string                // Cookie names stripped from the outgoing Cookie header
+	returnLastRedir bool                    // When true, exceeding maxRedirects returns the last 3xx instead of an error
+	rateLimit       float64                 // Per-request requests/second override; 0 uses Config.RateLimit
+	rateBurst       int                     // Burst size for rateLimit
+	noCache         bool                    // When true, bypass the response cache
+	earlyHints      bool                    // When true, capture headers of 103 Early Hints responses
+	retryStatuses   []int                   // Per-request retryable status codes; nil uses Config.RetryableStatusCodes
+	retryMethods    []string                // Per-request retryable methods; nil uses Config.RetryableMethods
+	retryIf         types.RetryIfFunc       // Per-request retry decision; nil uses Config.RetryIf
+	cancelGroup     string                  // Cancellation group joined while in flight; "" for none
+	bodyTee         io.Writer               // Receives a copy of the request body as it is sent
+	rawRequest      rawRequestCallback      // Mutates the built *http.Request just before it is sent
+	host            string                  // Host header override; "" uses the URL's host
+	authChallenge   authChallengeCallback   // Answers a 401 challenge, e.g. for digest auth
+	signer          rawRequestCallback      // Signs the final *http.Request, after rawRequest
+	readProgress    func(read, total int64) // Called as the response body is read
+	rawHeaders      map[string]string       // Headers sent with their names exactly as given, bypassing canonicalization
+	timeoutOffset   time.Duration           // Random offset from Config.TimeoutJitter, drawn once per request
+	allowPrivateIP  bool                    // When true, SSRF protection is lifted for this request
+	checkRedirect   types.CheckRedirectFunc // Per-request redirect callback; nil uses Config.CheckRedirect
+	sanitizedURL    string                  // Cached per-request sanitized URL, set by middleware on first access
 }
 
 // Compile-time interface check
@@ -267,6 +386,7 @@ func (r *Request) Headers() map[string]string  { return r.headers }
 func (r *Request) QueryParams() map[string]any { return r.queryParams }
 func (r *Request) Body() any                   { return r.body }
 func (r *Request) Timeout() time.Duration      { return r.timeout }
+func (r *Request) Deadline() time.Time         { return r.deadline }
 func (r *Request) MaxRetries() int             { return r.maxRetries }
 func (r *Request) Context() context.Context    { return r.context }
 func (r *Request) Cookies() []http.Cookie      { return r.cookies }
@@ -294,6 +414,7 @@ func (r *Request) EnsureQueryParams() map[string]any {
 }
 func (r *Request) SetBody(v any)                { r.body = v }
 func (r *Request) SetTimeout(v time.Duration)   { r.timeout = v }
+func (r *Request) SetDeadline(v time.Time)      { r.deadline = v }
 func (r *Request) SetMaxRetries(v int)          { r.maxRetries = v }
 func (r *Request) SetContext(v context.Context) { r.context = v }
 func (r *Request) SetCookies(v []http.Cookie)   { r.cookies = v }
@@ -301,12 +422,67 @@ func (r *Request) SetFollowRedirects(v *bool)   { r.followRedirects = v }
 func (r *Request) SetMaxRedirects(v *int)       { r.maxRedirects = v }
 func (r *Request) StreamBody() bool             { return r.streamBody }
 func (r *Request) SetStreamBody(v bool)         { r.streamBody = v }
+func (r *Request) SameHostRedirectsOnly() bool  { return r.sameHostOnly }
+func (r *Request) SetSameHostRedirectsOnly(v bool) {
+	r.sameHostOnly = v
+}
+func (r *Request) OmittedCookies() []string     { return r.omitCookies }
+func (r *Request) ReturnLastRedirect() bool     { return r.returnLastRedir }
+func (r *Request) SetReturnLastRedirect(v bool) { r.returnLastRedir = v }
+func (r *Request) RateLimit() (float64, int)    { return r.rateLimit, r.rateBurst }
+func (r *Request) SetRateLimit(rps float64, burst int) {
+	r.rateLimit, r.rateBurst = rps, burst
+}
+func (r *Request) NoCache() bool                             { return r.noCache }
+func (r *Request) SetNoCache(v bool)                         { r.noCache = v }
+func (r *Request) EarlyHints() bool                          { return r.earlyHints }
+func (r *Request) RetryableStatusCodes() []int               { return r.retryStatuses }
+func (r *Request) RetryableMethods() []string                { return r.retryMethods }
+func (r *Request) RetryIf() types.RetryIfFunc                { return r.retryIf }
+func (r *Request) CancelGroup() string                       { return r.cancelGroup }
+func (r *Request) BodyTee() io.Writer                        { return r.bodyTee }
+func (r *Request) SetEarlyHints(v bool)                      { r.earlyHints = v }
+func (r *Request) SetRetryableStatusCodes(v []int)           { r.retryStatuses = v }
+func (r *Request) SetRetryableMethods(v []string)            { r.retryMethods = v }
+func (r *Request) SetRetryIf(v types.RetryIfFunc)            { r.retryIf = v }
+func (r *Request) SetCancelGroup(v string)                   { r.cancelGroup = v }
+func (r *Request) SetBodyTee(v io.Writer)                    { r.bodyTee = v }
+func (r *Request) Host() string                              { return r.host }
+func (r *Request) SetHost(v string)                          { r.host = v }
+func (r *Request) ReadProgress() func(read, total int64)     { return r.readProgress }
+func (r *Request) SetReadProgress(v func(read, total int64)) { r.readProgress = v }
+func (r *Request) RawHeaders() map[string]string             { return r.rawHeaders }
+func (r *Request) SetOmittedCookies(v []string)              { r.omitCookies = v }
+func (r *Request) AllowPrivateIP() bool                      { return r.allowPrivateIP }
+func (r *Request) SetAllowPrivateIP(v bool)                  { r.allowPrivateIP = v }
+func (r *Request) CheckRedirect() types.CheckRedirectFunc {
+	return r.checkRedirect
+}
+func (r *Request) SetCheckRedirect(v types.CheckRedirectFunc) {
+	r.checkRedirect = v
+}
+func (r *Request) SetRawHeaders(v map[string]string) {
+	r.rawHeaders = v
+}
+func (r *Request) SetRawHeader(name, value string) {
+	if r.rawHeaders == nil {
+		r.rawHeaders = make(map[string]string)
+	}
+	r.rawHeaders[name] = value
+}
 
 // Callback accessors
-func (r *Request) OnRequest() requestCallback        { return r.onRequest }
-func (r *Request) OnResponse() responseCallback      { return r.onResponse }
-func (r *Request) SetOnRequest(cb requestCallback)   { r.onRequest = cb }
-func (r *Request) SetOnResponse(cb responseCallback) { r.onResponse = cb }
+func (r *Request) OnRequest() requestCallback          { return r.onRequest }
+func (r *Request) OnResponse() responseCallback        { return r.onResponse }
+func (r *Request) RawRequest() rawRequestCallback      { return r.rawRequest }
+func (r *Request) SetOnRequest(cb requestCallback)     { r.onRequest = cb }
+func (r *Request) SetOnResponse(cb responseCallback)   { r.onResponse = cb }
+func (r *Request) SetRawRequest(cb rawRequestCallback) { r.rawRequest = cb }
+
+func (r *Request) AuthChallenge() authChallengeCallback      { return r.authChallenge }
+func (r *Request) Signer() rawRequestCallback                { return r.signer }
+func (r *Request) SetAuthChallenge(cb authChallengeCallback) { r.authChallenge = cb }
+func (r *Request) SetSigner(cb rawRequestCallback)           { r.signer = cb }
 
 // Response represents an HTTP response.
 // Response objects are safe to read from multiple goroutines after they are returned.
@@ -316,16 +492,26 @@ type Response struct {
 	headers        http.Header
 	body           string
 	rawBody        []byte
+	compressedBody []byte             // Encoded body bytes; set only when KeepCompressedBody is enabled
 	bodyMu         sync.RWMutex       // Protects body/bodyReady for concurrent SetBody/Body access
 	bodyReady      bool               // True after body string has been computed from rawBody
 	rawBodyReader  io.ReadCloser      // Set when streamBody=true; caller must close
 	cancelFunc     context.CancelFunc // Stored for streaming mode cleanup
+	streamCtx      context.Context    // Request context governing the streaming body
 	contentLength  int64
 	proto          string
+	tlsState       *tls.ConnectionState // Negotiated TLS state; nil for plain HTTP
+	earlyHints     http.Header          // Headers from 103 Early Hints; set only when requested
+	bytesSent      int64                // Request line, headers and body of the final request
+	bytesReceived  int64                // Status line, headers and body of the final response
+	connectionID   string               // "local->remote" address pair of the final connection
+	timing         types.Timing         // Phase durations of the final request
+	retryHistory   []types.RetryAttempt // Attempts that were retried, oldest first
 	duration       time.Duration
 	attempts       int
 	cookies        []*http.Cookie
 	redirectChain  []string
+	redirectHops   []types.RedirectHop // Followed redirects with status and timing, oldest first
 	redirectCount  int
 	requestHeaders http.Header // Actual headers sent with the request
 	requestURL     string      // The actual URL that was requested (with query params)
@@ -359,18 +545,28 @@ func (r *Response) Body() string {
 	r.bodyMu.Unlock()
 	return b
 }
-func (r *Response) RawBody() []byte              { return r.rawBody }
-func (r *Response) ContentLength() int64         { return r.contentLength }
-func (r *Response) Proto() string                { return r.proto }
-func (r *Response) Duration() time.Duration      { return r.duration }
-func (r *Response) Attempts() int                { return r.attempts }
-func (r *Response) Cookies() []*http.Cookie      { return r.cookies }
-func (r *Response) RedirectChain() []string      { return r.redirectChain }
-func (r *Response) RedirectCount() int           { return r.redirectCount }
-func (r *Response) RequestHeaders() http.Header  { return r.requestHeaders }
-func (r *Response) RequestURL() string           { return r.requestURL }
-func (r *Response) RequestMethod() string        { return r.requestMethod }
-func (r *Response) RawBodyReader() io.ReadCloser { return r.rawBodyReader }
+func (r *Response) RawBody() []byte                    { return r.rawBody }
+func (r *Response) CompressedRawBody() []byte          { return r.compressedBody }
+func (r *Response) ContentLength() int64               { return r.contentLength }
+func (r *Response) Proto() string                      { return r.proto }
+func (r *Response) TLS() *tls.ConnectionState          { return r.tlsState }
+func (r *Response) EarlyHints() http.Header            { return r.earlyHints }
+func (r *Response) BytesSent() int64                   { return r.bytesSent }
+func (r *Response) BytesReceived() int64               { return r.bytesReceived }
+func (r *Response) ConnectionID() string               { return r.connectionID }
+func (r *Response) Timing() types.Timing               { return r.timing }
+func (r *Response) RetryHistory() []types.RetryAttempt { return r.retryHistory }
+func (r *Response) Duration() time.Duration            { return r.duration }
+func (r *Response) Attempts() int                      { return r.attempts }
+func (r *Response) Cookies() []*http.Cookie            { return r.cookies }
+func (r *Response) RedirectChain() []string            { return r.redirectChain }
+func (r *Response) RedirectHops() []types.RedirectHop  { return r.redirectHops }
+func (r *Response) RedirectCount() int                 { return r.redirectCount }
+func (r *Response) RequestHeaders() http.Header        { return r.requestHeaders }
+func (r *Response) RequestURL() string                 { return r.requestURL }
+func (r *Response) RequestMethod() string              { return r.requestMethod }
+func (r *Response) RawBodyReader() io.ReadCloser       { return r.rawBodyReader }
+func (r *Response) StreamContext() context.Context     { return r.streamCtx }
 
 // TransferHeaders returns the response headers and clears the internal reference.
 // The caller takes ownership of the returned map. Used by the public layer to
@@ -396,6 +592,41 @@ func (r *Response) SetRawBodyReader(rc io.ReadCloser) {
 	r.bodyMu.Unlock()
 }
 
+// DetachBody transfers ownership of the streaming body reader to the caller.
+// Closing the returned reader also releases the request context, so the
+// Response can be returned to the pool while the body is still being read.
+// Returns nil when the response was not streamed.
+func (r *Response) DetachBody() io.ReadCloser {
+	r.bodyMu.Lock()
+	defer r.bodyMu.Unlock()
+	if r.rawBodyReader == nil {
+		return nil
+	}
+	body := &detachedBody{ReadCloser: r.rawBodyReader, cancel: r.cancelFunc}
+	r.rawBodyReader = nil
+	r.cancelFunc = nil
+	return body
+}
+
+// detachedBody closes the underlying body and then cancels its request context.
+// Close is idempotent so the request context is cancelled only once.
+type detachedBody struct {
+	io.ReadCloser
+	cancel    context.CancelFunc
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (d *detachedBody) Close() error {
+	d.closeOnce.Do(func() {
+		d.closeErr = d.ReadCloser.Close()
+		if d.cancel != nil {
+			d.cancel()
+		}
+	})
+	return d.closeErr
+}
+
 // Mutators (implement ResponseMutator)
 func (r *Response) SetStatusCode(v int)      { r.statusCode = v }
 func (r *Response) SetStatus(v string)       { r.status = v }
@@ -412,16 +643,25 @@ func (r *Response) SetRawBody(v []byte) {
 	r.bodyReady = false
 	r.bodyMu.Unlock()
 }
-func (r *Response) SetContentLength(v int64)        { r.contentLength = v }
-func (r *Response) SetProto(v string)               { r.proto = v }
-func (r *Response) SetDuration(v time.Duration)     { r.duration = v }
-func (r *Response) SetAttempts(v int)               { r.attempts = v }
-func (r *Response) SetCookies(v []*http.Cookie)     { r.cookies = v }
-func (r *Response) SetRedirectChain(v []string)     { r.redirectChain = v }
-func (r *Response) SetRedirectCount(v int)          { r.redirectCount = v }
-func (r *Response) SetRequestHeaders(v http.Header) { r.requestHeaders = v }
-func (r *Response) SetRequestURL(v string)          { r.requestURL = v }
-func (r *Response) SetRequestMethod(v string)       { r.requestMethod = v }
+func (r *Response) SetCompressedRawBody(v []byte)          { r.compressedBody = v }
+func (r *Response) SetContentLength(v int64)               { r.contentLength = v }
+func (r *Response) SetProto(v string)                      { r.proto = v }
+func (r *Response) SetTLS(v *tls.ConnectionState)          { r.tlsState = v }
+func (r *Response) SetEarlyHints(v http.Header)            { r.earlyHints = v }
+func (r *Response) SetBytesSent(v int64)                   { r.bytesSent = v }
+func (r *Response) SetBytesReceived(v int64)               { r.bytesReceived = v }
+func (r *Response) SetConnectionID(v string)               { r.connectionID = v }
+func (r *Response) SetTiming(v types.Timing)               { r.timing = v }
+func (r *Response) SetRetryHistory(v []types.RetryAttempt) { r.retryHistory = v }
+func (r *Response) SetDuration(v time.Duration)            { r.duration = v }
+func (r *Response) SetAttempts(v int)                      { r.attempts = v }
+func (r *Response) SetCookies(v []*http.Cookie)            { r.cookies = v }
+func (r *Response) SetRedirectChain(v []string)            { r.redirectChain = v }
+func (r *Response) SetRedirectHops(v []types.RedirectHop)  { r.redirectHops = v }
+func (r *Response) SetRedirectCount(v int)                 { r.redirectCount = v }
+func (r *Response) SetRequestHeaders(v http.Header)        { r.requestHeaders = v }
+func (r *Response) SetRequestURL(v string)                 { r.requestURL = v }
+func (r *Response) SetRequestMethod(v string)              { r.requestMethod = v }
 
 // SetHeader sets a header with multiple values (implements ResponseMutator)
 func (r *Response) SetHeader(key string, values ...string) {
@@ -445,7 +685,9 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 
 	client := &Client{
 		config:          config,
+		clock:           clockOf(config),
 		metrics:         &metrics{},
+		rateLimiter:     newHostRateLimiter(),
 		requestPool:     newRequestPool(),
 		execRequestPool: newRequestPool(),
 		securityRequestPool: sync.Pool{
@@ -455,6 +697,10 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 		},
 	}
 
+	if config.EnableCache {
+		client.cache = newResponseCache(config.CacheMaxEntries, config.CacheMaxBytes)
+	}
+
 	var err error
 
 	// Use custom transport if provided, otherwise create default
@@ -467,6 +713,8 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 		connConfig.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
 		connConfig.MaxConnsPerHost = config.MaxConnsPerHost
 		connConfig.MaxResponseHeaderBytes = config.MaxResponseHeaderBytes
+		connConfig.MaxStatusLineLength = config.MaxStatusLineLength
+		connConfig.StrictFraming = config.StrictFraming
 		connConfig.DialTimeout = config.DialTimeout
 		connConfig.KeepAlive = config.KeepAlive
 		connConfig.TLSHandshakeTimeout = config.TLSHandshakeTimeout
@@ -475,8 +723,15 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 		connConfig.MinTLSVersion = config.MinTLSVersion
 		connConfig.MaxTLSVersion = config.MaxTLSVersion
 		connConfig.InsecureSkipVerify = config.InsecureSkipVerify
+		connConfig.RequireOCSPStapling = config.RequireOCSPStapling
 		connConfig.EnableHTTP2 = config.EnableHTTP2
+		connConfig.AllowH2C = config.AllowH2C
 		connConfig.ProxyURL = config.ProxyURL
+		connConfig.ProxyFunc = config.ProxyFunc
+		connConfig.UnixSocket = config.UnixSocket
+		connConfig.Resolver = config.Resolver
+		connConfig.DialContext = config.DialContext
+		connConfig.DialPreference = config.DialPreference
 		connConfig.EnableSystemProxy = config.EnableSystemProxy
 		connConfig.CookieJar = config.CookieJar
 		connConfig.AllowPrivateIPs = config.AllowPrivateIPs
@@ -484,6 +739,8 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 		connConfig.EnableDoH = config.EnableDoH
 		connConfig.DoHCacheTTL = config.DoHCacheTTL
 		connConfig.TLSConfig = config.TLSConfig
+		connConfig.ClientCertificates = config.ClientCertificates
+		connConfig.RootCAs = config.RootCAs
 
 		if config.CertificatePinner != nil {
 			connConfig.SetCertPinner(config.CertificatePinner)
@@ -512,6 +769,7 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 		MaxRequestBodySize:  config.MaxRequestBodySize,
 		AllowPrivateIPs:     config.AllowPrivateIPs,
 		ExemptNets:          config.ExemptNets,
+		AllowHopByHop:       config.AllowHopByHopHeaders,
 	}
 	client.validator = security.NewValidatorWithConfig(validatorConfig)
 
@@ -521,12 +779,22 @@ func NewClient(config *Config, opts ...clientOption) (*Client, error) {
 // ErrClientClosed is returned when attempting to use a closed client.
 var ErrClientClosed = errors.New("client is closed")
 
+// ErrStatusLineTooLong is returned when a response status line exceeds
+// Config.MaxStatusLineLength.
+var ErrStatusLineTooLong = connection.ErrStatusLineTooLong
+
+// ErrAmbiguousFraming is returned under Config.StrictFraming when a response
+// has both Content-Length and Transfer-Encoding.
+var ErrAmbiguousFraming = connection.ErrAmbiguousFraming
+
 func (c *Client) Request(ctx context.Context, method, url string, options ...RequestOption) (*Response, error) {
 	if atomic.LoadInt32(&c.closed) == 1 {
 		return nil, fmt.Errorf("%w", ErrClientClosed)
 	}
 
-	startTime := time.Now()
+	c.metrics.inFlight.Add(1)
+	defer c.metrics.inFlight.Add(-1)
+	startTime := c.clock.Now()
 
 	// Get Request from pool (already zeroed by putRequest via *req = Request{})
 	req := c.getRequest()
@@ -540,7 +808,7 @@ func (c *Client) Request(ctx context.Context, method, url string, options ...Req
 	for _, option := range options {
 		if option != nil {
 			if err := option(req); err != nil {
-				c.metrics.recordRequest(time.Since(startTime).Nanoseconds(), false)
+				c.metrics.recordRequest(c.clock.Now().Sub(startTime).Nanoseconds(), false)
 				return nil, fmt.Errorf("failed to apply request option: %w", err)
 			}
 		}
@@ -551,19 +819,52 @@ func (c *Client) Request(ctx context.Context, method, url string, options ...Req
 	secReq.Method = req.Method()
 	secReq.URL = req.URL()
 	secReq.Headers = req.Headers()
+	if len(req.rawHeaders) > 0 {
+		// Raw headers are subject to the same checks as canonical ones.
+		secReq.Headers = make(map[string]string, len(req.headers)+len(req.rawHeaders))
+		maps.Copy(secReq.Headers, req.headers)
+		maps.Copy(secReq.Headers, req.rawHeaders)
+	}
 	secReq.QueryParams = req.QueryParams()
 	secReq.Body = req.Body()
+	secReq.AllowPrivateIPs = req.allowPrivateIP
 
 	validationErr := c.validator.ValidateRequest(secReq)
 	c.putSecurityRequest(secReq)
 
 	if validationErr != nil {
-		c.metrics.recordRequest(time.Since(startTime).Nanoseconds(), false)
+		c.metrics.recordRequest(c.clock.Now().Sub(startTime).Nanoseconds(), false)
 		return nil, fmt.Errorf("request validation failed: %w", validationErr)
 	}
 
-	response, err := c.executeWithRetry(req)
-	duration := time.Since(startTime)
+	var leaveGroup func()
+	if req.cancelGroup != "" {
+		leaveGroup = c.cancelGroups.join(req)
+	}
+
+	var response *Response
+	var err error
+	if c.cacheable(req) {
+		response, err = c.sendCached(req)
+	} else {
+		response, err = c.fetch(req)
+	}
+	duration := c.clock.Now().Sub(startTime)
+
+	if leaveGroup != nil {
+		if err == nil && response.rawBodyReader != nil {
+			// A streamed body stays in the group until it is closed.
+			streamCancel := response.cancelFunc
+			response.cancelFunc = func() {
+				if streamCancel != nil {
+					streamCancel()
+				}
+				leaveGroup()
+			}
+		} else {
+			leaveGroup()
+		}
+	}
 
 	if err != nil {
 		c.metrics.recordRequest(duration.Nanoseconds(), false)
@@ -575,6 +876,46 @@ func (c *Client) Request(ctx context.Context, method, url string, options ...Req
 	return response, nil
 }
 
+// Warmup sends a HEAD request to url, without retries, so the pool holds a
+// connection to its host. Unlike Request it bypasses the cache, the rate
+// limiter and the request counters, and the response is discarded.
+func (c *Client) Warmup(ctx context.Context, url string) error {
+	if atomic.LoadInt32(&c.closed) == 1 {
+		return fmt.Errorf("%w", ErrClientClosed)
+	}
+
+	req := c.getRequest()
+	defer c.putRequest(req)
+	req.SetMethod(http.MethodHead)
+	req.SetURL(url)
+	req.SetContext(ctx)
+	req.SetMaxRetries(0)
+
+	secReq := c.getSecurityRequest()
+	secReq.Method = req.Method()
+	secReq.URL = req.URL()
+	validationErr := c.validator.ValidateRequest(secReq)
+	c.putSecurityRequest(secReq)
+	if validationErr != nil {
+		return fmt.Errorf("request validation failed: %w", validationErr)
+	}
+
+	resp, err := c.retryLoop(req)
+	if err != nil {
+		return err
+	}
+	ReleaseResponse(resp)
+	return nil
+}
+
+// fetch sends req over the network, waiting for the rate limiter first.
+func (c *Client) fetch(req *Request) (*Response, error) {
+	if err := c.waitRateLimit(req); err != nil {
+		return nil, err
+	}
+	return c.executeWithRetry(req)
+}
+
 // getRequest retrieves a Request object from the pool with safe type assertion
 func (c *Client) getRequest() *Request {
 	return c.requestPool.get()
@@ -619,6 +960,17 @@ var timerPool = sync.Pool{
 }
 
 func (c *Client) sleepWithContext(ctx context.Context, duration time.Duration) error {
+	if _, ok := c.clock.(realClock); !ok {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(duration):
+			return nil
+		}
+	}
 	if ctx == nil {
 		time.Sleep(duration)
 		return nil
@@ -642,9 +994,47 @@ func (c *Client) sleepWithContext(ctx context.Context, duration time.Duration) e
 	}
 }
 
-// executeWithRetry executes a request with intelligent retry logic.
-// Optimized for performance with minimal allocations and efficient error handling.
+// executeWithRetry executes a request with retries, reporting the request
+// lifecycle to the configured Tracer, if any.
 func (c *Client) executeWithRetry(req *Request) (*Response, error) {
+	tracer := c.config.Tracer
+	if tracer == nil {
+		return c.retryLoop(req)
+	}
+
+	start := c.clock.Now()
+	tracer.OnStart(req.Method(), validation.SanitizeURL(req.URL()))
+	resp, err := c.retryLoop(req)
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode()
+	}
+	tracer.OnFinish(statusCode, err, c.clock.Now().Sub(start))
+	return resp, err
+}
+
+// withinRetryBudget reports whether a retry after delay would start within
+// the configured RetryBudget of a request begun at start.
+func (c *Client) withinRetryBudget(start time.Time, delay time.Duration) bool {
+	budget := c.config.RetryBudget
+	return budget <= 0 || c.clock.Now().Sub(start)+delay < budget
+}
+
+// traceRetry notifies the configured Tracer that retry number attempt is starting.
+func (c *Client) traceRetry(attempt int) {
+	if c.config.Tracer != nil {
+		c.config.Tracer.OnRetry(attempt)
+	}
+}
+
+// retryLoop executes a request with intelligent retry logic.
+// Optimized for performance with minimal allocations and efficient error handling.
+func (c *Client) retryLoop(req *Request) (*Response, error) {
+	applyConfigOverride(req)
+	if err := c.applyTokenSource(req); err != nil {
+		return nil, classifyError(err, req.URL(), req.Method(), 0)
+	}
+
 	// Determine max retries: maxRetriesUnset = not configured (use config default), 0 = explicitly disabled
 	maxRetries := req.MaxRetries()
 	if maxRetries < 0 {
@@ -655,10 +1045,25 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 		}
 	}
 
+	// Streamed multipart files are read once and cannot be replayed.
+	if fd, ok := req.body.(*types.FormData); ok && hasStreamedFiles(fd) {
+		maxRetries = 0
+	}
+	if !c.retryEngine.allowsMethod(req.method, req.retryMethods) {
+		maxRetries = 0
+	}
+
+	// Draw the timeout offset once so every deadline computed for this
+	// request, across attempts, agrees.
+	if j := c.config.TimeoutJitter; j > 0 {
+		req.timeoutOffset = c.retryEngine.getJitter(2*j+1) - j
+	}
+
 	// Fast path: no retries configured (most common case)
 	// Skip deep copy since request is only executed once — original req
 	// is returned to pool by caller's defer putRequest regardless.
-	if maxRetries == 0 {
+	// An auth challenge may send the request twice, so it takes the slow path.
+	if maxRetries == 0 && req.authChallenge == nil {
 		resp, err := c.executeRequest(req, true)
 		if err != nil {
 			return nil, classifyError(err, req.URL(), req.Method(), 1)
@@ -674,6 +1079,10 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 	if c.config.CustomRetryPolicy != nil {
 		policy = c.config.CustomRetryPolicy
 	}
+	retryIf := req.retryIf
+	if retryIf == nil {
+		retryIf = c.config.RetryIf
+	}
 
 	// Create a shared timeout context for all retry attempts.
 	// This ensures WithTimeout is a total budget across retries,
@@ -683,9 +1092,10 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 	if retryCtx == nil {
 		retryCtx = backgroundCtx
 	}
-	retryTimeout := req.Timeout()
-	if retryTimeout <= 0 && c.config.Timeout > 0 {
-		retryTimeout = c.config.Timeout
+	retryTimeout := c.requestTimeout(req)
+	// RetryBudget bounds the attempts themselves, not only the delays.
+	if budget := c.config.RetryBudget; budget > 0 && (retryTimeout <= 0 || budget < retryTimeout) {
+		retryTimeout = budget
 	}
 	var overallCancel context.CancelFunc
 	if retryTimeout > 0 {
@@ -713,6 +1123,8 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 
 	var lastErr error
 	var lastResp *Response
+	var history []types.RetryAttempt
+	start := c.clock.Now()
 
 	// Buffer io.Reader body for retry safety. io.Reader is consumed on
 	// first use, so subsequent retry attempts would send an empty body.
@@ -738,26 +1150,42 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 					req.URL(), req.Method(), 0,
 				)
 			}
+			if sr, ok := r.(*sizedReader); ok && int64(len(buf)) < sr.size {
+				if overallCancel != nil {
+					overallCancel()
+				}
+				return nil, classifyError(
+					fmt.Errorf("request body ended after %d of %d bytes: %w", len(buf), sr.size, io.ErrUnexpectedEOF),
+					req.URL(), req.Method(), 0,
+				)
+			}
 			req.body = buf
 		}
 	}
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		resp, err := c.executeRequest(req, false)
+		resp, err := c.executeAuthenticated(req)
 
 		if err != nil {
 			clientErr := classifyErrorWithSanitizedURL(err, sanitizedURL, reqMethod, attempt+1)
 			lastErr = clientErr
 
-			// Fast path: non-retryable errors or max retries reached
-			if !clientErr.IsRetryable() || attempt >= maxRetries {
+			// Fast path: max retries reached, or a non-retryable error
+			// when the built-in classification decides
+			if attempt >= maxRetries || (retryIf == nil && !clientErr.IsRetryable()) {
 				releaseLastResp(&lastResp)
 				clientErr.Attempts = attempt + 1
 				return nil, clientErr
 			}
 
 			// Check retry policy
-			if !policy.ShouldRetry(nil, err, attempt) {
+			var retry bool
+			if retryIf != nil {
+				retry = retryIf(nil, clientErr, attempt)
+			} else {
+				retry = policy.ShouldRetry(nil, err, attempt)
+			}
+			if !retry {
 				releaseLastResp(&lastResp)
 				clientErr.Attempts = attempt + 1
 				return nil, clientErr
@@ -765,10 +1193,17 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 
 			// Calculate delay and sleep
 			delay := policy.GetDelay(attempt)
+			if !c.withinRetryBudget(start, delay) {
+				releaseLastResp(&lastResp)
+				clientErr.Attempts = attempt + 1
+				return nil, clientErr
+			}
+			history = append(history, types.RetryAttempt{Err: clientErr.Error(), Delay: delay})
 			if sleepErr := c.sleepWithContext(req.Context(), delay); sleepErr != nil {
 				releaseLastResp(&lastResp)
 				return nil, classifyError(sleepErr, req.URL(), req.Method(), attempt+1)
 			}
+			c.traceRetry(attempt + 1)
 			continue
 		}
 
@@ -779,8 +1214,20 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 			}
 			lastResp = resp
 
-			// Check if response status is retryable using policy
-			if policy.ShouldRetry(resp, nil, attempt) && attempt < maxRetries {
+			// Check if response status is retryable using policy; a
+			// per-request status list overrides the built-in policy's.
+			var retryable bool
+			if retryIf != nil {
+				retryable = retryIf(resp, nil, attempt)
+			} else if _, ok := policy.(*retryEngine); ok && req.retryStatuses != nil {
+				retryable = slices.Contains(req.retryStatuses, resp.StatusCode())
+			} else {
+				retryable = policy.ShouldRetry(resp, nil, attempt)
+			}
+			if !retryable {
+				retryable = c.retryEngine.matchesJSONField(resp.RawBody())
+			}
+			if retryable && attempt < maxRetries {
 				// Use built-in engine delay for Retry-After header support,
 				// otherwise delegate to the policy's GetDelay
 				var delay time.Duration
@@ -789,15 +1236,21 @@ func (c *Client) executeWithRetry(req *Request) (*Response, error) {
 				} else {
 					delay = policy.GetDelay(attempt)
 				}
-				if sleepErr := c.sleepWithContext(req.Context(), delay); sleepErr != nil {
-					releaseLastResp(&lastResp)
-					return nil, classifyErrorWithSanitizedURL(sleepErr, sanitizedURL, reqMethod, attempt+1)
+				// Out of budget: hand back this response as is.
+				if c.withinRetryBudget(start, delay) {
+					history = append(history, types.RetryAttempt{StatusCode: resp.StatusCode(), Delay: delay})
+					if sleepErr := c.sleepWithContext(req.Context(), delay); sleepErr != nil {
+						releaseLastResp(&lastResp)
+						return nil, classifyErrorWithSanitizedURL(sleepErr, sanitizedURL, reqMethod, attempt+1)
+					}
+					c.traceRetry(attempt + 1)
+					continue
 				}
-				continue
 			}
 
 			// Success - set attempt count and return
 			resp.SetAttempts(attempt + 1)
+			resp.SetRetryHistory(history)
 			// Transfer context cancel ownership: streaming responses
 			// need the cancel to stay alive until ReleaseResponse.
 			// Setting overallCancel=nil prevents the defer from cancelling.
@@ -879,6 +1332,89 @@ const (
 // intentional since unexported vars cannot be shared across packages.
 var backgroundCtx = context.Background()
 
+// requestTimeout returns the timeout for req: its own timeout or the client
+// default, extended by TimeoutPerMB for each megabyte of declared request body
+// so large uploads get proportionally more time, then offset by the request's
+// TimeoutJitter draw and capped by the time left before its deadline. Zero
+// means no timeout.
+func (c *Client) requestTimeout(req *Request) time.Duration {
+	timeout := req.Timeout()
+	if timeout <= 0 {
+		timeout = c.config.Timeout
+	}
+	if timeout > 0 && c.config.TimeoutPerMB > 0 {
+		if size := declaredBodySize(req); size > 0 {
+			timeout += time.Duration(float64(c.config.TimeoutPerMB) * float64(size) / (1 << 20))
+		}
+	}
+	if timeout > 0 && req.timeoutOffset != 0 {
+		// Jitter never shortens a timeout below half its configured value.
+		timeout = max(timeout+req.timeoutOffset, timeout/2)
+	}
+	if !req.deadline.IsZero() {
+		// A passed deadline still yields a positive timeout, so the
+		// context it bounds expires at once.
+		if remaining := max(time.Until(req.deadline), time.Nanosecond); timeout <= 0 || remaining < timeout {
+			timeout = remaining
+		}
+	}
+	return timeout
+}
+
+// declaredBodySize returns the request body length from an explicit
+// Content-Length header or, failing that, the body itself when its size is
+// known up front. Returns 0 when unknown.
+func declaredBodySize(req *Request) int64 {
+	for k, v := range req.headers {
+		if strings.EqualFold(k, "Content-Length") {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+	switch b := req.body.(type) {
+	case string:
+		return int64(len(b))
+	case []byte:
+		return int64(len(b))
+	case *types.FormData:
+		var n int64
+		for _, f := range b.Files {
+			if f == nil {
+				continue
+			}
+			if f.Reader != nil {
+				n += max(f.Size, 0)
+			} else {
+				n += int64(len(f.Content))
+			}
+		}
+		return n
+	case *sizedReader:
+		return b.size
+	case interface{ Len() int }:
+		return int64(b.Len())
+	}
+	return 0
+}
+
+// executeAuthenticated executes req and, when the response is a 401 that
+// req.authChallenge can answer, sends it once more with the Authorization
+// header it returns. The header is kept on req, so retries reuse it.
+func (c *Client) executeAuthenticated(req *Request) (*Response, error) {
+	resp, err := c.executeRequest(req, false)
+	if err != nil || req.authChallenge == nil || resp.StatusCode() != http.StatusUnauthorized {
+		return resp, err
+	}
+	auth, ok := req.authChallenge(resp)
+	if !ok {
+		return resp, nil
+	}
+	ReleaseResponse(resp)
+	req.SetHeader("Authorization", auth)
+	return c.executeRequest(req, false)
+}
+
 // executeRequest executes a single HTTP request with comprehensive error handling.
 // When skipCopy is true, the request is used directly without deep copy (safe when
 // the caller guarantees single-use, i.e., no retries).
@@ -889,10 +1425,7 @@ func (c *Client) executeRequest(req *Request, skipCopy bool) (*Response, error)
 		execCtx = backgroundCtx
 	}
 
-	timeout := req.Timeout()
-	if timeout <= 0 && c.config.Timeout > 0 {
-		timeout = c.config.Timeout
-	}
+	timeout := c.requestTimeout(req)
 
 	// Optimized: only create new context if absolutely necessary
 	var streamCancel context.CancelFunc
@@ -963,7 +1496,29 @@ func (c *Client) executeRequest(req *Request, skipCopy bool) (*Response, error)
 	var redirectSettings *redirectSettings
 	reqCopy.context, redirectSettings = c.transport.SetRedirectPolicy(execCtx, followRedirects, maxRedirects)
 	if redirectSettings != nil {
+		redirectSettings.sameHostOnly = reqCopy.sameHostOnly
+		redirectSettings.omitCookies = reqCopy.omitCookies
+		redirectSettings.returnLastOnLimit = reqCopy.returnLastRedir
+		redirectSettings.allowPrivateIPs = reqCopy.allowPrivateIP
+		redirectSettings.checkRedirect = c.config.CheckRedirect
+		if reqCopy.checkRedirect != nil {
+			redirectSettings.checkRedirect = reqCopy.checkRedirect
+		}
 		defer putRedirectSettings(redirectSettings)
+
+		if followRedirects && c.config.MaxRedirectTime > 0 {
+			redirectCtx, abort := context.WithCancelCause(reqCopy.context)
+			reqCopy.context = redirectCtx
+			redirectSettings.redirectTimeout = c.config.MaxRedirectTime
+			redirectSettings.abortRedirects = abort
+			// A streamed body outlives this call and is bounded by the
+			// parent context instead.
+			defer func() {
+				if !reqCopy.StreamBody() {
+					abort(nil)
+				}
+			}()
+		}
 	}
 
 	// Lazy sanitized URL: only compute when an error occurs.
@@ -990,29 +1545,88 @@ func (c *Client) executeRequest(req *Request, skipCopy bool) (*Response, error)
 	}
 	defer putHTTPHeader(httpReq.Header)
 
+	if reqCopy.allowPrivateIP && !c.config.AllowPrivateIPs {
+		// A connection dialed without SSRF checks must not be reused by
+		// requests that did not opt out of them.
+		httpReq = httpReq.WithContext(connection.WithPrivateIPsAllowed(httpReq.Context()))
+		httpReq.Close = true
+	}
+
+	if reqCopy.rawRequest != nil {
+		ctx := httpReq.Context()
+		if err := reqCopy.rawRequest(httpReq); err != nil {
+			return nil, classifyErrorWithSanitizedURL(fmt.Errorf("raw request callback failed: %w", err), sanitizeOnce(), req.Method(), 0)
+		}
+		if httpReq.Context() != ctx || httpReq.URL == nil {
+			// The context carries the timeout, cancellation and SSRF settings.
+			return nil, classifyErrorWithSanitizedURL(fmt.Errorf("raw request callback must not replace the request context or URL"), sanitizeOnce(), req.Method(), 0)
+		}
+	}
+	// Signing runs last so the signature covers the request as sent.
+	if reqCopy.signer != nil {
+		if err := reqCopy.signer(httpReq); err != nil {
+			return nil, classifyErrorWithSanitizedURL(fmt.Errorf("sign request failed: %w", err), sanitizeOnce(), req.Method(), 0)
+		}
+	}
+
+	var earlyHints http.Header
+	if reqCopy.earlyHints {
+		earlyHints = make(http.Header)
+		httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), earlyHintsTrace(earlyHints)))
+	}
+	if reqCopy.bodyTee != nil && httpReq.Body != nil && httpReq.Body != http.NoBody {
+		httpReq.Body = &teeReadCloser{Reader: io.TeeReader(httpReq.Body, reqCopy.bodyTee), Closer: httpReq.Body}
+	}
+	var wire wireCounter
+	httpReq = wire.attach(httpReq)
+	var timer phaseTimer
+	httpReq = timer.attach(httpReq)
+
 	httpResp, err := c.transport.RoundTrip(httpReq)
+	if redirectSettings != nil {
+		redirectSettings.stopRedirectTimer()
+	}
 
 	if err != nil {
+		if cause := context.Cause(reqCopy.context); errors.Is(cause, ErrRedirectTimeout) {
+			err = cause
+		}
 		return nil, classifyErrorWithSanitizedURL(err, sanitizeOnce(), req.Method(), 0)
 	}
 
+	if reqCopy.readProgress != nil && httpResp.Body != nil && httpResp.Body != http.NoBody {
+		httpResp.Body = &progressReadCloser{ReadCloser: httpResp.Body, total: httpResp.ContentLength, fn: reqCopy.readProgress}
+	}
+
 	// Streaming mode: skip body buffering, hand raw reader to caller.
 	// Caller is responsible for closing the body reader.
 	if reqCopy.StreamBody() {
 		resp := getResponse()
 		resp.SetStatusCode(httpResp.StatusCode)
 		resp.SetStatus(httpResp.Status)
-		resp.SetHeaders(httpResp.Header)
+		resp.SetHeaders(c.responseProcessor.retainHeaders(httpResp.Header, false))
 		resp.SetContentLength(httpResp.ContentLength)
 		resp.SetProto(httpResp.Proto)
-		resp.SetCookies(httpResp.Cookies())
+		resp.SetTLS(httpResp.TLS)
+		resp.SetCookies(responseCookies(httpResp.Header))
+		if len(earlyHints) > 0 {
+			resp.SetEarlyHints(earlyHints)
+		}
+		// The body has not been read yet, so only the headers are received.
+		resp.SetBytesSent(wire.sent(httpResp))
+		resp.SetBytesReceived(wire.received(httpResp))
+		resp.SetConnectionID(wire.connectionID())
+		resp.SetTiming(timer.timing(time.Now()))
 		streamLimit := c.config.MaxResponseBodySize
 		if streamLimit <= 0 {
 			streamLimit = defaultMaxDecompressedSize
 		}
-		lr := getLimitReader(httpResp.Body, streamLimit)
-		resp.rawBodyReader = &streamBodyReader{reader: lr, source: httpResp.Body}
+		resp.rawBodyReader = &streamBodyReader{
+			reader: io.LimitedReader{R: httpResp.Body, N: streamLimit},
+			source: httpResp.Body,
+		}
 		resp.cancelFunc = streamCancel
+		resp.streamCtx = execCtx
 		setCancelFuncToNil() // Prevent deferred cancel; ReleaseResponse handles cleanup
 
 		if httpResp.Request != nil {
@@ -1028,6 +1642,9 @@ func (c *Client) executeRequest(req *Request, skipCopy bool) (*Response, error)
 			resp.SetRedirectChain(redirectChain)
 			resp.SetRedirectCount(len(redirectChain))
 		}
+		if redirectSettings != nil {
+			resp.SetRedirectHops(redirectSettings.hops)
+		}
 
 		// Invoke OnResponse callback for streaming responses
 		if reqCopy.onResponse != nil {
@@ -1055,15 +1672,26 @@ func (c *Client) executeRequest(req *Request, skipCopy bool) (*Response, error)
 		}
 	}()
 
+	wire.countResponseBody(httpResp)
 	resp, err := c.responseProcessor.Process(httpResp)
 	if err != nil {
 		return nil, classifyErrorWithSanitizedURL(err, sanitizeOnce(), req.Method(), 0)
 	}
+	if len(earlyHints) > 0 {
+		resp.SetEarlyHints(earlyHints)
+	}
+	resp.SetBytesSent(wire.sent(httpResp))
+	resp.SetBytesReceived(wire.received(httpResp))
+	resp.SetConnectionID(wire.connectionID())
+	resp.SetTiming(timer.timing(time.Now()))
 
 	if redirectChain := c.transport.GetRedirectChain(reqCopy.context); len(redirectChain) > 0 {
 		resp.SetRedirectChain(redirectChain)
 		resp.SetRedirectCount(len(redirectChain))
 	}
+	if redirectSettings != nil {
+		resp.SetRedirectHops(redirectSettings.hops)
+	}
 
 	if httpResp.Request != nil {
 		resp.SetRequestHeaders(captureRequestHeaders(httpResp.Request))
@@ -1097,6 +1725,11 @@ func (c *Client) isHealthy() bool {
 	return c.metrics.isHealthy()
 }
 
+// Stats returns a snapshot of the client's request counters.
+func (c *Client) Stats() types.Stats {
+	return c.metrics.stats()
+}
+
 // IsClosed returns true if the client has been closed.
 func (c *Client) IsClosed() bool {
 	return atomic.LoadInt32(&c.closed) == 1
@@ -1133,3 +1766,19 @@ type clientOption func(*clientOptions)
 type clientOptions struct {
 	customTransport transportManager
 }
+
+// earlyHintsTrace returns a client trace that collects the headers of every
+// 103 Early Hints response into hints. Other 1xx responses are ignored.
+// Got1xxResponse runs before RoundTrip returns, so hints needs no locking.
+func earlyHintsTrace(hints http.Header) *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == http.StatusEarlyHints {
+				for k, v := range header {
+					hints[k] = append(hints[k], v...)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -819,3 +819,35 @@ func TestClient_SetRawBodyReader(t *testing.T) {
 	// Restore original to avoid leak if it was non-nil
 	_ = originalReader
 }
+
+func TestClient_RequestTimeoutPerMB(t *testing.T) {
+	c := &Client{config: &Config{Timeout: time.Second, TimeoutPerMB: 100 * time.Millisecond}}
+
+	tests := []struct {
+		name    string
+		setup   func(r *Request)
+		timeout time.Duration
+	}{
+		{"no body", func(r *Request) {}, time.Second},
+		{"declared content length", func(r *Request) { r.SetHeader("content-length", "52428800") }, 6 * time.Second},
+		{"byte body", func(r *Request) { r.SetBody(make([]byte, 2<<20)) }, 1200 * time.Millisecond},
+		{"request timeout is extended", func(r *Request) {
+			r.SetTimeout(3 * time.Second)
+			r.SetBody(make([]byte, 10<<20))
+		}, 4 * time.Second},
+		{"no timeout stays unlimited", func(r *Request) {
+			r.SetBody(make([]byte, 10<<20))
+			c.config.Timeout = 0
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &Request{}
+			tt.setup(req)
+			if got := c.requestTimeout(req); got != tt.timeout {
+				t.Errorf("Expected timeout %v, got %v", tt.timeout, got)
+			}
+		})
+	}
+}
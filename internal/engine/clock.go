@@ -0,0 +1,21 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/cybergodev/httpc/internal/types"
+)
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// clockOf returns config.Clock, or the real clock when it is unset.
+func clockOf(config *Config) types.Clock {
+	if config.Clock != nil {
+		return config.Clock
+	}
+	return realClock{}
+}
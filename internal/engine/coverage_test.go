@@ -894,6 +894,33 @@ func TestPooledLimitReader(t *testing.T) {
 	})
 }
 
+// TestStreamBodyReader validates closing a streamed body during a pending read.
+func TestStreamBodyReader(t *testing.T) {
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	body := &streamBodyReader{reader: io.LimitedReader{R: pr, N: 5}, source: pr}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(body)
+		done <- err
+	}()
+	if _, err := pw.Write([]byte("abc")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	select {
+	case err := <-done:
+		if !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("Expected the pending read to fail with io.ErrClosedPipe, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Close did not unblock the pending read")
+	}
+}
+
 // TestResponseProcessor_NilResponse validates nil response handling.
 func TestResponseProcessor_NilResponse(t *testing.T) {
 	config := &Config{Timeout: 30 * time.Second}
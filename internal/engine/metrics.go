@@ -3,6 +3,8 @@ package engine
 import (
 	"sync/atomic"
 	"time"
+
+	"github.com/cybergodev/httpc/internal/types"
 )
 
 // metricsSnapshot represents a point-in-time snapshot of client metrics.
@@ -30,6 +32,7 @@ type metrics struct {
 	successfulRequests atomic.Int64
 	failedRequests     atomic.Int64
 	averageLatency     atomic.Int64 // stored as nanoseconds
+	inFlight           atomic.Int64
 }
 
 // recordRequest records the result of a single request.
@@ -70,6 +73,17 @@ func (m *metrics) snapshot() metricsSnapshot {
 	}
 }
 
+// stats returns the counters in their exported form.
+func (m *metrics) stats() types.Stats {
+	return types.Stats{
+		TotalRequests:      m.totalRequests.Load(),
+		SuccessfulRequests: m.successfulRequests.Load(),
+		FailedRequests:     m.failedRequests.Load(),
+		InFlight:           m.inFlight.Load(),
+		AverageLatency:     time.Duration(m.averageLatency.Load()),
+	}
+}
+
 // reset resets all metrics to zero.
 func (m *metrics) reset() {
 	m.totalRequests.Store(0)
@@ -0,0 +1,251 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/cybergodev/httpc/internal/types"
+)
+
+// hasStreamedFiles reports whether fd contains a file part backed by a Reader.
+// Such bodies are encoded lazily and cannot be replayed on retry.
+func hasStreamedFiles(fd *types.FormData) bool {
+	if fd == nil {
+		return false
+	}
+	for _, f := range fd.Files {
+		if f != nil && f.Reader != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// createFilePart starts the part for a file upload. Without an explicit
+// ContentType, the type is sniffed from Content; streamed files have theirs
+// resolved beforehand by detectStreamedContentTypes.
+func createFilePart(writer *multipart.Writer, key string, fileData *types.FileData) (io.Writer, error) {
+	contentType := fileData.ContentType
+	if contentType == "" {
+		contentType = detectContentType(fileData.Content)
+	}
+	h := getMIMEHeader()
+	defer putMIMEHeader(h)
+	h.Set("Content-Disposition", `form-data; name="`+escapeQuotes(key)+`"; filename="`+escapeQuotes(fileData.Filename)+`"`)
+	h.Set("Content-Type", contentType)
+	return writer.CreatePart(*h)
+}
+
+// createFieldPart starts a non-file part carrying its own Content-Type.
+func createFieldPart(writer *multipart.Writer, key string, partData *types.PartData) (io.Writer, error) {
+	contentType := partData.ContentType
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	h := getMIMEHeader()
+	defer putMIMEHeader(h)
+	h.Set("Content-Disposition", `form-data; name="`+escapeQuotes(key)+`"`)
+	h.Set("Content-Type", contentType)
+	return writer.CreatePart(*h)
+}
+
+// writeFieldParts writes fd's Parts with writer.
+func writeFieldParts(writer *multipart.Writer, fd *types.FormData) error {
+	for key, partData := range fd.Parts {
+		if partData == nil {
+			continue
+		}
+		part, err := createFieldPart(writer, key, partData)
+		if err != nil {
+			return fmt.Errorf("create form part failed: %w", err)
+		}
+		if _, err := part.Write(partData.Content); err != nil {
+			return fmt.Errorf("write form part failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// detectContentType sniffs the MIME type of a file from its leading bytes,
+// falling back to application/octet-stream for empty content.
+func detectContentType(content []byte) string {
+	if len(content) == 0 {
+		return "application/octet-stream"
+	}
+	if len(content) > sniffLen {
+		content = content[:sniffLen]
+	}
+	return http.DetectContentType(content)
+}
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// detectStreamedContentTypes returns fd with a ContentType filled in for every
+// streamed file lacking one. The type is sniffed from the first sniffLen
+// bytes of the Reader, which are then replayed ahead of the rest. fd itself
+// is left untouched; a copy is returned only when something changed.
+func detectStreamedContentTypes(fd *types.FormData) *types.FormData {
+	var out *types.FormData
+	for key, f := range fd.Files {
+		if f == nil || f.Reader == nil || f.ContentType != "" {
+			continue
+		}
+		if out == nil {
+			out = &types.FormData{Fields: fd.Fields, Parts: fd.Parts, Files: maps.Clone(fd.Files)}
+		}
+		head := make([]byte, sniffLen)
+		n, err := io.ReadFull(f.Reader, head)
+		head = head[:n]
+		rest := f.Reader
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			rest = eofReader{}
+		} else if err != nil {
+			rest = errReader{err}
+		}
+		copied := *f
+		copied.ContentType = detectContentType(head)
+		copied.Reader = io.MultiReader(bytes.NewReader(head), rest)
+		out.Files[key] = &copied
+	}
+	if out == nil {
+		return fd
+	}
+	return out
+}
+
+// eofReader is an exhausted reader.
+type eofReader struct{}
+
+func (eofReader) Read([]byte) (int, error) { return 0, io.EOF }
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// streamingMultipartBody is a multipart body encoded on the fly by a
+// goroutine writing into a pipe, so file Readers are never buffered.
+// size is the exact encoded length, or -1 if any Reader size is unknown.
+type streamingMultipartBody struct {
+	*io.PipeReader
+	size int64
+}
+
+// newStreamingMultipartBody starts encoding fd into a pipe and returns the
+// read side along with the form's Content-Type. Streamed files without a
+// ContentType are sniffed first, which reads their leading bytes up front. The encoder goroutine exits
+// when the body is fully written or the reader is closed; the transport
+// always closes request bodies, including on failure.
+func newStreamingMultipartBody(fd *types.FormData) (*streamingMultipartBody, string) {
+	fd = detectStreamedContentTypes(fd)
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	body := &streamingMultipartBody{PipeReader: pr, size: multipartSize(fd, writer.Boundary())}
+
+	go func() {
+		pw.CloseWithError(writeMultipart(writer, fd))
+	}()
+
+	return body, writer.FormDataContentType()
+}
+
+// writeMultipart encodes fd's fields, parts and files with writer and closes it.
+func writeMultipart(writer *multipart.Writer, fd *types.FormData) error {
+	for key, value := range fd.Fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return fmt.Errorf("write form field failed: %w", err)
+		}
+	}
+	if err := writeFieldParts(writer, fd); err != nil {
+		return err
+	}
+	for key, fileData := range fd.Files {
+		if fileData == nil {
+			continue
+		}
+		part, err := createFilePart(writer, key, fileData)
+		if err != nil {
+			return fmt.Errorf("create form file failed: %w", err)
+		}
+		if fileData.Reader == nil {
+			_, err = part.Write(fileData.Content)
+		} else if fileData.Size > 0 {
+			// Content-Length was computed from Size; a short reader must fail
+			// rather than silently send a truncated body.
+			_, err = io.CopyN(part, fileData.Reader, fileData.Size)
+		} else {
+			_, err = io.Copy(part, fileData.Reader)
+		}
+		if err != nil {
+			return fmt.Errorf("write file content failed: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close multipart writer failed: %w", err)
+	}
+	return nil
+}
+
+// multipartSize returns the encoded length of fd with the given boundary, or
+// -1 if a streamed file has no known Size. It encodes the form without file
+// contents and adds the content lengths, so nothing is buffered.
+func multipartSize(fd *types.FormData, boundary string) int64 {
+	var contents int64
+	for _, f := range fd.Files {
+		if f == nil {
+			continue
+		}
+		if f.Reader == nil {
+			contents += int64(len(f.Content))
+		} else if f.Size > 0 {
+			contents += f.Size
+		} else {
+			return -1
+		}
+	}
+
+	var cw countingWriter
+	writer := multipart.NewWriter(&cw)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return -1
+	}
+	for key, value := range fd.Fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return -1
+		}
+	}
+	for key, p := range fd.Parts {
+		if p == nil {
+			continue
+		}
+		if _, err := createFieldPart(writer, key, p); err != nil {
+			return -1
+		}
+		contents += int64(len(p.Content))
+	}
+	for key, f := range fd.Files {
+		if f == nil {
+			continue
+		}
+		if _, err := createFilePart(writer, key, f); err != nil {
+			return -1
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return -1
+	}
+	return cw.n + contents
+}
+
+// countingWriter discards writes, counting the bytes.
+type countingWriter struct{ n int64 }
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
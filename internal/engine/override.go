@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/cybergodev/httpc/internal/types"
+)
+
+// configOverrideKey is the context key for a *types.ConfigOverride.
+type configOverrideKey struct{}
+
+// WithConfigOverride returns a copy of ctx carrying o.
+func WithConfigOverride(ctx context.Context, o types.ConfigOverride) context.Context {
+	return context.WithValue(ctx, configOverrideKey{}, &o)
+}
+
+// applyConfigOverride fills the timeout and max retries of req from the
+// override in its context, leaving values set by request options alone.
+func applyConfigOverride(req *Request) {
+	ctx := req.Context()
+	if ctx == nil {
+		return
+	}
+	o, ok := ctx.Value(configOverrideKey{}).(*types.ConfigOverride)
+	if !ok {
+		return
+	}
+	if req.timeout <= 0 && o.Timeout > 0 {
+		req.timeout = o.Timeout
+	}
+	if req.maxRetries < 0 && o.MaxRetries != nil && *o.MaxRetries >= 0 {
+		req.maxRetries = *o.MaxRetries
+	}
+}
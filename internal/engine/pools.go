@@ -241,10 +241,7 @@ func appendQueryParams(existingQuery string, params map[string]any) string {
 		} else {
 			sb.WriteByte('&')
 		}
-		sb.WriteString(QueryEscape(key))
-		sb.WriteByte('=')
-
-		writeQueryParamValue(sb, value, numBuf[:0])
+		writeQueryParam(sb, key, value, numBuf[:0])
 	}
 
 	result := sb.String()
@@ -252,6 +249,26 @@ func appendQueryParams(existingQuery string, params map[string]any) string {
 	return result
 }
 
+// writeQueryParam appends "key=value" to sb. A non-empty []string value is
+// written as one pair per element ("tag=a&tag=b").
+func writeQueryParam(sb *strings.Builder, key string, value any, numBuf []byte) {
+	escapedKey := QueryEscape(key)
+	if values, ok := value.([]string); ok && len(values) > 0 {
+		for i, v := range values {
+			if i > 0 {
+				sb.WriteByte('&')
+			}
+			sb.WriteString(escapedKey)
+			sb.WriteByte('=')
+			sb.WriteString(QueryEscape(v))
+		}
+		return
+	}
+	sb.WriteString(escapedKey)
+	sb.WriteByte('=')
+	writeQueryParamValue(sb, value, numBuf)
+}
+
 // writeQueryParamValue appends a query parameter value to sb.
 // Numeric and bool values are written directly via strconv.Append*
 // to avoid intermediate string allocations. Strings are URL-escaped.
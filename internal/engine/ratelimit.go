@@ -0,0 +1,172 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRateLimitBuckets bounds the number of per-host buckets kept in memory.
+// When exceeded, buckets that have refilled completely are evicted; they carry
+// no state that a fresh bucket would not.
+const maxRateLimitBuckets = 1024
+
+// rateLimitKey identifies a token bucket. Requests overriding the limit via
+// WithRateLimit get their own bucket per host and limit, so they never drain
+// the client-wide bucket.
+type rateLimitKey struct {
+	host  string
+	rate  float64
+	burst int
+}
+
+// tokenBucket is a token-bucket limiter allowing rate tokens per second with
+// bursts of up to burst tokens. Tokens may go negative: each waiter reserves
+// its token up front, which keeps waiters in FIFO order without a queue.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// refill adds tokens accrued since the last update. Caller must hold b.mu.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// reserve takes one token and returns how long the caller must wait before
+// using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was never used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.mu.Unlock()
+}
+
+// full reports whether the bucket has refilled to its burst size.
+func (b *tokenBucket) full(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// hostRateLimiter holds one token bucket per host (and per-request override).
+type hostRateLimiter struct {
+	mu      sync.Mutex
+	buckets map[rateLimitKey]*tokenBucket
+}
+
+func newHostRateLimiter() *hostRateLimiter {
+	return &hostRateLimiter{buckets: make(map[rateLimitKey]*tokenBucket)}
+}
+
+// bucket returns the bucket for key, creating it if needed.
+func (l *hostRateLimiter) bucket(key rateLimitKey, now time.Time) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	if len(l.buckets) >= maxRateLimitBuckets {
+		for k, b := range l.buckets {
+			if b.full(now) {
+				delete(l.buckets, k)
+			}
+		}
+	}
+	b := newTokenBucket(key.rate, key.burst, now)
+	l.buckets[key] = b
+	return b
+}
+
+// wait blocks until a token for host is available or ctx is done.
+// Returns ctx.Err() if the context ends first; the token is then released.
+func (l *hostRateLimiter) wait(ctx context.Context, host string, rate float64, burst int) error {
+	now := time.Now()
+	b := l.bucket(rateLimitKey{host: host, rate: rate, burst: burst}, now)
+	delay := b.reserve(now)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// waitRateLimit blocks until the request's host has a token available, using
+// the per-request limit if set and the client-wide limit otherwise. The wait is
+// bounded by the request context and timeout; on expiry it returns a classified
+// timeout or cancellation error.
+func (c *Client) waitRateLimit(req *Request) error {
+	rate, burst := req.RateLimit()
+	if rate <= 0 {
+		rate, burst = c.config.RateLimit, c.config.RateLimitBurst
+	}
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	u, err := url.Parse(req.URL())
+	if err != nil {
+		return classifyError(fmt.Errorf("rate limit: %w", err), req.URL(), req.Method(), 0)
+	}
+
+	ctx := req.Context()
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+	timeout := req.Timeout()
+	if timeout <= 0 {
+		timeout = c.config.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := c.rateLimiter.wait(ctx, strings.ToLower(u.Host), rate, burst); err != nil {
+		return classifyError(fmt.Errorf("waiting for rate limit: %w", err), req.URL(), req.Method(), 0)
+	}
+	return nil
+}
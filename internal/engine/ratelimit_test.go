@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// RATE LIMITER UNIT TESTS
+// ============================================================================
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(10, 2, now)
+
+	// Burst is available immediately.
+	if d := b.reserve(now); d != 0 {
+		t.Errorf("first reserve delay = %v, want 0", d)
+	}
+	if d := b.reserve(now); d != 0 {
+		t.Errorf("second reserve delay = %v, want 0", d)
+	}
+	// Third token must wait 1/rate; fourth waits behind it.
+	if d := b.reserve(now); d != 100*time.Millisecond {
+		t.Errorf("third reserve delay = %v, want 100ms", d)
+	}
+	if d := b.reserve(now); d != 200*time.Millisecond {
+		t.Errorf("fourth reserve delay = %v, want 200ms", d)
+	}
+
+	// Refill is capped at burst.
+	later := now.Add(time.Hour)
+	if !b.full(later) {
+		t.Error("bucket should be full after an hour")
+	}
+}
+
+func TestHostRateLimiter_Wait(t *testing.T) {
+	l := newHostRateLimiter()
+
+	t.Run("hosts are independent", func(t *testing.T) {
+		ctx := context.Background()
+		if err := l.wait(ctx, "a.example", 1, 1); err != nil {
+			t.Fatalf("wait a: %v", err)
+		}
+		start := time.Now()
+		if err := l.wait(ctx, "b.example", 1, 1); err != nil {
+			t.Fatalf("wait b: %v", err)
+		}
+		if time.Since(start) > 50*time.Millisecond {
+			t.Error("second host should not wait on the first host's bucket")
+		}
+	})
+
+	t.Run("context cancellation releases token", func(t *testing.T) {
+		ctx := context.Background()
+		if err := l.wait(ctx, "c.example", 0.5, 1); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+		cctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err := l.wait(cctx, "c.example", 0.5, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want DeadlineExceeded", err)
+		}
+
+		b := l.bucket(rateLimitKey{host: "c.example", rate: 0.5, burst: 1}, time.Now())
+		b.mu.Lock()
+		tokens := b.tokens
+		b.mu.Unlock()
+		if tokens < -0.1 {
+			t.Errorf("cancelled reservation was not returned, tokens = %v", tokens)
+		}
+	})
+}
@@ -206,6 +206,19 @@ func getPooledBytesReader(b []byte) io.Reader {
 	return wrapper
 }
 
+// sizedReader is a reader body whose length is known up front, so the
+// request carries a Content-Length instead of chunked encoding.
+type sizedReader struct {
+	io.Reader
+	size int64
+}
+
+// SizedReader returns a request body that sends the first n bytes of r with
+// a Content-Length of n. The request fails if r ends before n bytes.
+func SizedReader(r io.Reader, n int64) io.Reader {
+	return &sizedReader{Reader: io.LimitReader(r, n), size: n}
+}
+
 // rawCacheMaxSize limits the raw-string URL cache to prevent unbounded growth
 // from URL variants (e.g., different query parameter orderings for the same endpoint).
 const rawCacheMaxSize = 2048
@@ -657,6 +670,11 @@ func (p *requestProcessor) Build(req *Request) (*http.Request, error) {
 		parsedURL.RawQuery = appendQueryParams(parsedURL.RawQuery, req.QueryParams())
 	}
 
+	if req.Body() != nil && !p.config.AllowGetBody &&
+		(req.Method() == http.MethodGet || req.Method() == http.MethodHead) {
+		return nil, fmt.Errorf("request body not allowed for %s requests (enable AllowGetBody to send one)", req.Method())
+	}
+
 	var body io.Reader
 	var contentType string
 
@@ -683,6 +701,10 @@ func (p *requestProcessor) Build(req *Request) (*http.Request, error) {
 				}
 				body = getPooledBytesReader(xmlData)
 				contentType = "application/xml"
+			} else if fd, ok := v.(*types.FormData); ok && hasStreamedFiles(fd) {
+				// Files backed by a Reader are encoded lazily through a pipe
+				// so large uploads are never held in memory.
+				body, contentType = newStreamingMultipartBody(fd)
 			} else if fd, ok := v.(*types.FormData); ok {
 				// Use pooled buffer for multipart form data
 				buf := getMultipartBuffer()
@@ -695,28 +717,17 @@ func (p *requestProcessor) Build(req *Request) (*http.Request, error) {
 					}
 				}
 
+				if err := writeFieldParts(writer, fd); err != nil {
+					putMultipartBuffer(buf)
+					return nil, err
+				}
+
 				for key, fileData := range fd.Files {
 					if fileData == nil {
 						continue
 					}
 
-					var part io.Writer
-					var err error
-
-					if fileData.ContentType != "" {
-						h := getMIMEHeader()
-						escapedKey := escapeQuotes(key)
-						escapedFilename := escapeQuotes(fileData.Filename)
-						contentDisposition := `form-data; name="` + escapedKey + `"; filename="` + escapedFilename + `"`
-
-						h.Set("Content-Disposition", contentDisposition)
-						h.Set("Content-Type", fileData.ContentType)
-						part, err = writer.CreatePart(*h)
-						putMIMEHeader(h)
-					} else {
-						part, err = writer.CreateFormFile(key, fileData.Filename)
-					}
-
+					part, err := createFilePart(writer, key, fileData)
 					if err != nil {
 						putMultipartBuffer(buf)
 						return nil, fmt.Errorf("create form file failed: %w", err)
@@ -779,11 +790,24 @@ func (p *requestProcessor) Build(req *Request) (*http.Request, error) {
 		Body:       bodyRC,
 		Host:       parsedURL.Host,
 	}
+	if req.host != "" {
+		httpReq.Host = req.host
+	}
 	httpReq = httpReq.WithContext(ctx)
 
 	// Set Content-Length from known body types
 	p.setContentLength(httpReq, body)
 
+	// In-memory bodies can be replayed by the transport, e.g. to follow a
+	// 307 or 308 redirect. Reader bodies are buffered to []byte by the
+	// retry loop, so they are replayable whenever retries are enabled.
+	switch v := req.Body().(type) {
+	case string:
+		httpReq.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(v)), nil }
+	case []byte:
+		httpReq.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(v)), nil }
+	}
+
 	if contentType != "" && httpReq.Header.Get("Content-Type") == "" {
 		httpReq.Header.Set("Content-Type", contentType)
 	}
@@ -817,6 +841,13 @@ func (p *requestProcessor) Build(req *Request) (*http.Request, error) {
 		httpReq.AddCookie(&cookies[i])
 	}
 
+	// Raw headers go in last, replacing any canonical equivalent, so the
+	// HTTP/1.1 transport writes the name exactly as given.
+	for name, value := range req.RawHeaders() {
+		httpReq.Header.Del(name)
+		httpReq.Header[name] = []string{value}
+	}
+
 	return httpReq, nil
 }
 
@@ -840,6 +871,15 @@ func (p *requestProcessor) setContentLength(req *http.Request, body io.Reader) {
 		if v.buf != nil {
 			req.ContentLength = int64(v.buf.Len())
 		}
+	case *streamingMultipartBody:
+		if v.size > 0 {
+			req.ContentLength = v.size
+		}
+	case *sizedReader:
+		req.ContentLength = v.size
+		if v.size == 0 {
+			req.Body = http.NoBody
+		}
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -116,12 +117,16 @@ func putLimitReader(lr *pooledLimitReader) {
 	limitReaderPool.Put(lr)
 }
 
-// streamBodyReader wraps a pooledLimitReader to enforce MaxResponseBodySize
-// in streaming mode. It also holds a reference to the underlying source body
-// so Close() properly closes the original http.Response.Body and returns the
-// pooledLimitReader to the pool.
+// streamBodyReader enforces MaxResponseBodySize in streaming mode. It also
+// holds a reference to the underlying source body so Close() properly closes
+// the original http.Response.Body.
+//
+// Callers close a streamed body from another goroutine to unblock a pending
+// Read, so Close only touches the source body. The limit reader is therefore
+// not pooled: returning it to the pool mid-read would hand it to another
+// request while this one is still reading through it.
 type streamBodyReader struct {
-	reader *pooledLimitReader
+	reader io.LimitedReader
 	source io.ReadCloser
 }
 
@@ -130,7 +135,6 @@ func (s *streamBodyReader) Read(p []byte) (int, error) {
 }
 
 func (s *streamBodyReader) Close() error {
-	putLimitReader(s.reader)
 	return s.source.Close()
 }
 
@@ -169,12 +173,37 @@ func getResponse() *Response {
 
 type responseProcessor struct {
 	config *Config
+	// retain holds the canonical names of Config.RetainResponseHeaders.
+	retain []string
 }
 
 func newResponseProcessor(config *Config) *responseProcessor {
-	return &responseProcessor{
+	p := &responseProcessor{
 		config: config,
 	}
+	for _, name := range config.RetainResponseHeaders {
+		p.retain = append(p.retain, http.CanonicalHeaderKey(name))
+	}
+	return p
+}
+
+// retainHeaders returns the headers to store on a Response. Without an
+// allowlist it returns src itself, or a clone when clone is set; with one it
+// copies only the listed headers, which never aliases src.
+func (p *responseProcessor) retainHeaders(src http.Header, clone bool) http.Header {
+	if len(p.retain) == 0 {
+		if clone {
+			return CloneHeader(src)
+		}
+		return src
+	}
+	dst := make(http.Header, len(p.retain))
+	for _, name := range p.retain {
+		if v, ok := src[name]; ok {
+			dst[name] = append([]string(nil), v...)
+		}
+	}
+	return dst
 }
 
 func (p *responseProcessor) Process(httpResp *http.Response) (*Response, error) {
@@ -182,9 +211,20 @@ func (p *responseProcessor) Process(httpResp *http.Response) (*Response, error)
 		return nil, fmt.Errorf("HTTP response is nil")
 	}
 
-	wasCompressed := httpResp.Header.Get("Content-Encoding") != ""
+	encoding := httpResp.Header.Get("Content-Encoding")
+	if encoding == "" && p.config.SniffCompression && sniffGzip(httpResp) {
+		encoding = "gzip"
+	}
+	wasCompressed := encoding != ""
+
+	// Capture the encoded bytes alongside decoding when requested. The buffer is
+	// freshly allocated (never pooled) because ownership passes to the Response.
+	var compressed *bytes.Buffer
+	if wasCompressed && p.config.KeepCompressedBody {
+		compressed = &bytes.Buffer{}
+	}
 
-	body, err := p.readBody(httpResp)
+	body, err := p.readBody(httpResp, encoding, compressed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
@@ -209,20 +249,45 @@ func (p *responseProcessor) Process(httpResp *http.Response) (*Response, error)
 	resp.SetStatus(httpResp.Status)
 	// Clone headers so the engine owns the copy. This enables TransferHeaders()
 	// in the public layer to take ownership without a second clone.
-	resp.SetHeaders(CloneHeader(httpResp.Header))
+	resp.SetHeaders(p.retainHeaders(httpResp.Header, true))
 	resp.SetRawBody(body)
+	if compressed != nil {
+		resp.SetCompressedRawBody(compressed.Bytes())
+	}
 	// Body string is lazily converted on first access via Body() to avoid
 	// doubling memory when caller only uses RawBody
 	resp.SetContentLength(contentLength)
 	resp.SetProto(httpResp.Proto)
-	// Only parse cookies when Set-Cookie header is present to avoid unnecessary allocation
-	if _, ok := httpResp.Header["Set-Cookie"]; ok {
-		resp.SetCookies(httpResp.Cookies())
-	}
+	resp.SetTLS(httpResp.TLS)
+	resp.SetCookies(responseCookies(httpResp.Header))
 
 	return resp, nil
 }
 
+// responseCookies parses the Set-Cookie headers in h. Unlike
+// http.Response.Cookies it matches the header name case-insensitively, so
+// cookies are not dropped when a transport or middleware stores them under
+// a non-canonical key such as HTTP/2's lowercase "set-cookie". Invalid
+// cookies are skipped. Returns nil when there is no Set-Cookie header.
+func responseCookies(h http.Header) []*http.Cookie {
+	var cookies []*http.Cookie
+	add := func(values []string) {
+		for _, v := range values {
+			if c, err := http.ParseSetCookie(v); err == nil {
+				cookies = append(cookies, c)
+			}
+		}
+	}
+	// The canonical key comes first so the usual order is kept.
+	add(h["Set-Cookie"])
+	for name, values := range h {
+		if name != "Set-Cookie" && strings.EqualFold(name, "Set-Cookie") {
+			add(values)
+		}
+	}
+	return cookies
+}
+
 // readBody reads and optionally decompresses the response body with size limits.
 // Uses buffer and limit reader pools to reduce heap allocations.
 //
@@ -231,8 +296,11 @@ func (p *responseProcessor) Process(httpResp *http.Response) (*Response, error)
 // This function MUST return a freshly allocated []byte.
 // The returned slice must not be retained by any other reference (pool or shared buffer).
 //
+// When compressedOut is non-nil and the response is encoded, the bytes read from
+// the wire are copied into it before decompression.
+//
 // SECURITY: Implements protection against decompression bomb attacks.
-func (p *responseProcessor) readBody(httpResp *http.Response) ([]byte, error) {
+func (p *responseProcessor) readBody(httpResp *http.Response, encoding string, compressedOut *bytes.Buffer) ([]byte, error) {
 	if httpResp.Body == nil {
 		return nil, nil
 	}
@@ -243,12 +311,25 @@ func (p *responseProcessor) readBody(httpResp *http.Response) ([]byte, error) {
 	var decompressedLr *pooledLimitReader
 	var decompressor io.ReadCloser // Track decompressor for cleanup
 
-	if encoding := httpResp.Header.Get("Content-Encoding"); encoding != "" {
+	if encoding != "" {
+		if p.config.StrictEncoding {
+			var accepted string
+			if httpResp.Request != nil {
+				accepted = httpResp.Request.Header.Get("Accept-Encoding")
+			}
+			if err := checkContentEncoding(encoding, accepted); err != nil {
+				return nil, err
+			}
+		}
 		isCompressed = true
 		var err error
 		// SECURITY: Limit compressed data size before decompression to prevent zip bombs
 		compressedLr = getLimitReader(httpResp.Body, maxCompressedSize+1)
-		decompressor, err = p.createDecompressor(compressedLr, encoding)
+		var source io.Reader = compressedLr
+		if compressedOut != nil {
+			source = io.TeeReader(compressedLr, compressedOut)
+		}
+		decompressor, err = p.createDecompressor(source, encoding)
 		if err != nil {
 			putLimitReader(compressedLr)
 			return nil, fmt.Errorf("failed to create decompressor for %s: %w", encoding, err)
@@ -348,6 +429,54 @@ func (p *responseProcessor) readBody(httpResp *http.Response) ([]byte, error) {
 	return result, nil
 }
 
+// sniffGzip reports whether httpResp's body starts with the gzip magic
+// number. The peeked bytes are replayed, so the body reads as before.
+func sniffGzip(httpResp *http.Response) bool {
+	if httpResp.Body == nil || httpResp.Body == http.NoBody {
+		return false
+	}
+	var magic [2]byte
+	n, _ := io.ReadFull(httpResp.Body, magic[:])
+	if n == 0 {
+		return false
+	}
+	httpResp.Body = &teeReadCloser{
+		Reader: io.MultiReader(bytes.NewReader(magic[:n]), httpResp.Body),
+		Closer: httpResp.Body,
+	}
+	return n == len(magic) && magic[0] == 0x1f && magic[1] == 0x8b
+}
+
+// checkContentEncoding verifies that encoding is one the client both advertised
+// in accepted (an Accept-Encoding value) and is able to decode. Used when
+// StrictEncoding is enabled to turn a misbehaving server into a clear error.
+func checkContentEncoding(encoding, accepted string) error {
+	enc := strings.ToLower(strings.TrimSpace(encoding))
+	switch enc {
+	case "identity":
+		return nil
+	case "gzip", "deflate":
+	default:
+		return fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	for part := range strings.SplitSeq(accepted, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != enc && coding != "*" {
+			continue
+		}
+		// "q=0" explicitly refuses the coding.
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(strings.ToLower(params), " ", ""), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil && v == 0 {
+				continue
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("response content encoding %q was not advertised in Accept-Encoding %q", encoding, accepted)
+}
+
 // createDecompressor creates an appropriate decompressor based on the encoding type.
 // Uses pooled readers for gzip and deflate to reduce allocations.
 func (p *responseProcessor) createDecompressor(reader io.Reader, encoding string) (io.ReadCloser, error) {
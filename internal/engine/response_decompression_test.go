@@ -504,3 +504,50 @@ func TestCreateDecompressor_UnsupportedEncodings(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseProcessor_StrictEncoding(t *testing.T) {
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte("strict"))
+	_ = w.Close()
+
+	tests := []struct {
+		name     string
+		strict   bool
+		encoding string
+		accept   string
+		body     []byte
+		wantErr  string
+	}{
+		{"advertised gzip", true, "gzip", "gzip, deflate", gz.Bytes(), ""},
+		{"wildcard", true, "gzip", "*", gz.Bytes(), ""},
+		{"refused with q=0", true, "gzip", "deflate, gzip;q=0", gz.Bytes(), "not advertised"},
+		{"unadvertised deflate", true, "gzip", "deflate", gz.Bytes(), "not advertised"},
+		{"unknown encoding", true, "zstd", "gzip", []byte("garbage"), "unsupported content encoding"},
+		{"unknown encoding lenient", false, "zstd", "gzip", []byte("garbage"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor := newResponseProcessor(&Config{StrictEncoding: tt.strict})
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			req.Header.Set("Accept-Encoding", tt.accept)
+			resp, err := processor.Process(&http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"Content-Encoding": []string{tt.encoding}},
+				Body:       io.NopCloser(bytes.NewReader(tt.body)),
+				Request:    req,
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				ReleaseResponse(resp)
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -397,6 +397,37 @@ func TestResponseProcessor_CookieProcessing(t *testing.T) {
 	}
 }
 
+func TestResponseProcessor_LowercaseSetCookie(t *testing.T) {
+	processor := newResponseProcessor(&Config{Timeout: 30 * time.Second, MaxResponseBodySize: 1024})
+
+	httpResponse := &http.Response{
+		StatusCode: 200,
+		Status:     "200 OK",
+		Header: http.Header{
+			"Set-Cookie": {"a=1; Path=/"},
+			"set-cookie": {"b=2; HttpOnly", "c=3"},
+		},
+		Body:    io.NopCloser(strings.NewReader("OK")),
+		Request: &http.Request{},
+	}
+
+	resp, err := processor.Process(httpResponse)
+	if err != nil {
+		t.Fatalf("Failed to process response: %v", err)
+	}
+	cookies := resp.Cookies()
+	if len(cookies) != 3 {
+		t.Fatalf("Expected 3 cookies, got %d", len(cookies))
+	}
+	if cookies[0].Name != "a" || cookies[1].Name != "b" || !cookies[1].HttpOnly || cookies[2].Value != "3" {
+		t.Errorf("Unexpected cookies: %v", cookies)
+	}
+
+	if got := responseCookies(http.Header{"Content-Type": {"text/plain"}}); got != nil {
+		t.Errorf("Expected nil without Set-Cookie, got %v", got)
+	}
+}
+
 func TestResponseProcessor_ErrorHandling(t *testing.T) {
 	config := &Config{
 		Timeout: 30 * time.Second,
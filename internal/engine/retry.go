@@ -2,11 +2,16 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math"
 	"math/rand/v2"
 	"net/http"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cybergodev/httpc/internal/types"
@@ -14,15 +19,28 @@ import (
 
 type retryEngine struct {
 	config *Config
+	clock  types.Clock
+	randMu sync.Mutex // Guards config.JitterRand, which is not safe for concurrent use
+	// jsonValue is Config.RetryJSONValue in decoded-JSON form (float64,
+	// string, bool, map, slice), so it compares equal to body values.
+	jsonValue any
 }
 
 // Compile-time interface check
 var _ types.RetryPolicy = (*retryEngine)(nil)
 
 func newRetryEngine(config *Config) *retryEngine {
-	return &retryEngine{
+	r := &retryEngine{
 		config: config,
+		clock:  clockOf(config),
 	}
+	if config.RetryJSONValue != nil {
+		// Validated as marshalable by the public config layer.
+		if data, err := json.Marshal(config.RetryJSONValue); err == nil {
+			_ = json.Unmarshal(data, &r.jsonValue)
+		}
+	}
+	return r
 }
 
 // ShouldRetry implements types.RetryPolicy interface.
@@ -44,11 +62,18 @@ func (r *retryEngine) GetDelay(attempt int) time.Duration {
 
 // GetDelayWithResponse returns the delay for the given attempt, considering response headers.
 // It first checks for Retry-After header, then falls back to exponential backoff.
+// Retry-After is honored on any retried status, not only 429 and 503, so custom
+// retryable codes such as 413 or 301 respect the server's requested delay.
+// A Retry-After delay is capped at MaxRetryDelay when set; a date in the past
+// or "0" means the server is ready now, so no delay is applied.
 func (r *retryEngine) GetDelayWithResponse(attempt int, resp *Response) time.Duration {
 	// Check Retry-After header first
 	if resp != nil {
-		if retryAfterDelay := parseRetryAfterHeader(resp.Headers()); retryAfterDelay > 0 {
-			return retryAfterDelay
+		if delay, ok := retryAfterDelay(resp.Headers(), r.clock.Now()); ok {
+			if r.config.MaxRetryDelay > 0 && delay > r.config.MaxRetryDelay {
+				delay = r.config.MaxRetryDelay
+			}
+			return delay
 		}
 	}
 
@@ -57,53 +82,46 @@ func (r *retryEngine) GetDelayWithResponse(attempt int, resp *Response) time.Dur
 
 // parseRetryAfterHeader parses the Retry-After header and returns the delay duration.
 // Returns 0 if the header is not present or cannot be parsed.
-// Supports both delta-seconds and HTTP-date formats per RFC 7231.
+func parseRetryAfterHeader(headers http.Header) time.Duration {
+	delay, _ := retryAfterDelay(headers, time.Now())
+	return delay
+}
+
+// retryAfterDelay parses the first Retry-After header value, reporting
+// whether it was present and valid. Supports both delta-seconds and HTTP-date
+// formats per RFC 9110; a date before now yields a zero delay.
 // SECURITY: The delay is capped at maxRetryAfterDelay (60s) to prevent a malicious
 // server from causing indefinite waits via unreasonably large Retry-After values.
-func parseRetryAfterHeader(headers http.Header) time.Duration {
+func retryAfterDelay(headers http.Header, now time.Time) (time.Duration, bool) {
 	const maxRetryAfterDelay = 60 * time.Second
 
-	if headers == nil {
-		return 0
-	}
-
 	retryAfterValues := headers["Retry-After"]
 	if len(retryAfterValues) == 0 {
-		return 0
+		return 0, false
 	}
-
 	retryAfter := retryAfterValues[0]
 
-	// Try parsing as seconds (delta-seconds format)
-	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
-		delay := time.Duration(seconds) * time.Second
-		if delay > maxRetryAfterDelay {
-			delay = maxRetryAfterDelay
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		// Delta-seconds format; compare before multiplying to avoid overflow
+		if seconds > int(maxRetryAfterDelay/time.Second) {
+			return maxRetryAfterDelay, true
 		}
-		return delay
+		delay = time.Duration(seconds) * time.Second
+	} else if retryTime, err := http.ParseTime(retryAfter); err == nil {
+		// HTTP-date (IMF-fixdate, RFC 850 or asctime)
+		delay = retryTime.Sub(now)
+	} else if retryTime, err := time.Parse(time.RFC1123, retryAfter); err == nil {
+		// RFC1123 with a zone other than GMT (e.g., "UTC")
+		delay = retryTime.Sub(now)
+	} else if retryTime, err := time.Parse(time.RFC1123Z, retryAfter); err == nil {
+		// RFC1123 with numeric timezone (e.g., "Mon, 02 Jan 2006 15:04:05 -0700")
+		delay = retryTime.Sub(now)
+	} else {
+		return 0, false
 	}
 
-	// Try parsing as HTTP date (RFC1123 format)
-	if retryTime, err := time.Parse(time.RFC1123, retryAfter); err == nil {
-		if delay := time.Until(retryTime); delay > 0 {
-			if delay > maxRetryAfterDelay {
-				delay = maxRetryAfterDelay
-			}
-			return delay
-		}
-	}
-
-	// Try RFC1123 with numeric timezone (e.g., "Mon, 02 Jan 2006 15:04:05 -0700")
-	if retryTime, err := time.Parse(time.RFC1123Z, retryAfter); err == nil {
-		if delay := time.Until(retryTime); delay > 0 {
-			if delay > maxRetryAfterDelay {
-				delay = maxRetryAfterDelay
-			}
-			return delay
-		}
-	}
-
-	return 0
+	return min(max(delay, 0), maxRetryAfterDelay), true
 }
 
 // calculateExponentialDelay calculates the exponential backoff delay with optional jitter.
@@ -136,13 +154,41 @@ func (r *retryEngine) calculateExponentialDelay(attempt int) time.Duration {
 	}
 
 	// Apply jitter to prevent thundering herd
-	if r.config.Jitter {
+	switch r.jitterStrategy() {
+	case types.JitterProportional:
 		result = r.applyJitter(result)
+	case types.JitterFull:
+		result = r.getJitter(result + 1)
+	case types.JitterEqual:
+		result = result/2 + r.getJitter(result-result/2+1)
+	case types.JitterDecorrelated:
+		// Stateless form: the previous delay is the un-jittered backoff
+		// of the attempt before, so each attempt's range is known.
+		prev := delay
+		if attempt > 0 {
+			prev = time.Duration(exponentialDelay / backoffFactor)
+		}
+		lower, upper := delay, 3*prev
+		if maxDelay := r.config.MaxRetryDelay; maxDelay > 0 {
+			lower, upper = min(lower, maxDelay), min(upper, maxDelay)
+		}
+		result = lower + r.getJitter(upper-lower+1)
 	}
 
 	return result
 }
 
+// jitterStrategy resolves JitterAuto from the Jitter flag.
+func (r *retryEngine) jitterStrategy() types.JitterStrategy {
+	if s := r.config.JitterStrategy; s != types.JitterAuto {
+		return s
+	}
+	if r.config.Jitter {
+		return types.JitterProportional
+	}
+	return types.JitterNone
+}
+
 // applyJitter adds randomization to the delay to prevent thundering herd problems.
 func (r *retryEngine) applyJitter(delay time.Duration) time.Duration {
 	if delay <= 0 {
@@ -174,14 +220,74 @@ func (r *retryEngine) isRetryableError(err error) bool {
 }
 
 // getJitter generates pseudo-random jitter for retry delays.
-// Uses math/rand/v2 for high-quality randomness without security concerns.
+// Uses math/rand/v2 for high-quality randomness without security concerns,
+// or Config.JitterRand when injected for reproducible delays.
 func (r *retryEngine) getJitter(maxJitter time.Duration) time.Duration {
 	if maxJitter <= 0 {
 		return 0
 	}
+	if src := r.config.JitterRand; src != nil {
+		r.randMu.Lock()
+		defer r.randMu.Unlock()
+		return time.Duration(src.Int64N(int64(maxJitter)))
+	}
 	return time.Duration(rand.Int64N(int64(maxJitter)))
 }
 
+// matchesJSONField reports whether body is a JSON object whose
+// Config.RetryJSONField (a dot-separated path such as "error.retryable")
+// equals Config.RetryJSONValue, or, when no value is configured, holds
+// anything other than null or false.
+func (r *retryEngine) matchesJSONField(body []byte) bool {
+	if r.config.RetryJSONField == "" || len(body) == 0 {
+		return false
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return false
+	}
+	for key := range strings.SplitSeq(r.config.RetryJSONField, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		if v, ok = obj[key]; !ok {
+			return false
+		}
+	}
+	if r.config.RetryJSONValue == nil {
+		return v != nil && v != false
+	}
+	return reflect.DeepEqual(v, r.jsonValue)
+}
+
 func (r *retryEngine) isRetryableStatus(statusCode int) bool {
+	if codes := r.config.RetryableStatusCodes; codes != nil {
+		return slices.Contains(codes, statusCode)
+	}
 	return retryableStatusCodes[statusCode]
 }
+
+// idempotentMethods are retried by default (RFC 9110 Section 9.2.2); a
+// failed POST or PATCH may already have taken effect on the server.
+var idempotentMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+	http.MethodPut, http.MethodDelete,
+}
+
+// allowsMethod reports whether requests with method may be retried, using
+// override when non-nil, then Config.RetryableMethods, then the idempotent
+// methods. An empty method means GET.
+func (r *retryEngine) allowsMethod(method string, override []string) bool {
+	if method == "" {
+		method = http.MethodGet
+	}
+	methods := override
+	if methods == nil {
+		methods = r.config.RetryableMethods
+	}
+	if methods == nil {
+		methods = idempotentMethods
+	}
+	return slices.ContainsFunc(methods, func(m string) bool { return strings.EqualFold(m, method) })
+}
@@ -3,10 +3,13 @@ package engine
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/cybergodev/httpc/internal/types"
 )
 
 // ============================================================================
@@ -282,6 +285,108 @@ func TestRetryEngine_GetDelay_WithJitter(t *testing.T) {
 	}
 }
 
+func TestRetryEngine_GetDelay_SeededJitter(t *testing.T) {
+	sequence := func() []time.Duration {
+		engine := newRetryEngine(&Config{
+			RetryDelay:    100 * time.Millisecond,
+			BackoffFactor: 2.0,
+			Jitter:        true,
+			JitterRand:    rand.New(rand.NewPCG(42, 7)),
+		})
+		delays := make([]time.Duration, 8)
+		for i := range delays {
+			delays[i] = engine.GetDelay(i % 3)
+		}
+		return delays
+	}
+
+	first, second := sequence(), sequence()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Delay %d differs across runs with the same seed: %v vs %v", i, first[i], second[i])
+		}
+	}
+	if first[0] == first[3] && first[3] == first[6] {
+		t.Error("Expected jitter to vary delays within a seeded sequence")
+	}
+}
+
+func TestRetryEngine_GetDelay_JitterStrategies(t *testing.T) {
+	newEngine := func(s types.JitterStrategy) *retryEngine {
+		return newRetryEngine(&Config{
+			RetryDelay:     100 * time.Millisecond,
+			BackoffFactor:  2.0,
+			MaxRetryDelay:  time.Second,
+			Jitter:         true,
+			JitterStrategy: s,
+			JitterRand:     rand.New(rand.NewPCG(1, 2)),
+		})
+	}
+
+	t.Run("None", func(t *testing.T) {
+		if d := newEngine(types.JitterNone).GetDelay(1); d != 200*time.Millisecond {
+			t.Errorf("Expected the plain backoff of 200ms, got %v", d)
+		}
+	})
+
+	t.Run("Full", func(t *testing.T) {
+		engine := newEngine(types.JitterFull)
+		for attempt := range 6 {
+			// 100ms * 2^attempt, capped at 1s
+			limit := min(100*time.Millisecond<<attempt, time.Second)
+			for range 50 {
+				if d := engine.GetDelay(attempt); d < 0 || d > limit {
+					t.Fatalf("Attempt %d: delay %v outside [0, %v]", attempt, d, limit)
+				}
+			}
+		}
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		engine := newEngine(types.JitterEqual)
+		for range 50 {
+			if d := engine.GetDelay(2); d < 200*time.Millisecond || d > 400*time.Millisecond {
+				t.Fatalf("Delay %v outside [200ms, 400ms]", d)
+			}
+		}
+	})
+
+	t.Run("Decorrelated", func(t *testing.T) {
+		engine := newEngine(types.JitterDecorrelated)
+		for attempt := range 6 {
+			// Three times the previous backoff (the base for attempt 0),
+			// capped at 1s.
+			prev := 100 * time.Millisecond
+			if attempt > 0 {
+				prev = 100 * time.Millisecond << (attempt - 1)
+			}
+			limit := min(3*prev, time.Second)
+			seen := map[time.Duration]bool{}
+			for range 50 {
+				d := engine.GetDelay(attempt)
+				if d < 100*time.Millisecond || d > limit {
+					t.Fatalf("Attempt %d: delay %v outside [100ms, %v]", attempt, d, limit)
+				}
+				seen[d] = true
+			}
+			if len(seen) < 2 {
+				t.Errorf("Attempt %d: expected varying delays", attempt)
+			}
+		}
+	})
+
+	t.Run("Auto", func(t *testing.T) {
+		engine := newEngine(types.JitterAuto)
+		if engine.jitterStrategy() != types.JitterProportional {
+			t.Error("Expected Jitter to select the proportional strategy")
+		}
+		engine.config.Jitter = false
+		if engine.jitterStrategy() != types.JitterNone {
+			t.Error("Expected no jitter when Jitter is off")
+		}
+	})
+}
+
 // TestRetryEngine_GetDelay_TableDriven consolidates MaxRetryDelay and DefaultValues
 // into a single table-driven test.
 func TestRetryEngine_GetDelay_TableDriven(t *testing.T) {
@@ -685,3 +790,61 @@ func TestRetryEngine_GetDelayWithResponse(t *testing.T) {
 		}
 	})
 }
+
+func TestRetryEngine_GetDelayWithResponse_RetryAfterForms(t *testing.T) {
+	engine := newRetryEngine(&Config{
+		RetryDelay:    100 * time.Millisecond,
+		BackoffFactor: 2.0,
+		MaxRetryDelay: 20 * time.Second,
+	})
+	delayFor := func(value string) time.Duration {
+		resp := &Response{}
+		resp.SetHeaders(http.Header{"Retry-After": {value}})
+		return engine.GetDelayWithResponse(0, resp)
+	}
+
+	t.Run("seconds", func(t *testing.T) {
+		if delay := delayFor("5"); delay != 5*time.Second {
+			t.Errorf("Expected 5s, got %v", delay)
+		}
+	})
+
+	t.Run("seconds clamped to MaxRetryDelay", func(t *testing.T) {
+		if delay := delayFor("45"); delay != 20*time.Second {
+			t.Errorf("Expected 20s, got %v", delay)
+		}
+	})
+
+	t.Run("zero seconds retries immediately", func(t *testing.T) {
+		if delay := delayFor("0"); delay != 0 {
+			t.Errorf("Expected 0, got %v", delay)
+		}
+	})
+
+	t.Run("absolute date", func(t *testing.T) {
+		date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+		if delay := delayFor(date); delay < 8*time.Second || delay > 10*time.Second {
+			t.Errorf("Expected about 10s, got %v", delay)
+		}
+	})
+
+	t.Run("absolute date clamped to MaxRetryDelay", func(t *testing.T) {
+		date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+		if delay := delayFor(date); delay != 20*time.Second {
+			t.Errorf("Expected 20s, got %v", delay)
+		}
+	})
+
+	t.Run("past date retries immediately", func(t *testing.T) {
+		date := time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)
+		if delay := delayFor(date); delay != 0 {
+			t.Errorf("Expected 0 for past date, got %v", delay)
+		}
+	})
+
+	t.Run("invalid value uses exponential backoff", func(t *testing.T) {
+		if delay := delayFor("soon"); delay != 100*time.Millisecond {
+			t.Errorf("Expected 100ms, got %v", delay)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/cybergodev/httpc/internal/types"
+)
+
+// phaseTimer records when the phases of the final request hop began and
+// ended. Trace hooks run on transport goroutines, so the times are guarded
+// by mu. Network phases are timed with the real clock, not Config.Clock.
+type phaseTimer struct {
+	mu sync.Mutex
+	t  phaseTimes
+}
+
+// phaseTimes holds the recorded times; zero times were not reached.
+type phaseTimes struct {
+	start                     time.Time
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	firstByte                 time.Time
+}
+
+// attach installs a client trace on httpReq that records the phase times.
+func (p *phaseTimer) attach(httpReq *http.Request) *http.Request {
+	mark := func(t *time.Time) {
+		p.mu.Lock()
+		*t = time.Now()
+		p.mu.Unlock()
+	}
+	trace := &httptrace.ClientTrace{
+		// GetConn starts every hop, including redirects; only the last
+		// request sent is reported.
+		GetConn: func(string) {
+			p.mu.Lock()
+			p.t = phaseTimes{start: time.Now()}
+			p.mu.Unlock()
+		},
+		DNSStart: func(httptrace.DNSStartInfo) { mark(&p.t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { mark(&p.t.dnsDone) },
+		ConnectStart: func(string, string) {
+			p.mu.Lock()
+			// Dual-stack dialing may start several attempts; time from the first.
+			if p.t.connectStart.IsZero() {
+				p.t.connectStart = time.Now()
+			}
+			p.mu.Unlock()
+		},
+		ConnectDone:          func(string, string, error) { mark(&p.t.connectDone) },
+		TLSHandshakeStart:    func() { mark(&p.t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&p.t.tlsDone) },
+		GotFirstResponseByte: func() { mark(&p.t.firstByte) },
+	}
+	return httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace))
+}
+
+// timing returns the phase durations, with Total measured up to end.
+func (p *phaseTimer) timing(end time.Time) types.Timing {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	t := p.t
+	if t.start.IsZero() {
+		return types.Timing{}
+	}
+	return types.Timing{
+		DNS:     phaseDuration(t.dnsStart, t.dnsDone),
+		Connect: phaseDuration(t.connectStart, t.connectDone),
+		TLS:     phaseDuration(t.tlsStart, t.tlsDone),
+		TTFB:    phaseDuration(t.start, t.firstByte),
+		Total:   phaseDuration(t.start, end),
+	}
+}
+
+// phaseDuration returns done-start, or 0 if either time is unset.
+func phaseDuration(start, done time.Time) time.Duration {
+	if start.IsZero() || done.IsZero() || done.Before(start) {
+		return 0
+	}
+	return done.Sub(start)
+}
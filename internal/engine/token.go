@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// applyTokenSource sets the Authorization header of req from the configured
+// TokenSource and, unless req already answers 401 challenges, arranges for
+// one refresh and resend on a 401. Requests with their own Authorization
+// header are left alone.
+func (c *Client) applyTokenSource(req *Request) error {
+	ts := c.config.TokenSource
+	if ts == nil || req.authChallenge != nil {
+		return nil
+	}
+	for name := range req.headers {
+		if strings.EqualFold(name, "Authorization") {
+			return nil
+		}
+	}
+	token, err := ts.Token()
+	if err != nil {
+		return fmt.Errorf("token source failed: %w", err)
+	}
+	req.SetHeader("Authorization", "Bearer "+token)
+	req.authChallenge = func(*Response) (string, bool) {
+		fresh, err := ts.Token()
+		if err != nil || fresh == "" || fresh == token {
+			return "", false // resending the same token is pointless
+		}
+		token = fresh
+		return "Bearer " + fresh, true
+	}
+	return nil
+}
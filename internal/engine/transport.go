@@ -6,11 +6,14 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cybergodev/httpc/internal/connection"
 	"github.com/cybergodev/httpc/internal/security"
+	"github.com/cybergodev/httpc/internal/types"
 	"github.com/cybergodev/httpc/internal/validation"
 )
 
@@ -24,9 +27,27 @@ const maxInlineRedirects = 8
 type redirectSettings struct {
 	followRedirects bool
 	maxRedirects    int
-	chainLen        int
-	inlineChain     [maxInlineRedirects]string
-	overflowChain   []string
+	sameHostOnly    bool
+	omitCookies     []string // Cookie names to strip from every outgoing hop
+	// returnLastOnLimit returns the last 3xx response instead of an error
+	// when maxRedirects is exceeded.
+	returnLastOnLimit bool
+	// allowPrivateIPs lifts SSRF checks on redirect targets for this request.
+	allowPrivateIPs bool
+	// checkRedirect is the user callback consulted last for each redirect.
+	checkRedirect types.CheckRedirectFunc
+	// redirectTimeout bounds the time from the first redirect response to
+	// the final response; abortRedirects cancels the request when it passes.
+	redirectTimeout time.Duration
+	abortRedirects  context.CancelCauseFunc
+	redirectTimer   *time.Timer
+	// hops records each followed redirect; hopStart is when the current
+	// hop's request was sent.
+	hops          []types.RedirectHop
+	hopStart      time.Time
+	chainLen      int
+	inlineChain   [maxInlineRedirects]string
+	overflowChain []string
 }
 
 // addRedirect adds a URL to the redirect chain.
@@ -46,6 +67,28 @@ func (s *redirectSettings) addRedirect(url string) {
 	s.chainLen++
 }
 
+// ErrRedirectTimeout is the cause of a request aborted because following its
+// redirects took longer than Config.MaxRedirectTime.
+var ErrRedirectTimeout = fmt.Errorf("redirects exceeded MaxRedirectTime: %w", context.DeadlineExceeded)
+
+// startRedirectTimer starts the MaxRedirectTime budget on the first redirect.
+func (s *redirectSettings) startRedirectTimer() {
+	if s.redirectTimeout <= 0 || s.abortRedirects == nil || s.redirectTimer != nil {
+		return
+	}
+	abort := s.abortRedirects
+	s.redirectTimer = time.AfterFunc(s.redirectTimeout, func() { abort(ErrRedirectTimeout) })
+}
+
+// stopRedirectTimer stops the MaxRedirectTime budget once the final response
+// has arrived.
+func (s *redirectSettings) stopRedirectTimer() {
+	if s.redirectTimer != nil {
+		s.redirectTimer.Stop()
+		s.redirectTimer = nil
+	}
+}
+
 // getChain returns the redirect chain as a slice.
 // Returns a freshly allocated copy to prevent mutation.
 func (s *redirectSettings) getChain() []string {
@@ -109,6 +152,16 @@ func putRedirectSettings(s *redirectSettings) {
 	// Reset all fields
 	s.followRedirects = false
 	s.maxRedirects = 0
+	s.sameHostOnly = false
+	s.omitCookies = nil
+	s.returnLastOnLimit = false
+	s.allowPrivateIPs = false
+	s.checkRedirect = nil
+	s.stopRedirectTimer()
+	s.redirectTimeout = 0
+	s.abortRedirects = nil
+	s.hops = nil
+	s.hopStart = time.Time{}
 	s.chainLen = 0
 	// Clear inline chain to allow GC of strings
 	for i := range s.inlineChain {
@@ -158,9 +211,10 @@ func newTransport(config *Config, pool *connection.PoolManager) (*transport, err
 		redirectWhitelist: config.RedirectWhitelist,
 	}
 
-	// Create http.Client with optional cookie jar
+	// Create http.Client with optional cookie jar. The cookie filter runs
+	// after the jar has added its cookies, so WithoutCookie covers them too.
 	httpClient := &http.Client{
-		Transport: httpTransport,
+		Transport: &cookieFilterTransport{base: httpTransport},
 	}
 
 	// Set cookie jar if enabled and provided
@@ -191,6 +245,12 @@ func (t *transport) checkRedirect(req *http.Request, via []*http.Request) error
 		return http.ErrUseLastResponse
 	}
 
+	// Same-host policy: hand the 3xx back to the caller instead of leaving
+	// the host of the original request.
+	if settings.sameHostOnly && len(via) > 0 && !strings.EqualFold(req.URL.Hostname(), via[0].URL.Hostname()) {
+		return http.ErrUseLastResponse
+	}
+
 	// SECURITY: Check redirect whitelist first
 	if t.redirectWhitelist != nil {
 		if !t.redirectWhitelist.IsAllowed(req.URL.Hostname()) {
@@ -200,15 +260,30 @@ func (t *transport) checkRedirect(req *http.Request, via []*http.Request) error
 
 	// SECURITY: Validate redirect target for SSRF protection
 	// This prevents redirects to private/reserved IP addresses when SSRF protection is enabled
-	if !t.allowPrivateIPs {
+	if !t.allowPrivateIPs && !settings.allowPrivateIPs {
 		if err := t.validateRedirectTarget(req.URL); err != nil {
 			return fmt.Errorf("redirect blocked: %w", err)
 		}
 	}
+	if settings.allowPrivateIPs && !t.allowPrivateIPs {
+		// http.Client does not carry Close over to redirect requests; the
+		// hop is dialed without SSRF checks, so keep it out of the pool too.
+		req.Close = true
+	}
 
 	// Track redirect chain
 	if len(via) > 0 {
-		settings.addRedirect(via[len(via)-1].URL.String())
+		from := via[len(via)-1].URL.String()
+		settings.addRedirect(from)
+		settings.startRedirectTimer()
+
+		now := time.Now()
+		hop := types.RedirectHop{FromURL: from, ToURL: req.URL.String(), Duration: now.Sub(settings.hopStart)}
+		if req.Response != nil {
+			hop.StatusCode = req.Response.StatusCode
+		}
+		settings.hops = append(settings.hops, hop)
+		settings.hopStart = now
 	}
 
 	// SECURITY: Strip sensitive headers on cross-origin redirects to prevent
@@ -220,6 +295,21 @@ func (t *transport) checkRedirect(req *http.Request, via []*http.Request) error
 		req.Header.Del("Cookie")
 	}
 
+	// http.Client has already set Referer to the previous URL unless the
+	// caller supplied one; drop the automatic value when disabled, and any
+	// value when leaving https for http.
+	if len(via) > 0 {
+		if via[len(via)-1].URL.Scheme == "https" && req.URL.Scheme == "http" {
+			req.Header.Del("Referer")
+		} else if t.config.DisableRefererOnRedirect {
+			if explicit := via[0].Header.Get("Referer"); explicit != "" {
+				req.Header.Set("Referer", explicit)
+			} else {
+				req.Header.Del("Referer")
+			}
+		}
+	}
+
 	// SECURITY: Detect circular redirects to prevent infinite loops.
 	// A circular redirect occurs when the target URL appeared earlier in the chain
 	// but was reached from a DIFFERENT URL (true cycle). Same-URL repeats (A→A→A)
@@ -244,17 +334,52 @@ func (t *transport) checkRedirect(req *http.Request, via []*http.Request) error
 
 	// Check redirect limit (0 means unlimited)
 	if settings.maxRedirects > 0 && len(via) >= settings.maxRedirects {
+		if settings.returnLastOnLimit {
+			return http.ErrUseLastResponse
+		}
 		return fmt.Errorf("stopped after %d redirects", settings.maxRedirects)
 	}
 
 	// Default Go limit is 10, we respect that if maxRedirects is 0
 	if settings.maxRedirects == 0 && len(via) >= 10 {
+		if settings.returnLastOnLimit {
+			return http.ErrUseLastResponse
+		}
 		return fmt.Errorf("stopped after 10 redirects")
 	}
 
+	if settings.checkRedirect != nil {
+		return settings.checkRedirect(req, via)
+	}
 	return nil
 }
 
+// cookieFilterTransport strips cookies named in the request's redirectSettings
+// from the Cookie header before delegating to base. http.Client adds jar cookies
+// before calling its Transport, so this is the only point where they can be removed.
+type cookieFilterTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (f *cookieFilterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	settings, ok := req.Context().Value(redirectContextKey{}).(*redirectSettings)
+	if !ok || len(settings.omitCookies) == 0 || req.Header.Get("Cookie") == "" {
+		return f.base.RoundTrip(req)
+	}
+
+	cookies := req.Cookies()
+	// RoundTrippers must not modify the caller's request.
+	filtered := req.Clone(req.Context())
+	filtered.Header.Del("Cookie")
+	for _, c := range cookies {
+		if !slices.Contains(settings.omitCookies, c.Name) {
+			filtered.AddCookie(c)
+		}
+	}
+	return f.base.RoundTrip(filtered)
+}
+
 // validateRedirectTarget checks if the redirect target URL is allowed under SSRF protection rules.
 // This prevents attackers from using HTTP redirects to bypass initial SSRF validation.
 //
@@ -302,6 +427,7 @@ func (t *transport) SetRedirectPolicy(ctx context.Context, followRedirects bool,
 	settings := getRedirectSettings()
 	settings.followRedirects = followRedirects
 	settings.maxRedirects = maxRedirects
+	settings.hopStart = time.Now()
 	newCtx := context.WithValue(ctx, redirectContextKey{}, settings)
 	return newCtx, settings
 }
@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+)
+
+// wireCounter tallies the bytes of the final request/response exchange as
+// framed by HTTP/1.1: request line, header fields and body on the way out;
+// status line, header fields and body on the way in. Chunked-encoding framing
+// and HTTP/2 header compression are not accounted for, and bodies of
+// intermediate redirect responses are not counted.
+//
+// It also records the connection the final request was sent on.
+//
+// Trace hooks and body reads run on transport goroutines, so the counters
+// are atomic.
+type wireCounter struct {
+	headersSent  atomic.Int64
+	bodySent     atomic.Int64
+	bodyReceived atomic.Int64
+	connID       atomic.Pointer[string]
+}
+
+// attach installs the counter on httpReq: a client trace counting the header
+// fields written for each hop and, for bodies of unknown length, a reader
+// counting the bytes the transport consumes.
+func (w *wireCounter) attach(httpReq *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		// GetConn starts every hop, including redirects; only the last
+		// request sent is reported.
+		GetConn: func(string) { w.headersSent.Store(0) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Conn == nil {
+				return
+			}
+			id := info.Conn.LocalAddr().String() + "->" + info.Conn.RemoteAddr().String()
+			w.connID.Store(&id)
+		},
+		WroteHeaderField: func(key string, values []string) {
+			var n int
+			for _, v := range values {
+				n += len(key) + len(": ") + len(v) + len("\r\n")
+			}
+			w.headersSent.Add(int64(n))
+		},
+	}
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), trace))
+	if httpReq.ContentLength < 0 && httpReq.Body != nil && httpReq.Body != http.NoBody {
+		httpReq.Body = &countingReadCloser{ReadCloser: httpReq.Body, n: &w.bodySent}
+	}
+	return httpReq
+}
+
+// countResponseBody wraps httpResp.Body so bytes read from it are counted.
+func (w *wireCounter) countResponseBody(httpResp *http.Response) {
+	if httpResp.Body != nil && httpResp.Body != http.NoBody {
+		httpResp.Body = &countingReadCloser{ReadCloser: httpResp.Body, n: &w.bodyReceived}
+	}
+}
+
+// sent returns the bytes written for the request that produced httpResp.
+func (w *wireCounter) sent(httpResp *http.Response) int64 {
+	n := w.headersSent.Load()
+	req := httpResp.Request
+	if req == nil {
+		return n
+	}
+	if req.URL != nil {
+		// "METHOD /path HTTP/1.1\r\n" followed by the blank line ending the headers.
+		n += int64(len(req.Method) + 1 + len(req.URL.RequestURI()) + 1 + len("HTTP/1.1") + 2 + 2)
+	}
+	if req.ContentLength > 0 {
+		n += req.ContentLength
+	} else {
+		n += w.bodySent.Load()
+	}
+	return n
+}
+
+// received returns the bytes of httpResp's status line and headers plus the
+// body bytes read so far.
+func (w *wireCounter) received(httpResp *http.Response) int64 {
+	// "HTTP/1.1 200 OK\r\n" followed by the blank line ending the headers.
+	n := int64(len(httpResp.Proto) + 1 + len(httpResp.Status) + 2 + 2)
+	for k, vs := range httpResp.Header {
+		for _, v := range vs {
+			n += int64(len(k) + len(": ") + len(v) + len("\r\n"))
+		}
+	}
+	return n + w.bodyReceived.Load()
+}
+
+// connectionID returns the "local->remote" address pair of the connection
+// the final request was sent on, or "" if none was reported.
+func (w *wireCounter) connectionID() string {
+	if id := w.connID.Load(); id != nil {
+		return *id
+	}
+	return ""
+}
+
+// teeReadCloser reads through a TeeReader while closing the original body.
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// countingReadCloser counts the bytes read through it into n.
+type countingReadCloser struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// progressReadCloser reports the running byte count and the expected total
+// (-1 if unknown) to fn after each read that returns data.
+type progressReadCloser struct {
+	io.ReadCloser
+	read  int64
+	total int64
+	fn    func(read, total int64)
+}
+
+func (p *progressReadCloser) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		p.fn(p.read, p.total)
+	}
+	return n, err
+}
@@ -41,6 +41,7 @@ type Config struct {
 	MaxRequestBodySize  int64
 	AllowPrivateIPs     bool
 	ExemptNets          []*net.IPNet
+	AllowHopByHop       bool
 }
 
 // Request represents a security validation request with method, URL, headers, and body.
@@ -50,6 +51,9 @@ type Request struct {
 	Headers     map[string]string
 	QueryParams map[string]any
 	Body        any
+	// AllowPrivateIPs permits a private or reserved target for this request
+	// even when Config.AllowPrivateIPs is false.
+	AllowPrivateIPs bool
 }
 
 // NewValidator creates a new Validator with default security settings.
@@ -86,7 +90,12 @@ func NewValidatorWithConfig(config *Config) *Validator {
 // ValidateRequest validates an HTTP request against the configured security rules.
 func (v *Validator) ValidateRequest(req *Request) error {
 	if v.config.ValidateURL {
-		if err := v.validateURL(req.URL); err != nil {
+		if req.AllowPrivateIPs && !v.config.AllowPrivateIPs {
+			// Not cached: the URL must still be checked for other requests.
+			if _, err := validation.ValidateAndParseURL(req.URL); err != nil {
+				return err
+			}
+		} else if err := v.validateURL(req.URL); err != nil {
 			return err
 		}
 	}
@@ -99,6 +108,14 @@ func (v *Validator) ValidateRequest(req *Request) error {
 		}
 	}
 
+	if !v.config.AllowHopByHop {
+		for key, value := range req.Headers {
+			if isHopByHopHeader(key, value) {
+				return fmt.Errorf("invalid header %s: hop-by-hop headers are managed by the transport", key)
+			}
+		}
+	}
+
 	if req.Body != nil {
 		if err := v.validateRequestBodySize(req.Body); err != nil {
 			return err
@@ -217,6 +234,28 @@ func validateCommonHeaderValue(key, value string) error {
 	return nil
 }
 
+// hopByHopHeaders lists headers that describe a single connection rather than
+// the message (RFC 9110 Section 7.6.1). The transport manages them; set by a
+// caller they can change message framing or hijack the connection.
+var hopByHopHeaders = []string{"Keep-Alive", "Proxy-Connection", "TE", "Trailer", "Transfer-Encoding", "Upgrade"}
+
+// isHopByHopHeader reports whether key is a hop-by-hop header a caller must
+// not set. Connection is allowed when it only asks for keep-alive or close,
+// which the transport honors.
+func isHopByHopHeader(key, value string) bool {
+	if validation.EqualFold(key, "Connection") {
+		return validateHeaderValueTokens(value, connectionPersistence, "Connection") != nil
+	}
+	for _, h := range hopByHopHeaders {
+		if validation.EqualFold(key, h) {
+			return true
+		}
+	}
+	return false
+}
+
+var connectionPersistence = []string{"keep-alive", "close"}
+
 // validateRequestBodySize checks the request body against the configured size limit.
 // Only validates when MaxRequestBodySize is explicitly set; does not fall back to
 // MaxResponseBodySize since they serve different purposes.
@@ -244,9 +283,23 @@ func (v *Validator) validateRequestBodySize(body any) error {
 			// Account for: field key + value + Content-Disposition overhead (~60 bytes per field).
 			size += int64(len(k)) + int64(len(v)) + 60
 		}
+		for k, p := range b.Parts {
+			if p == nil {
+				continue
+			}
+			// Account for: field key + content + Content-Disposition and
+			// Content-Type headers (~90 bytes per part).
+			size += int64(len(k)) + int64(len(p.Content)) + 90
+		}
 		for _, f := range b.Files {
 			// Account for: filename + content + MIME headers (~120 bytes per file part).
-			size += int64(len(f.Filename)) + int64(len(f.Content)) + 120
+			// Streamed files count their declared Size; unknown sizes are the
+			// caller's responsibility, as for io.Reader bodies.
+			content := int64(len(f.Content))
+			if f.Reader != nil {
+				content = max(f.Size, 0)
+			}
+			size += int64(len(f.Filename)) + content + 120
 		}
 	default:
 		// For io.Reader and other types, caller is responsible for size control.
@@ -374,6 +374,23 @@ func TestValidator_ValidateRequestSize_BodyTypes(t *testing.T) {
 		}
 	})
 
+	t.Run("FormData parts too large", func(t *testing.T) {
+		validator := NewValidator()
+		validator.config.MaxRequestBodySize = 200
+
+		form := &types.FormData{
+			Parts: map[string]*types.PartData{
+				"meta": {ContentType: "application/json", Content: make([]byte, 500)},
+				"nil":  nil,
+			},
+		}
+		req := &Request{Method: "POST", URL: "http://example.com", Body: form}
+
+		if err := validator.ValidateRequest(req); err == nil {
+			t.Error("expected error for oversized FormData parts")
+		}
+	})
+
 	t.Run("FormData files too large", func(t *testing.T) {
 		validator := NewValidator()
 		validator.config.MaxRequestBodySize = 10
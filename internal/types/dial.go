@@ -0,0 +1,15 @@
+package types
+
+// DialPreference controls the order in which a host's resolved IPv4 and
+// IPv6 addresses are dialed.
+type DialPreference int
+
+const (
+	// DialDualStack dials addresses in resolver order, racing both families
+	// (Happy Eyeballs) when the system dialer resolves the host itself.
+	DialDualStack DialPreference = iota
+	// DialPreferIPv4 dials IPv4 addresses first, falling back to IPv6.
+	DialPreferIPv4
+	// DialPreferIPv6 dials IPv6 addresses first, falling back to IPv4.
+	DialPreferIPv6
+)
@@ -3,6 +3,8 @@
 // enabling compile-time type checking without runtime type assertions.
 package types
 
+import "io"
+
 // FormData represents multipart form data for HTTP requests.
 // It contains both text fields and file uploads.
 //
@@ -25,17 +27,41 @@ package types
 type FormData struct {
 	// Fields contains the text form fields.
 	Fields map[string]string
+	// Parts contains non-file fields mapped by field name, for values that
+	// need their own Content-Type, such as a JSON document alongside a file.
+	Parts map[string]*PartData
 	// Files contains the file uploads mapped by field name.
 	Files map[string]*FileData
 }
 
+// PartData represents a non-file multipart field with an explicit
+// Content-Type. Unlike a FileData part, it carries no filename.
+type PartData struct {
+	// ContentType is the MIME type of the part (e.g., "application/json").
+	// When empty, it defaults to "text/plain; charset=utf-8".
+	ContentType string
+	// Content is the raw part content.
+	Content []byte
+}
+
 // FileData represents a file to be uploaded in a multipart form.
 // It contains the filename, file content, and content type.
 type FileData struct {
 	// Filename is the name of the file as sent to the server.
 	Filename string
-	// Content is the raw file content.
+	// Content is the raw file content. Ignored when Reader is set.
 	Content []byte
 	// ContentType is the MIME type of the file (e.g., "image/png", "application/pdf").
+	// When empty, it is detected from the first 512 bytes of the content
+	// with http.DetectContentType, falling back to application/octet-stream.
 	ContentType string
+	// Reader streams the file content instead of Content, so large files are
+	// never held in memory. It is read once, during the request; forms with a
+	// Reader are therefore not retried. The caller remains responsible for
+	// closing it.
+	Reader io.Reader
+	// Size is the number of bytes Reader yields. When every streamed file has
+	// a Size, the request carries a Content-Length; otherwise it is sent with
+	// chunked encoding. A Reader yielding fewer bytes fails the request.
+	Size int64
 }
@@ -157,3 +157,64 @@ type RetryPolicy interface {
 	// MaxRetries returns the maximum number of retry attempts.
 	MaxRetries() int
 }
+
+// RetryIfFunc decides whether to retry after an attempt (0-indexed). Exactly
+// one of resp and err is non-nil. It replaces the retry policy's ShouldRetry,
+// so it can act on application-level signals in the body:
+//
+//	func(resp ResponseReader, err error, attempt int) bool {
+//	    if err != nil {
+//	        return true
+//	    }
+//	    return strings.Contains(resp.Body(), `"status":"pending"`)
+//	}
+type RetryIfFunc func(resp ResponseReader, err error, attempt int) bool
+
+// CheckRedirectFunc inspects a redirect before it is followed, with the same
+// contract as http.Client.CheckRedirect: req is the upcoming request and via
+// the requests made so far, oldest first. Returning an error aborts the
+// request with that error; returning http.ErrUseLastResponse stops following
+// and hands the 3xx response back to the caller. It may modify req.Header.
+type CheckRedirectFunc func(req *http.Request, via []*http.Request) error
+
+// Tracer receives lifecycle callbacks for each request, for feeding
+// tracing or metrics systems (e.g. OpenTelemetry spans).
+// Callbacks run synchronously on the request goroutine and must be
+// safe for concurrent use.
+//
+// Example implementation:
+//
+//	type spanTracer struct{ span trace.Span }
+//
+//	func (t *spanTracer) OnStart(method, url string) { ... }
+//	func (t *spanTracer) OnRetry(attempt int)        { t.span.AddEvent("retry") }
+//	func (t *spanTracer) OnFinish(statusCode int, err error, d time.Duration) { ... }
+type Tracer interface {
+	// OnStart is called once before the first attempt. url is sanitized
+	// (credentials redacted).
+	OnStart(method, url string)
+
+	// OnRetry is called before each retry attempt, after the backoff delay.
+	// attempt is the retry number, starting at 1 for the first retry.
+	OnRetry(attempt int)
+
+	// OnFinish is called once when the request completes. statusCode is 0
+	// when err is non-nil. duration covers all attempts and retry delays.
+	OnFinish(statusCode int, err error, duration time.Duration)
+}
+
+// Clock is the time source for retry backoff, Retry-After dates and request
+// durations. The default uses the time package; tests can supply a fake whose
+// After fires immediately to check backoff delays without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// TokenSource supplies bearer tokens, e.g. OAuth2 access tokens. Token is
+// called before each request and once more when the server answers 401, so
+// implementations should cache a valid token and refresh it when it has
+// expired or was rejected. It must be safe for concurrent use.
+type TokenSource interface {
+	Token() (string, error)
+}
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// ConfigOverride holds per-request defaults carried in a context. Zero
+// fields leave the client setting in place.
+type ConfigOverride struct {
+	// Timeout replaces Timeouts.Request when positive.
+	Timeout time.Duration
+	// MaxRetries replaces Retry.MaxRetries when non-nil.
+	MaxRetries *int
+}
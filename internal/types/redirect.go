@@ -0,0 +1,13 @@
+package types
+
+import "time"
+
+// RedirectHop records one followed redirect: the URL that answered with a
+// redirect, the URL it pointed to, the redirect status code, and the time
+// from sending the request for FromURL to receiving its redirect response.
+type RedirectHop struct {
+	FromURL    string
+	ToURL      string
+	StatusCode int
+	Duration   time.Duration
+}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+// RetryAttempt records a failed attempt that was retried: the response
+// status (0 when the attempt failed without a response), the error message
+// (empty when a response was received), and the delay slept before the next
+// attempt.
+type RetryAttempt struct {
+	StatusCode int
+	Err        string
+	Delay      time.Duration
+}
+
+// JitterStrategy selects how retry backoff delays are randomized.
+type JitterStrategy int
+
+const (
+	// JitterAuto uses JitterProportional when jitter is enabled and
+	// JitterNone otherwise.
+	JitterAuto JitterStrategy = iota
+	// JitterNone uses the backoff delay as is.
+	JitterNone
+	// JitterProportional varies the delay by up to ±10%.
+	JitterProportional
+	// JitterFull picks a delay uniformly between 0 and the backoff delay.
+	JitterFull
+	// JitterEqual keeps half the backoff delay and picks the other half
+	// uniformly.
+	JitterEqual
+	// JitterDecorrelated picks a delay uniformly between the initial delay
+	// and three times the previous attempt's backoff delay, capped at the
+	// maximum delay.
+	JitterDecorrelated
+)
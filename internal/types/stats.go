@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// Stats is a point-in-time view of a client's request counters. A request
+// counts as successful when it returns a response, whatever its status code,
+// and as failed when it returns an error. Counters are read individually, so
+// a snapshot taken under load may not satisfy Total == Successful + Failed
+// exactly.
+type Stats struct {
+	TotalRequests      int64
+	SuccessfulRequests int64
+	FailedRequests     int64
+	// InFlight is the number of requests currently being executed,
+	// including retries and backoff waits.
+	InFlight int64
+	// AverageLatency is an exponentially weighted moving average of request
+	// durations, favouring recent requests.
+	AverageLatency time.Duration
+}
@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// Timing breaks down the final request sent, after any redirects, into the
+// phases traced by net/http/httptrace. DNS, Connect and TLS are zero when
+// the phase did not happen, e.g. on a reused connection or for an IP
+// literal. TTFB is the time from starting to obtain a connection to the
+// first response byte; Total runs from the same point until the response
+// body was read, or until its headers arrived for streamed responses.
+type Timing struct {
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	TTFB    time.Duration
+	Total   time.Duration
+}
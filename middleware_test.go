@@ -1033,3 +1033,13 @@ func TestMiddleware_BoundaryConditions(t *testing.T) {
 		}
 	})
 }
+
+// newMiddlewareTestClient returns a test client with a pass-through
+// middleware, so requests take the middleware chain's forwarding path.
+func newMiddlewareTestClient() (Client, error) {
+	config := testConfig()
+	config.Middleware.Middlewares = []MiddlewareFunc{
+		func(next Handler) Handler { return next },
+	}
+	return New(config)
+}
@@ -1,10 +1,14 @@
 package httpc
 
 import (
+	"bytes"
 	"context"
+	cryptorand "crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/url"
 	"path/filepath"
@@ -29,6 +33,24 @@ func WithHeader(key, value string) RequestOption {
 	}
 }
 
+// WithRawHeaderName sets a header whose name is sent exactly as given,
+// bypassing Go's canonicalization (e.g. "x-api-KEY" instead of "X-Api-Key"),
+// for servers that match header names case-sensitively. It replaces any
+// header of the same name set by other options or client defaults.
+// Casing is only preserved over HTTP/1.1; HTTP/2 always lowercases names.
+// Host and User-Agent are written by the transport itself and cannot be
+// renamed this way.
+// Returns ErrInvalidHeader if the name or value contains invalid characters.
+func WithRawHeaderName(name, value string) RequestOption {
+	return func(r *engine.Request) error {
+		if err := validation.ValidateHeaderKeyValue(name, value); err != nil {
+			return fmt.Errorf("invalid header: %w", err)
+		}
+		r.SetRawHeader(name, value)
+		return nil
+	}
+}
+
 // WithHeaderMap sets multiple headers from a map.
 // Returns ErrInvalidHeader if any key or value contains invalid characters
 // (CRLF injection prevention).
@@ -50,44 +72,91 @@ func WithUserAgent(userAgent string) RequestOption {
 	return WithHeader("User-Agent", userAgent)
 }
 
+// WithAcceptEncoding sets the Accept-Encoding header explicitly, replacing the
+// default "gzip, deflate". Use "identity" to opt out of compression.
+//
+// Note: the response processor only decompresses gzip and deflate. Other
+// codecs (e.g., "br") may be requested, but such responses will fail to decode
+// unless the server falls back to a supported encoding.
+//
+// Returns an error if no encodings are given, or if any encoding is empty or
+// contains invalid characters.
+func WithAcceptEncoding(encodings ...string) RequestOption {
+	return func(r *engine.Request) error {
+		if len(encodings) == 0 {
+			return fmt.Errorf("at least one encoding is required")
+		}
+		for _, enc := range encodings {
+			if strings.TrimSpace(enc) == "" {
+				return fmt.Errorf("encoding cannot be empty")
+			}
+		}
+		value := strings.Join(encodings, ", ")
+		if err := validation.ValidateHeaderKeyValue("Accept-Encoding", value); err != nil {
+			return fmt.Errorf("invalid header: %w", err)
+		}
+		r.SetHeader("Accept-Encoding", value)
+		return nil
+	}
+}
+
 // WithBasicAuth sets HTTP Basic Authentication using the provided username and password.
 // Returns an error if username is empty, or if username or password exceeds the maximum
 // length or contains invalid characters.
 func WithBasicAuth(username, password string) RequestOption {
 	return func(r *engine.Request) error {
-		if username == "" {
-			return fmt.Errorf("username cannot be empty")
-		}
-		if err := validation.ValidateCredential(username, validation.MaxCredLen, true, "username"); err != nil {
-			return fmt.Errorf("invalid username: %w", err)
-		}
-		if err := validation.ValidateCredential(password, validation.MaxCredLen, false, "password"); err != nil {
-			return fmt.Errorf("invalid password: %w", err)
+		value, err := basicAuthValue(username, password)
+		if err != nil {
+			return err
 		}
-
-		// Efficient string concatenation and encoding
-		creds := username + ":" + password
-		r.SetHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(creds)))
+		r.SetHeader("Authorization", value)
 		return nil
 	}
 }
 
+// basicAuthValue validates the credentials and returns the Authorization
+// header value for Basic authentication.
+func basicAuthValue(username, password string) (string, error) {
+	if username == "" {
+		return "", fmt.Errorf("username cannot be empty")
+	}
+	if err := validation.ValidateCredential(username, validation.MaxCredLen, true, "username"); err != nil {
+		return "", fmt.Errorf("invalid username: %w", err)
+	}
+	if err := validation.ValidateCredential(password, validation.MaxCredLen, false, "password"); err != nil {
+		return "", fmt.Errorf("invalid password: %w", err)
+	}
+
+	// Efficient string concatenation and encoding
+	creds := username + ":" + password
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(creds)), nil
+}
+
 // WithBearerToken sets the Authorization header to "Bearer <token>".
 // Returns an error if token is empty or fails token format validation.
 func WithBearerToken(token string) RequestOption {
 	return func(r *engine.Request) error {
-		if token == "" {
-			return fmt.Errorf("token cannot be empty")
-		}
-		if err := validation.ValidateToken(token); err != nil {
+		value, err := bearerAuthValue(token)
+		if err != nil {
 			return err
 		}
-
-		r.SetHeader("Authorization", "Bearer "+token)
+		r.SetHeader("Authorization", value)
 		return nil
 	}
 }
 
+// bearerAuthValue validates token and returns the Authorization header
+// value for Bearer authentication.
+func bearerAuthValue(token string) (string, error) {
+	if token == "" {
+		return "", fmt.Errorf("token cannot be empty")
+	}
+	if err := validation.ValidateToken(token); err != nil {
+		return "", err
+	}
+	return "Bearer " + token, nil
+}
+
 // WithQuery sets a single query parameter on the request.
 // Returns an error if the key is empty, too long, or contains invalid characters,
 // or if the formatted value exceeds the maximum allowed length.
@@ -109,6 +178,40 @@ func WithQuery(key string, value any) RequestOption {
 	}
 }
 
+// WithQuerySlice adds values for a repeated query parameter, producing
+// "?tag=a&tag=b" for WithQuerySlice("tag", []string{"a", "b"}). Values are
+// appended to any already set for key, so the option may be used repeatedly.
+// An empty values slice leaves the query unchanged.
+// Returns an error if the key is invalid or any value exceeds the maximum
+// allowed length.
+func WithQuerySlice(key string, values []string) RequestOption {
+	return func(r *engine.Request) error {
+		if err := validation.ValidateQueryKey(key); err != nil {
+			return err
+		}
+		for _, v := range values {
+			if len(v) > validation.MaxValueLen {
+				return fmt.Errorf("query value too long (max %d)", validation.MaxValueLen)
+			}
+		}
+		if len(values) == 0 {
+			return nil
+		}
+
+		params := r.EnsureQueryParams()
+		var merged []string
+		switch existing := params[key].(type) {
+		case nil:
+		case []string:
+			merged = append(merged, existing...)
+		default:
+			merged = append(merged, engine.FormatQueryParam(existing))
+		}
+		params[key] = append(merged, values...)
+		return nil
+	}
+}
+
 // WithQueryMap sets multiple query parameters from a map.
 // Returns an error if any key is empty, too long, or contains invalid characters,
 // or if any formatted value exceeds the maximum allowed length.
@@ -452,7 +555,38 @@ func WithFormData(data *FormData) RequestOption {
 	}
 }
 
+// WithFormDataStreaming sets the request body as multipart/form-data encoded
+// on the fly into an io.Pipe while the request is sent, instead of being
+// assembled in memory first. Files with Content are streamed from it; files
+// with a Reader are copied from it. The request carries a Content-Length only
+// when every streamed file's size is known, as with FileData.Size.
+//
+// A streamed body cannot be replayed, so the request is never retried: the
+// error of the first failed attempt is returned. data is not modified.
+// Returns an error if data is nil.
+func WithFormDataStreaming(data *FormData) RequestOption {
+	return func(r *engine.Request) error {
+		if data == nil {
+			return fmt.Errorf("form data cannot be nil")
+		}
+		streamed := &FormData{Fields: data.Fields, Parts: data.Parts, Files: make(map[string]*FileData, len(data.Files))}
+		for key, f := range data.Files {
+			if f != nil && f.Reader == nil {
+				copied := *f
+				copied.Reader = bytes.NewReader(f.Content)
+				copied.Size = int64(len(f.Content))
+				copied.Content = nil
+				f = &copied
+			}
+			streamed.Files[key] = f
+		}
+		r.SetBody(streamed)
+		return nil
+	}
+}
+
 // WithFile adds a file upload to the request as multipart/form-data.
+// The part's Content-Type is detected from content.
 // Returns an error if fieldName or filename is empty, contains invalid characters,
 // or resolves to an invalid path (e.g., ".." or ".").
 func WithFile(fieldName, filename string, content []byte) RequestOption {
@@ -488,6 +622,61 @@ func WithFile(fieldName, filename string, content []byte) RequestOption {
 	}
 }
 
+// WithFileReader adds a file upload streamed from r to the request as
+// multipart/form-data. Unlike WithFile, the content is copied from r while the
+// request is sent and never held in memory, which suits large files:
+//
+//	f, _ := os.Open("backup.tar")
+//	defer f.Close()
+//	result, err := client.Post(url, httpc.WithFileReader("file", "backup.tar", f, "application/x-tar"))
+//
+// The request is sent with chunked encoding; to send a Content-Length, use
+// WithFormData with FileData.Reader and FileData.Size. r is read once, so the
+// request is not retried. An empty contentType is detected from the first
+// 512 bytes of r, falling back to application/octet-stream.
+// Returns an error if r is nil or fieldName or filename is invalid.
+func WithFileReader(fieldName, filename string, r io.Reader, contentType string) RequestOption {
+	return func(req *engine.Request) error {
+		if r == nil {
+			return fmt.Errorf("file reader cannot be nil")
+		}
+		if fieldName == "" {
+			return fmt.Errorf("field name cannot be empty")
+		}
+		if filename == "" {
+			return fmt.Errorf("filename cannot be empty")
+		}
+		if err := validation.ValidateFieldName(fieldName, "field name"); err != nil {
+			return fmt.Errorf("invalid field name: %w", err)
+		}
+		if err := validation.ValidateFieldName(filename, "filename"); err != nil {
+			return fmt.Errorf("invalid filename: %w", err)
+		}
+		if contentType != "" {
+			if err := validation.ValidateHeaderKeyValue("Content-Type", contentType); err != nil {
+				return fmt.Errorf("invalid content type: %w", err)
+			}
+		}
+
+		cleanFilename := filepath.Base(filename)
+		if cleanFilename == "." || cleanFilename == ".." || cleanFilename == "" {
+			return fmt.Errorf("invalid filename")
+		}
+
+		req.SetBody(&FormData{
+			Fields: make(map[string]string, 1),
+			Files: map[string]*FileData{
+				fieldName: {
+					Filename:    cleanFilename,
+					ContentType: contentType,
+					Reader:      r,
+				},
+			},
+		})
+		return nil
+	}
+}
+
 // WithTimeout sets a per-request timeout that overrides the client's default timeout.
 // Returns ErrInvalidTimeout if timeout is negative or exceeds 30 minutes.
 func WithTimeout(timeout time.Duration) RequestOption {
@@ -503,6 +692,20 @@ func WithTimeout(timeout time.Duration) RequestOption {
 	}
 }
 
+// WithDeadline ends the request at t, across all attempts: retries and their
+// backoff delays stop when t passes, and the request fails with a timeout
+// error. Combined with WithTimeout or a context deadline, the earliest wins.
+// Returns an error if t is the zero time.
+func WithDeadline(t time.Time) RequestOption {
+	return func(r *engine.Request) error {
+		if t.IsZero() {
+			return fmt.Errorf("%w: deadline cannot be zero", ErrInvalidTimeout)
+		}
+		r.SetDeadline(t)
+		return nil
+	}
+}
+
 // WithContext sets the context for the request, enabling timeout and cancellation control.
 // The context overrides the client's default timeout for this request.
 // Returns an error if ctx is nil.
@@ -516,6 +719,30 @@ func WithContext(ctx context.Context) RequestOption {
 	}
 }
 
+// WithConfigOverride returns a copy of ctx carrying per-request defaults
+// for the timeout and max retries, so middleware or wrappers can adjust
+// requests without touching call sites:
+//
+//	retries := 0
+//	ctx = httpc.WithConfigOverride(ctx, httpc.ConfigOverride{
+//	    Timeout:    2 * time.Second,
+//	    MaxRetries: &retries,
+//	})
+//	result, err := client.Request(ctx, "GET", url)
+//
+// The request's context is consulted, so a middleware can also install an
+// override with req.SetContext. Precedence is WithTimeout and WithMaxRetries
+// first, then the context override, then the client Config. Out-of-range
+// values are clamped to the limits WithTimeout and WithMaxRetries enforce.
+func WithConfigOverride(ctx context.Context, o ConfigOverride) context.Context {
+	o.Timeout = min(max(o.Timeout, 0), maxTimeout)
+	if o.MaxRetries != nil {
+		n := min(max(*o.MaxRetries, 0), maxRetryAttempts)
+		o.MaxRetries = &n
+	}
+	return engine.WithConfigOverride(ctx, o)
+}
+
 // WithMaxRetries sets the maximum number of retry attempts for this request.
 // Returns ErrInvalidRetry if maxRetries is negative or exceeds 10.
 func WithMaxRetries(maxRetries int) RequestOption {
@@ -528,6 +755,200 @@ func WithMaxRetries(maxRetries int) RequestOption {
 	}
 }
 
+// WithRetryPolicy overrides which response statuses and request methods are
+// retried for this request. A nil slice keeps the client's
+// Retry.RetryableStatusCodes or Retry.RetryableMethods; statusCodes has no
+// effect when a Retry.CustomPolicy is configured.
+//
+// Example:
+//
+//	// Retry this POST on 503 only; the server deduplicates by idempotency key.
+//	result, err := client.Post(url,
+//	    httpc.WithJSON(order),
+//	    httpc.WithRetryPolicy([]int{503}, []string{"POST"}))
+//
+// Returns ErrInvalidRetry if a status code is outside 100-599 or a method is
+// empty or contains invalid characters.
+func WithRetryPolicy(statusCodes []int, methods []string) RequestOption {
+	return func(r *engine.Request) error {
+		if err := validateRetryPolicy("", statusCodes, methods); err != nil {
+			return err
+		}
+		if statusCodes != nil {
+			r.SetRetryableStatusCodes(statusCodes)
+		}
+		if methods != nil {
+			r.SetRetryableMethods(methods)
+		}
+		return nil
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header, which payment and
+// transaction APIs use to deduplicate a repeated request. An empty key is
+// replaced by a random UUID. The key is fixed when the option is applied,
+// so every retry of the request sends the same key. Combine with
+// WithRetryPolicy to retry a POST:
+//
+//	result, err := client.Post(url,
+//	    httpc.WithJSON(payment),
+//	    httpc.WithIdempotencyKey(""),
+//	    httpc.WithRetryPolicy(nil, []string{"POST"}))
+//
+// Returns an error if key contains invalid header characters.
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *engine.Request) error {
+		if key == "" {
+			r.SetHeader("Idempotency-Key", newUUID())
+			return nil
+		}
+		if err := validation.ValidateHeaderKeyValue("Idempotency-Key", key); err != nil {
+			return fmt.Errorf("invalid idempotency key: %w", err)
+		}
+		r.SetHeader("Idempotency-Key", key)
+		return nil
+	}
+}
+
+// newUUID returns a random (version 4) UUID from crypto/rand.
+func newUUID() string {
+	var b [16]byte
+	_, _ = cryptorand.Read(b[:]) // never fails on supported platforms
+	b[6] = b[6]&0x0f | 0x40      // version 4
+	b[8] = b[8]&0x3f | 0x80      // RFC 9562 variant
+	h := hex.EncodeToString(b[:])
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// WithRetryIf sets the retry decision for this request, replacing
+// Retry.RetryIf and the retry policy's ShouldRetry. Retries remain bounded by
+// the request's max retries.
+//
+// Example:
+//
+//	// Poll until the job leaves the pending state
+//	result, err := client.Get(jobURL,
+//	    httpc.WithMaxRetries(5),
+//	    httpc.WithRetryIf(func(resp httpc.ResponseReader, err error, attempt int) bool {
+//	        return err == nil && strings.Contains(resp.Body(), `"status":"pending"`)
+//	    }))
+//
+// Returns an error if fn is nil.
+func WithRetryIf(fn RetryIfFunc) RequestOption {
+	return func(r *engine.Request) error {
+		if fn == nil {
+			return fmt.Errorf("retry callback cannot be nil")
+		}
+		r.SetRetryIf(fn)
+		return nil
+	}
+}
+
+// WithRequestTee copies the request body to w as it is sent, after
+// serialization (JSON, XML, form, and multipart encoding), so w receives
+// exactly the bytes transmitted. Streamed bodies are copied as they stream.
+// Each attempt is copied, so a retried request writes its body to w once per
+// attempt. Writes happen on the transport's goroutine; w must not block, and
+// an error from w fails the request.
+// Returns an error if w is nil.
+func WithRequestTee(w io.Writer) RequestOption {
+	return func(r *engine.Request) error {
+		if w == nil {
+			return fmt.Errorf("tee writer cannot be nil")
+		}
+		r.SetBodyTee(w)
+		return nil
+	}
+}
+
+// WithHost sets the Host header sent with the request, while the connection
+// still goes to the URL's host. Setting "Host" with WithHeader has no effect,
+// as net/http takes the header from the request's Host field. Useful for
+// virtual-host testing and CDN origin pulls:
+//
+//	result, err := client.Get("http://10.0.0.5/health", httpc.WithHost("www.example.com"))
+//
+// For https URLs the TLS server name remains the URL's host.
+// Returns an error if host is empty or not a valid host or host:port.
+func WithHost(host string) RequestOption {
+	return func(r *engine.Request) error {
+		if host == "" {
+			return fmt.Errorf("host cannot be empty")
+		}
+		if u, err := url.Parse("//" + host); err != nil || u.Host != host || u.User != nil {
+			return fmt.Errorf("invalid host %q", host)
+		}
+		r.SetHost(host)
+		return nil
+	}
+}
+
+// WithRawRequest calls fn with the fully built *http.Request just before it
+// is sent, as an escape hatch for settings no option exposes, such as
+// trailers or the Host header:
+//
+//	result, err := client.Get(url, httpc.WithRawRequest(func(req *http.Request) error {
+//	    req.Host = "internal.example.com"
+//	    return nil
+//	}))
+//
+// fn runs once per attempt, after headers, cookies and body are set. It may
+// change the request in place but must not replace its context, which
+// carries the timeout and cancellation, or clear its URL; doing so fails the
+// request, as does an error from fn. Multiple callbacks run in the order
+// added.
+// Returns an error if fn is nil.
+func WithRawRequest(fn func(*http.Request) error) RequestOption {
+	return func(r *engine.Request) error {
+		if fn == nil {
+			return fmt.Errorf("raw request callback cannot be nil")
+		}
+		existing := r.RawRequest()
+		if existing == nil {
+			r.SetRawRequest(fn)
+			return nil
+		}
+		r.SetRawRequest(func(req *http.Request) error {
+			if err := existing(req); err != nil {
+				return err
+			}
+			return fn(req)
+		})
+		return nil
+	}
+}
+
+// WithReadProgress calls fn as the response body is read, with the bytes
+// read so far and the expected total from Content-Length (-1 if unknown).
+// Counts are of the body as received, before decompression. Useful for
+// progress indicators on large buffered responses; for streamed bodies
+// (WithStreamBody) fn fires as the caller reads. fn runs on the reading
+// goroutine and must not block.
+// Returns an error if fn is nil.
+func WithReadProgress(fn func(read, total int64)) RequestOption {
+	return func(r *engine.Request) error {
+		if fn == nil {
+			return fmt.Errorf("progress callback cannot be nil")
+		}
+		r.SetReadProgress(fn)
+		return nil
+	}
+}
+
+// WithCancelGroup adds the request to the named cancellation group while it
+// is in flight, so Client.CancelGroup(name) can abort it together with the
+// other requests in the group.
+// Returns an error if name is empty.
+func WithCancelGroup(name string) RequestOption {
+	return func(r *engine.Request) error {
+		if name == "" {
+			return fmt.Errorf("cancel group name cannot be empty")
+		}
+		r.SetCancelGroup(name)
+		return nil
+	}
+}
+
 // WithFollowRedirects controls whether HTTP redirects are followed for this request.
 func WithFollowRedirects(follow bool) RequestOption {
 	return func(r *engine.Request) error {
@@ -536,9 +957,52 @@ func WithFollowRedirects(follow bool) RequestOption {
 	}
 }
 
+// WithSameHostRedirectsOnly follows redirects only while they stay on the host
+// of the original request. A redirect pointing to a different host is not
+// followed; the 3xx response is returned to the caller instead (no error).
+// Other redirect settings, such as WithMaxRedirects, still apply.
+func WithSameHostRedirectsOnly() RequestOption {
+	return func(r *engine.Request) error {
+		r.SetSameHostRedirectsOnly(true)
+		return nil
+	}
+}
+
+// WithAllowPrivateIP lifts SSRF protection for this request only, so a
+// client with Security.AllowPrivateIPs disabled can still reach an internal
+// address, e.g. for a health check. Redirects followed by the request are
+// exempt too. The connection is closed after the request rather than
+// returned to the pool, so later requests are checked as usual.
+func WithAllowPrivateIP() RequestOption {
+	return func(r *engine.Request) error {
+		r.SetAllowPrivateIP(true)
+		return nil
+	}
+}
+
+// WithCheckRedirect sets a callback consulted before each redirect of this
+// request is followed, replacing MiddlewareConfig.CheckRedirect. It runs after
+// the built-in redirect checks; return http.ErrUseLastResponse to stop and
+// receive the 3xx response, or another error to fail the request:
+//
+//	httpc.WithCheckRedirect(func(req *http.Request, via []*http.Request) error {
+//	    if req.URL.Host != via[0].URL.Host {
+//	        return http.ErrUseLastResponse
+//	    }
+//	    return nil
+//	})
+func WithCheckRedirect(fn CheckRedirectFunc) RequestOption {
+	return func(r *engine.Request) error {
+		r.SetCheckRedirect(fn)
+		return nil
+	}
+}
+
 // WithStreamBody enables streaming mode where the response body is not buffered
-// into memory. The caller reads the body directly via the engine Response's
-// RawBodyReader. Used internally for file downloads to avoid buffering large files.
+// into memory. Read the body via Result.Stream() (the caller must close it), or
+// let Result.Unmarshal decode it straight from the connection, bounded by the
+// request context. Also used internally for file downloads to avoid buffering
+// large files.
 func WithStreamBody(stream bool) RequestOption {
 	return func(r *engine.Request) error {
 		r.SetStreamBody(stream)
@@ -561,6 +1025,80 @@ func WithMaxRedirects(maxRedirects int) RequestOption {
 	}
 }
 
+// WithRedirectLimit sets the maximum number of redirects to follow for this
+// request and chooses what happens when the limit is exceeded. With
+// errorOnExceed true the request fails, as with WithMaxRedirects. With
+// errorOnExceed false the last 3xx response is returned instead, with no error.
+// Redirect following is enabled for the request.
+//
+// Example:
+//
+//	// Follow at most 3 redirects, then hand back the 3xx response
+//	result, err := client.Get(url, httpc.WithRedirectLimit(3, false))
+//	if err == nil && result.IsRedirect() {
+//	    log.Printf("gave up at %s", result.Response.Headers.Get("Location"))
+//	}
+//
+// Returns an error if n is less than 1 or exceeds 50.
+// Use WithFollowRedirects(false) to disable redirects entirely.
+func WithRedirectLimit(n int, errorOnExceed bool) RequestOption {
+	return func(r *engine.Request) error {
+		if n < 1 {
+			return fmt.Errorf("redirect limit must be at least 1")
+		}
+		if n > maxRedirectLimit {
+			return fmt.Errorf("redirect limit exceeds maximum %d", maxRedirectLimit)
+		}
+		follow := true
+		r.SetFollowRedirects(&follow)
+		r.SetMaxRedirects(&n)
+		r.SetReturnLastRedirect(!errorOnExceed)
+		return nil
+	}
+}
+
+// WithRateLimit overrides the client's per-host rate limit for this request:
+// at most rps requests per second to the request's host, with bursts of up to
+// burst requests. Requests sharing the same override share a token bucket per
+// host, separate from the client-wide Connection.RateLimit bucket.
+//
+// The request blocks until a token is available; if the context is cancelled
+// or the timeout expires first, a timeout or cancellation error is returned.
+//
+// Returns an error if rps is not positive or burst is less than 1.
+func WithRateLimit(rps float64, burst int) RequestOption {
+	return func(r *engine.Request) error {
+		if !(rps > 0) || math.IsInf(rps, 0) {
+			return fmt.Errorf("rate limit must be a positive number, got %v", rps)
+		}
+		if burst < 1 {
+			return fmt.Errorf("rate limit burst must be at least 1, got %d", burst)
+		}
+		r.SetRateLimit(rps, burst)
+		return nil
+	}
+}
+
+// WithNoCache bypasses the response cache (Connection.EnableCache) for this
+// request: no cached response is served and the response is not stored.
+// Has no effect when caching is disabled.
+func WithNoCache() RequestOption {
+	return func(r *engine.Request) error {
+		r.SetNoCache(true)
+		return nil
+	}
+}
+
+// WithEarlyHints captures the headers of 103 Early Hints responses sent before
+// the final response, exposing them via Result.Meta.EarlyHints. Useful for
+// prefetching resources announced with "Link: rel=preload".
+func WithEarlyHints() RequestOption {
+	return func(r *engine.Request) error {
+		r.SetEarlyHints(true)
+		return nil
+	}
+}
+
 // WithBinary sets binary data as the request body with an optional content type.
 // Returns an error if data is nil.
 func WithBinary(data []byte, contentType ...string) RequestOption {
@@ -580,6 +1118,31 @@ func WithBinary(data []byte, contentType ...string) RequestOption {
 	}
 }
 
+// WithBodyReaderLimit sets the request body to the first n bytes of r, e.g.
+// one chunk of a larger file, sent with a Content-Length of n. The rest of r
+// is left unread. r must hold at least n bytes; if it ends early the request
+// fails. An empty contentType defaults to application/octet-stream.
+// Returns an error if r is nil or n is negative.
+func WithBodyReaderLimit(r io.Reader, n int64, contentType string) RequestOption {
+	return func(req *engine.Request) error {
+		if r == nil {
+			return fmt.Errorf("body reader cannot be nil")
+		}
+		if n < 0 {
+			return fmt.Errorf("body limit cannot be negative, got %d", n)
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		} else if err := validation.ValidateHeaderKeyValue("Content-Type", contentType); err != nil {
+			return fmt.Errorf("invalid content type: %w", err)
+		}
+
+		req.SetBody(engine.SizedReader(r, n))
+		req.SetHeader("Content-Type", contentType)
+		return nil
+	}
+}
+
 // WithCookie adds a cookie to the request after validation.
 // Returns an error if the cookie name or value fails validation (empty name,
 // control characters, or invalid characters).
@@ -742,6 +1305,33 @@ func parseCookieString(cookieString string) ([]http.Cookie, error) {
 	return cookies, nil
 }
 
+// WithoutCookie drops the named cookie from the outgoing Cookie header for this
+// request, whether it comes from the cookie jar, a DomainClient session, or an
+// explicit WithCookie option. The cookie remains stored for later requests.
+// Call it multiple times to omit several cookies.
+//
+// Example:
+//
+//	// Send all jar cookies except the CSRF token
+//	result, err := client.Get(url, httpc.WithoutCookie("csrf_token"))
+//
+// Returns an error if name is empty or not a valid cookie name.
+func WithoutCookie(name string) RequestOption {
+	return func(r *engine.Request) error {
+		if name == "" {
+			return fmt.Errorf("cookie name cannot be empty")
+		}
+		if err := validation.ValidateCookieName(name); err != nil {
+			return fmt.Errorf("invalid cookie name: %w", err)
+		}
+		existing := r.OmittedCookies()
+		omitted := make([]string, len(existing), len(existing)+1)
+		copy(omitted, existing)
+		r.SetOmittedCookies(append(omitted, name))
+		return nil
+	}
+}
+
 // WithOnRequest registers a callback invoked before the request is sent.
 // The callback receives the request mutator, allowing inspection or modification
 // of the request before it's transmitted.
@@ -860,3 +1450,31 @@ func WithSecureCookie(securityConfig *validation.CookieSecurityConfig) RequestOp
 		return nil
 	}
 }
+
+// WithModifiers applies a sequence of request options in order, stopping at the
+// first one that returns an error. It lets reusable groups of mutations (tenant
+// headers, tracing, signing) be composed into a single option.
+//
+// Example:
+//
+//	withTenant := httpc.WithModifiers(
+//	    httpc.WithHeader("X-Tenant-ID", tenantID),
+//	    httpc.WithBearerToken(token),
+//	)
+//	result, err := client.Get("https://api.example.com/items", withTenant)
+//
+// Returns an error if any modifier is nil, or the first error returned by a
+// modifier (wrapped with its position).
+func WithModifiers(mods ...RequestOption) RequestOption {
+	return func(r *engine.Request) error {
+		for i, mod := range mods {
+			if mod == nil {
+				return fmt.Errorf("modifier %d cannot be nil", i)
+			}
+			if err := mod(r); err != nil {
+				return fmt.Errorf("modifier %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+}
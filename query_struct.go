@@ -0,0 +1,174 @@
+package httpc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cybergodev/httpc/internal/engine"
+	"github.com/cybergodev/httpc/internal/validation"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// WithQueryStruct sets query parameters from the exported fields of a struct
+// (or pointer to one), named by their `url` tags:
+//
+//	type ListParams struct {
+//	    Query  string    `url:"q"`
+//	    Page   int       `url:"page,omitempty"`
+//	    Tags   []string  `url:"tag"`             // tag=a&tag=b
+//	    Since  time.Time `url:"since,omitempty"` // RFC 3339
+//	    Draft  *bool     `url:"draft"`           // omitted when nil
+//	    Secret string    `url:"-"`               // never sent
+//	}
+//
+// Fields without a tag use the field name. "omitempty" skips zero values and
+// empty slices. Pointers are followed; nil pointers and empty slices are
+// never sent. Slices and arrays produce one parameter per element. Embedded
+// structs contribute their fields; other struct fields must be time.Time or
+// implement fmt.Stringer. A nil pointer sets no parameters.
+//
+// Returns an error if v is not a struct, a field type is unsupported, or a
+// key or value fails validation.
+func WithQueryStruct(v any) RequestOption {
+	return func(r *engine.Request) error {
+		rv := reflect.ValueOf(v)
+		for rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return nil
+			}
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return fmt.Errorf("query struct must be a struct, got %T", v)
+		}
+
+		params := make(map[string]any)
+		if err := appendQueryFields(rv, params); err != nil {
+			return err
+		}
+
+		existing := r.EnsureQueryParams()
+		for k, val := range params {
+			if err := validation.ValidateQueryKey(k); err != nil {
+				return fmt.Errorf("invalid key %s: %w", k, err)
+			}
+			values, ok := val.([]string)
+			if !ok {
+				values = []string{val.(string)}
+			}
+			for _, s := range values {
+				if len(s) > validation.MaxValueLen {
+					return fmt.Errorf("query value too long for key %s (max %d)", k, validation.MaxValueLen)
+				}
+			}
+			existing[k] = val
+		}
+		return nil
+	}
+}
+
+// appendQueryFields adds the query parameters for the fields of struct rv to
+// params: a string per scalar field and a []string per slice field.
+func appendQueryFields(rv reflect.Value, params map[string]any) error {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get("url")
+		if tag == "-" || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		omitEmpty := opts == "omitempty"
+
+		fv := rv.Field(i)
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Pointer {
+			continue // nil
+		}
+
+		if sf.Anonymous && name == "" && fv.Kind() == reflect.Struct && fv.Type() != timeType {
+			if err := appendQueryFields(fv, params); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		isBytes := fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Uint8
+		if (fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array) && !isBytes {
+			values := make([]string, 0, fv.Len())
+			for j := range fv.Len() {
+				s, ok, err := formatQueryField(fv.Index(j))
+				if err != nil {
+					return fmt.Errorf("query field %s: %w", sf.Name, err)
+				}
+				if ok {
+					values = append(values, s)
+				}
+			}
+			if len(values) > 0 {
+				params[name] = values
+			}
+			continue
+		}
+
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		s, _, err := formatQueryField(fv)
+		if err != nil {
+			return fmt.Errorf("query field %s: %w", sf.Name, err)
+		}
+		params[name] = s
+	}
+	return nil
+}
+
+// formatQueryField formats a single field or slice element, reporting false
+// for a nil pointer.
+func formatQueryField(fv reflect.Value) (string, bool, error) {
+	for fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return "", false, nil
+		}
+		fv = fv.Elem()
+	}
+	// Fields promoted from unexported embedded structs cannot be converted
+	// back to interfaces, so scalars are formatted by kind.
+	if fv.CanInterface() {
+		if t, ok := fv.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339), true, nil
+		}
+		if s, ok := fv.Interface().(fmt.Stringer); ok {
+			return s.String(), true, nil
+		}
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String(), true, nil
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool()), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(fv.Uint(), 10), true, nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits()), true, nil
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			return string(fv.Bytes()), true, nil
+		}
+	}
+	return "", false, fmt.Errorf("unsupported type %s", fv.Type())
+}
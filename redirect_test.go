@@ -1,9 +1,15 @@
 package httpc
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cybergodev/httpc/internal/engine"
 )
@@ -167,6 +173,48 @@ func TestRedirect_PerRequestOverride(t *testing.T) {
 	}
 }
 
+func TestRedirect_PerRequestEnable(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/start" {
+			http.Redirect(w, r, "/final", http.StatusFound)
+			return
+		}
+		w.Write([]byte("Final destination"))
+	}))
+	defer server.Close()
+
+	// Client configured not to follow redirects
+	config := testConfig()
+	config.Middleware.FollowRedirects = false
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	resp, err := client.Get(server.URL + "/start")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusFound {
+		t.Errorf("Expected status 302 without override, got %d", resp.StatusCode())
+	}
+
+	// Override to follow redirects for this request only
+	resp, err = client.Get(server.URL+"/start", WithFollowRedirects(true))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK || resp.Body() != "Final destination" {
+		t.Errorf("Expected 200 'Final destination', got %d %q", resp.StatusCode(), resp.Body())
+	}
+	if resp.Meta.RedirectCount != 1 {
+		t.Errorf("Expected 1 redirect, got %d", resp.Meta.RedirectCount)
+	}
+}
+
 func TestRedirect_MaxRedirectsPerRequest(t *testing.T) {
 	t.Parallel()
 
@@ -301,6 +349,61 @@ func TestRedirect_ChainTracking(t *testing.T) {
 	}
 }
 
+func TestRedirect_Hops(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/c", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(testConfig())
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	resp, err := client.Get(server.URL + "/a")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	want := []RedirectHop{
+		{FromURL: server.URL + "/a", ToURL: server.URL + "/b", StatusCode: http.StatusFound},
+		{FromURL: server.URL + "/b", ToURL: server.URL + "/c", StatusCode: http.StatusMovedPermanently},
+	}
+	hops := resp.Meta.RedirectHops
+	if len(hops) != len(want) {
+		t.Fatalf("Expected %d hops, got %d: %+v", len(want), len(hops), hops)
+	}
+	for i, w := range want {
+		got := hops[i]
+		if got.FromURL != w.FromURL || got.ToURL != w.ToURL || got.StatusCode != w.StatusCode {
+			t.Errorf("hop %d = %+v, want %+v", i, got, w)
+		}
+		if got.Duration <= 0 {
+			t.Errorf("hop %d duration = %v, want > 0", i, got.Duration)
+		}
+	}
+
+	t.Run("NoRedirects", func(t *testing.T) {
+		resp, err := client.Get(server.URL + "/c")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.Meta.RedirectHops != nil {
+			t.Errorf("Expected nil hops, got %+v", resp.Meta.RedirectHops)
+		}
+	})
+}
+
 var maxRedirectValidationCases = []struct {
 	name        string
 	maxRedirect int
@@ -523,3 +626,355 @@ func TestRedirect_MixedStatusCodes(t *testing.T) {
 		t.Errorf("Expected 3 redirects, got %d", resp.Meta.RedirectCount)
 	}
 }
+
+func TestRedirect_SameHostOnly(t *testing.T) {
+	t.Parallel()
+
+	finalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("other host"))
+	}))
+	defer finalServer.Close()
+	// Same port, different hostname: 127.0.0.1 vs localhost.
+	crossHostURL := strings.Replace(finalServer.URL, "127.0.0.1", "localhost", 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, "/same", http.StatusFound)
+		case "/same":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("same host"))
+		case "/away":
+			http.Redirect(w, r, crossHostURL, http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("same host followed", func(t *testing.T) {
+		resp, err := client.Get(server.URL+"/start", WithSameHostRedirectsOnly())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || resp.Body() != "same host" {
+			t.Errorf("Expected 200 'same host', got %d %q", resp.StatusCode(), resp.Body())
+		}
+		if resp.Meta.RedirectCount != 1 {
+			t.Errorf("Expected 1 redirect, got %d", resp.Meta.RedirectCount)
+		}
+	})
+
+	t.Run("cross host returned unfollowed", func(t *testing.T) {
+		resp, err := client.Get(server.URL+"/away", WithSameHostRedirectsOnly())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusFound {
+			t.Errorf("Expected 302, got %d", resp.StatusCode())
+		}
+		if loc := resp.Response.Headers.Get("Location"); loc != crossHostURL {
+			t.Errorf("Expected Location %q, got %q", crossHostURL, loc)
+		}
+	})
+
+	t.Run("cross host followed without option", func(t *testing.T) {
+		resp, err := client.Get(server.URL + "/away")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || resp.Body() != "other host" {
+			t.Errorf("Expected 200 'other host', got %d %q", resp.StatusCode(), resp.Body())
+		}
+	})
+}
+
+func TestRedirect_RedirectLimit(t *testing.T) {
+	t.Parallel()
+
+	// /hop/N redirects to /hop/N+1 forever.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		http.Redirect(w, r, "/hop/"+strconv.Itoa(n+1), http.StatusFound)
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("exceed with error", func(t *testing.T) {
+		_, err := client.Get(server.URL+"/hop/0", WithRedirectLimit(3, true))
+		if err == nil || !strings.Contains(err.Error(), "stopped after 3 redirects") {
+			t.Errorf("Expected redirect limit error, got %v", err)
+		}
+	})
+
+	t.Run("exceed returns last response", func(t *testing.T) {
+		resp, err := client.Get(server.URL+"/hop/0", WithRedirectLimit(3, false))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode() != http.StatusFound {
+			t.Errorf("Expected 302, got %d", resp.StatusCode())
+		}
+		// The limit is checked before following a hop, matching WithMaxRedirects.
+		if loc := resp.Response.Headers.Get("Location"); loc != "/hop/3" {
+			t.Errorf("Expected Location /hop/3, got %q", loc)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		for _, n := range []int{0, -1, 51} {
+			if _, err := client.Get(server.URL+"/hop/0", WithRedirectLimit(n, true)); err == nil {
+				t.Errorf("Expected error for limit %d", n)
+			}
+		}
+	})
+}
+
+func TestRedirect_Referer(t *testing.T) {
+	t.Parallel()
+
+	referers := make(chan string, 1)
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, "/end", http.StatusFound)
+		case "/end":
+			referers <- r.Header.Get("Referer")
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer plain.Close()
+
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, plain.URL+"/end", http.StatusFound)
+	}))
+	defer secure.Close()
+
+	newClient := func(t *testing.T, setReferer bool) Client {
+		t.Helper()
+		cfg := testConfig()
+		cfg.Middleware.DisableRefererOnRedirect = !setReferer
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+	get := func(t *testing.T, client Client, url string, opts ...RequestOption) string {
+		t.Helper()
+		if _, err := client.Get(url, opts...); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		return <-referers
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		client := newClient(t, true)
+		if got, want := get(t, client, plain.URL+"/start"), plain.URL+"/start"; got != want {
+			t.Errorf("Expected Referer %q, got %q", want, got)
+		}
+
+		// A MiddlewareConfig built by hand keeps the default behavior.
+		cfg := testConfig()
+		cfg.Middleware = &MiddlewareConfig{FollowRedirects: true, MaxRedirects: 10}
+		literal, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer literal.Close()
+		if got, want := get(t, literal, plain.URL+"/start"), plain.URL+"/start"; got != want {
+			t.Errorf("Expected Referer %q with a literal MiddlewareConfig, got %q", want, got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := newClient(t, false)
+		if got := get(t, client, plain.URL+"/start"); got != "" {
+			t.Errorf("Expected no Referer, got %q", got)
+		}
+		if got := get(t, client, plain.URL+"/start", WithHeader("Referer", "https://app.example/")); got != "https://app.example/" {
+			t.Errorf("Expected caller-set Referer to be kept, got %q", got)
+		}
+	})
+
+	t.Run("https to http downgrade", func(t *testing.T) {
+		client := newClient(t, true)
+		if got := get(t, client, secure.URL+"/start"); got != "" {
+			t.Errorf("Expected no Referer on downgrade, got %q", got)
+		}
+		if got := get(t, client, secure.URL+"/start", WithHeader("Referer", "https://app.example/")); got != "" {
+			t.Errorf("Expected caller-set Referer to be dropped on downgrade, got %q", got)
+		}
+	})
+}
+
+func TestRedirect_MaxRedirectTime(t *testing.T) {
+	t.Parallel()
+
+	// Each hop after the first is slow; /hop/N redirects to /hop/N+1 until /hop/3.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		if n > 0 {
+			select {
+			case <-time.After(150 * time.Millisecond):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if n < 3 {
+			http.Redirect(w, r, "/hop/"+strconv.Itoa(n+1), http.StatusFound)
+			return
+		}
+		w.Write([]byte("done"))
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T, limit time.Duration) Client {
+		t.Helper()
+		cfg := testConfig()
+		cfg.Middleware.MaxRedirectTime = limit
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		t.Cleanup(func() { client.Close() })
+		return client
+	}
+
+	t.Run("cumulative cap fires", func(t *testing.T) {
+		client := newClient(t, 200*time.Millisecond)
+		start := time.Now()
+		_, err := client.Get(server.URL + "/hop/0")
+		if !errors.Is(err, ErrRedirectTimeout) {
+			t.Fatalf("Expected ErrRedirectTimeout, got %v", err)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+		}
+		var clientErr *ClientError
+		if errors.As(err, &clientErr) && clientErr.Type != ErrorTypeTimeout {
+			t.Errorf("Expected timeout error type, got %v", clientErr.Type)
+		}
+		if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+			t.Errorf("Expected abort near 200ms, took %v", elapsed)
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		client := newClient(t, 2*time.Second)
+		resp, err := client.Get(server.URL + "/hop/0")
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.Body() != "done" || resp.Meta.RedirectCount != 3 {
+			t.Errorf("Expected 'done' after 3 redirects, got %q after %d", resp.Body(), resp.Meta.RedirectCount)
+		}
+	})
+
+	t.Run("no redirect starts no budget", func(t *testing.T) {
+		client := newClient(t, 200*time.Millisecond)
+		// No redirect: the budget never starts.
+		resp, err := client.Get(server.URL + "/hop/3")
+		if err != nil || resp.Body() != "done" {
+			t.Fatalf("Expected 'done', got %v %v", resp, err)
+		}
+	})
+}
+
+func TestRedirect_CheckRedirect(t *testing.T) {
+	t.Parallel()
+
+	authSeen := make(chan string, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authSeen <- r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+	// Same server reached through a different host name.
+	crossHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, crossHost+"/landing", http.StatusFound)
+	}))
+	defer origin.Close()
+
+	t.Run("AuthorizationDroppedAcrossHosts", func(t *testing.T) {
+		client, err := New(testConfig())
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		resp, err := client.Get(origin.URL, WithBearerToken("secret"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode())
+		}
+		if got := <-authSeen; got != "" {
+			t.Errorf("Authorization leaked to redirect target: %q", got)
+		}
+	})
+
+	t.Run("ConfigDenies", func(t *testing.T) {
+		var calls atomic.Int32
+		cfg := testConfig()
+		cfg.Middleware.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			calls.Add(1)
+			if req.Header.Get("Authorization") != "" {
+				t.Error("Authorization should be stripped before CheckRedirect runs")
+			}
+			if len(via) != 1 || req.URL.Path != "/landing" {
+				t.Errorf("unexpected redirect %s via %d requests", req.URL, len(via))
+			}
+			return http.ErrUseLastResponse
+		}
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		resp, err := client.Get(origin.URL, WithBearerToken("secret"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusFound {
+			t.Errorf("Expected 302, got %d", resp.StatusCode())
+		}
+		if calls.Load() != 1 {
+			t.Errorf("Expected 1 CheckRedirect call, got %d", calls.Load())
+		}
+	})
+
+	t.Run("PerRequestError", func(t *testing.T) {
+		client, err := New(testConfig())
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		errDenied := errors.New("cross-host redirect denied")
+		_, err = client.Get(origin.URL, WithCheckRedirect(func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host != via[0].URL.Host {
+				return errDenied
+			}
+			return nil
+		}))
+		if !errors.Is(err, errDenied) {
+			t.Errorf("Expected errDenied, got %v", err)
+		}
+	})
+}
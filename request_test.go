@@ -1,15 +1,28 @@
 package httpc
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cybergodev/httpc/internal/engine"
 	"github.com/cybergodev/httpc/internal/validation"
 )
 
@@ -101,6 +114,45 @@ func TestRequest_Headers(t *testing.T) {
 		}
 	})
 
+	t.Run("WithAcceptEncoding", func(t *testing.T) {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("Accept-Encoding")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		if _, err := client.Get(server.URL); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got != "gzip, deflate" {
+			t.Errorf("Expected default Accept-Encoding 'gzip, deflate', got %q", got)
+		}
+
+		if _, err := client.Get(server.URL, WithAcceptEncoding("identity")); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got != "identity" {
+			t.Errorf("Expected Accept-Encoding 'identity', got %q", got)
+		}
+
+		if _, err := client.Get(server.URL, WithAcceptEncoding("gzip", "br")); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got != "gzip, br" {
+			t.Errorf("Expected Accept-Encoding 'gzip, br', got %q", got)
+		}
+
+		for _, bad := range [][]string{nil, {""}, {"gzip\r\nX-Injected: 1"}} {
+			if err := WithAcceptEncoding(bad...)(&engine.Request{}); err == nil {
+				t.Errorf("expected error for encodings %q", bad)
+			}
+		}
+	})
+
 	t.Run("RequestHeadersInspection", func(t *testing.T) {
 		t.Parallel()
 
@@ -132,6 +184,148 @@ func TestRequest_Headers(t *testing.T) {
 	})
 }
 
+func TestRequest_HopByHopHeaders(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	rejected := []struct{ key, value string }{
+		{"Transfer-Encoding", "chunked"},
+		{"transfer-encoding", "chunked"},
+		{"Upgrade", "websocket"},
+		{"Connection", "Upgrade"},
+		{"TE", "trailers"},
+		{"Keep-Alive", "timeout=5"},
+	}
+	for _, h := range rejected {
+		if _, err := client.Get(server.URL, WithHeader(h.key, h.value)); err == nil {
+			t.Errorf("expected %s: %s to be rejected", h.key, h.value)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests to reach the server, got %d", n)
+	}
+
+	if _, err := client.Get(server.URL, WithHeader("Connection", "close")); err != nil {
+		t.Errorf("expected Connection: close to be allowed, got %v", err)
+	}
+
+	cfg := testConfig()
+	cfg.Security.AllowHopByHopHeaders = true
+	unsafe, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer unsafe.Close()
+	if _, err := unsafe.Get(server.URL, WithHeader("Upgrade", "websocket")); err != nil {
+		t.Errorf("expected Upgrade to be allowed with AllowHopByHopHeaders, got %v", err)
+	}
+}
+
+func TestRequest_GetBody(t *testing.T) {
+	bodies := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies <- string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	payload := map[string]string{"q": "x"}
+	if _, err := client.Get(server.URL, WithJSON(payload)); err == nil {
+		t.Error("expected GET with a JSON body to fail by default")
+	}
+	if _, err := client.Head(server.URL, WithBody("data")); err == nil {
+		t.Error("expected HEAD with a body to fail by default")
+	}
+	if n := len(bodies); n != 0 {
+		t.Errorf("expected no requests to reach the server, got %d", n)
+	}
+
+	cfg := testConfig()
+	cfg.Security.AllowGetBody = true
+	allowed, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer allowed.Close()
+	if _, err := allowed.Get(server.URL, WithJSON(payload)); err != nil {
+		t.Fatalf("expected GET with body to succeed with AllowGetBody, got %v", err)
+	}
+	if got := <-bodies; got != `{"q":"x"}` {
+		t.Errorf("server received body %q", got)
+	}
+}
+
+func TestWithRawHeaderName(t *testing.T) {
+	// net/http servers canonicalize incoming names, so read the raw request.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	lines := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var got []string
+		br := bufio.NewReader(conn)
+		for {
+			line, err := br.ReadString('\n')
+			if err != nil || line == "\r\n" {
+				break
+			}
+			got = append(got, strings.TrimRight(line, "\r\n"))
+		}
+		_, _ = conn.Write([]byte("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n"))
+		lines <- got
+	}()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	_, err = client.Get("http://"+ln.Addr().String(),
+		WithHeader("X-Api-Key", "canonical"),
+		WithRawHeaderName("x-api-KEY", "secret"),
+		WithHeader("Content-Md5", "abc"),
+		WithRawHeaderName("CONTENT-MD5", "def"),
+	)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	got := <-lines
+	has := func(want string) bool { return slices.Contains(got, want) }
+	if !has("x-api-KEY: secret") {
+		t.Errorf("expected exact-case header line, got %q", got)
+	}
+	if !has("CONTENT-MD5: def") {
+		t.Errorf("expected raw CONTENT-MD5 header line, got %q", got)
+	}
+	for _, line := range got {
+		if strings.HasPrefix(line, "X-Api-Key:") || strings.HasPrefix(line, "Content-Md5:") {
+			t.Errorf("canonical duplicate %q should have been replaced", line)
+		}
+	}
+
+	if _, err := client.Get("http://"+ln.Addr().String(), WithRawHeaderName("x-bad", "a\r\nb")); err == nil {
+		t.Error("expected CRLF in raw header value to be rejected")
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Authentication
 // ----------------------------------------------------------------------------
@@ -160,11 +354,138 @@ func TestRequest_Authentication(t *testing.T) {
 		}
 	})
 
+	t.Run("WithDigestAuth", func(t *testing.T) {
+		const realm, nonce, opaque = "api@example.com", "7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", "FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"
+		sha := func(s string) string {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:])
+		}
+		var challenged, bodies atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if b, _ := io.ReadAll(r.Body); string(b) == "payload" {
+				bodies.Add(1)
+			}
+			creds := appendAuthChallenges(nil, r.Header.Get("Authorization"))
+			if len(creds) != 1 || creds[0].Scheme != "Digest" {
+				challenged.Add(1)
+				w.Header().Set("WWW-Authenticate", `Basic realm="other", Digest realm="`+realm+
+					`", qop="auth, auth-int", algorithm=SHA-256, nonce="`+nonce+`", opaque="`+opaque+`"`)
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			p := creds[0].Params
+			ha1 := sha("user:" + realm + ":secret")
+			ha2 := sha(r.Method + ":" + r.URL.RequestURI())
+			want := sha(ha1 + ":" + nonce + ":" + p["nc"] + ":" + p["cnonce"] + ":auth:" + ha2)
+			if p["username"] != "user" || p["uri"] != r.URL.RequestURI() || p["qop"] != "auth" ||
+				p["opaque"] != opaque || p["cnonce"] == "" || p["response"] != want {
+				t.Errorf("Invalid digest credentials: %q", r.Header.Get("Authorization"))
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		resp, err := client.Post(server.URL+"/orders?id=1", WithDigestAuth("user", "secret"),
+			WithBody(strings.NewReader("payload")))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || challenged.Load() != 1 || bodies.Load() != 2 {
+			t.Errorf("Expected 200 after one challenge with the body sent twice, got %d, %d challenges, %d bodies",
+				resp.StatusCode(), challenged.Load(), bodies.Load())
+		}
+
+		mwClient, _ := newMiddlewareTestClient()
+		defer mwClient.Close()
+		resp, err = mwClient.Get(server.URL+"/orders", WithDigestAuth("user", "secret"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || challenged.Load() != 2 {
+			t.Errorf("Expected 200 after one challenge with middleware configured, got %d, %d challenges",
+				resp.StatusCode(), challenged.Load())
+		}
+
+		// RFC 2617 section 3.5 example.
+		ch := AuthChallenge{Scheme: "Digest", Params: map[string]string{
+			"realm": "testrealm@host.com", "qop": "auth,auth-int",
+			"nonce": "dcd98b7102dd2f0e8b11d0f600bfb0c093", "opaque": "5ccc069c403ebaf9f0171e9517f40e41",
+		}}
+		auth, ok := digestAuthorization(ch, "Mufasa", "Circle Of Life", "GET", "/dir/index.html", "0a4f113b")
+		if !ok || !strings.Contains(auth, `response="6629fae49393a05397450978507c4ef1"`) {
+			t.Errorf("Unexpected RFC 2617 digest: %q", auth)
+		}
+		ch.Params["algorithm"] = "SHA-512"
+		if _, ok := digestAuthorization(ch, "u", "p", "GET", "/", "c"); ok {
+			t.Error("Expected unsupported algorithm to be rejected")
+		}
+	})
+
+	t.Run("WithAWSSigV4", func(t *testing.T) {
+		var gotAuth, gotDate, gotBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			gotAuth, gotDate, gotBody = r.Header.Get("Authorization"), r.Header.Get("X-Amz-Date"), string(b)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		_, err := client.Post(server.URL+"/items", WithBody(strings.NewReader("payload")),
+			WithAWSSigV4("AKID", "secret", "eu-west-1", "execute-api"))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/"+gotDate[:8]+"/eu-west-1/execute-api/aws4_request, ") ||
+			!strings.Contains(gotAuth, "host;") || !strings.Contains(gotAuth, ";x-amz-date,") || gotBody != "payload" {
+			t.Errorf("Unexpected signed request: auth %q, date %q, body %q", gotAuth, gotDate, gotBody)
+		}
+
+		mwClient, _ := newMiddlewareTestClient()
+		defer mwClient.Close()
+		gotAuth = ""
+		if _, err := mwClient.Get(server.URL, WithAWSSigV4("AKID", "secret", "eu-west-1", "execute-api")); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if !strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/") {
+			t.Errorf("Expected a signed request with middleware configured, got auth %q", gotAuth)
+		}
+
+		if _, err := client.Get(server.URL, WithAWSSigV4("AKID", "", "eu-west-1", "s3")); err == nil {
+			t.Error("Expected error for empty secret key")
+		}
+
+		// AWS Signature Version 4 documentation example (IAM ListUsers).
+		req, _ := http.NewRequest("GET", "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+		req.Header.Set("X-Amz-Date", "20150830T123600Z")
+		canonical, _ := sigV4CanonicalRequest(req, "iam", sha256Hex(nil))
+		if got := sha256Hex([]byte(canonical)); got != "f536975d06c0309214f805bb90ccff089219ecd68b2577efef23edd43b7e1a59" {
+			t.Errorf("Unexpected canonical request hash %s for:\n%s", got, canonical)
+		}
+		signAWSSigV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "iam",
+			time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+		want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+			"SignedHeaders=content-type;host;x-amz-date, " +
+			"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+		if got := req.Header.Get("Authorization"); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+	})
+
 	authErrorCases := []struct {
 		name string
 		opt  RequestOption
 	}{
 		{"EmptyUsername", WithBasicAuth("", "pass")},
+		{"EmptyDigestUsername", WithDigestAuth("", "pass")},
 		{"EmptyBearerToken", WithBearerToken("")},
 		{"EmptyHeaderKey", WithHeader("", "value")},
 		{"EmptyHeaderKeyWithControlChars", WithHeader("X-Bad\r\n", "value")},
@@ -255,6 +576,134 @@ func TestRequest_QueryParameters(t *testing.T) {
 		}
 	})
 
+	t.Run("WithQuerySlice", func(t *testing.T) {
+		queries := make(chan url.Values, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries <- r.URL.Query()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		_, err := client.Get(server.URL+"?fixed=1",
+			WithQuery("status", "open"),
+			WithQuerySlice("status", []string{"closed"}),
+			WithQuerySlice("tag", []string{"a", "b c"}),
+			WithQuerySlice("tag", []string{"d&e"}),
+		)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		q := <-queries
+		if got := q["tag"]; !slices.Equal(got, []string{"a", "b c", "d&e"}) {
+			t.Errorf("Expected tag=[a b c d&e], got %q", got)
+		}
+		if got := q["status"]; !slices.Equal(got, []string{"open", "closed"}) {
+			t.Errorf("Expected status=[open closed], got %q", got)
+		}
+		if q.Get("fixed") != "1" {
+			t.Errorf("Expected existing query to be kept, got %v", q)
+		}
+
+		if _, err := client.Get(server.URL, WithQuerySlice("tag", nil), WithQuerySlice("label", []string{})); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if q := <-queries; len(q) != 0 {
+			t.Errorf("Expected empty slices to add no parameters, got %v", q)
+		}
+
+		if _, err := client.Get(server.URL, WithQuerySlice("", []string{"a"})); err == nil {
+			t.Error("Expected error for empty key")
+		}
+	})
+
+	t.Run("WithQueryStruct", func(t *testing.T) {
+		queries := make(chan string, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries <- r.URL.RawQuery
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		type Paging struct {
+			Page  int `url:"page,omitempty"`
+			Limit int `url:"limit"`
+		}
+		type params struct {
+			Paging
+			Query   string    `url:"q"`
+			Tags    []string  `url:"tag"`
+			IDs     []*int    `url:"id"`
+			Since   time.Time `url:"since,omitempty"`
+			Until   time.Time `url:"until,omitempty"`
+			Draft   *bool     `url:"draft"`
+			Archive *bool     `url:"archived"`
+			Empty   string    `url:"empty,omitempty"`
+			Blank   string    `url:"blank"`
+			Secret  string    `url:"-"`
+			Plain   float64
+			hidden  string
+		}
+		one, two, yes := 1, 2, true
+		p := &params{
+			Paging: Paging{Limit: 20},
+			Query:  "go http",
+			Tags:   []string{"a", "b"},
+			IDs:    []*int{&one, nil, &two},
+			Since:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Draft:  &yes,
+			Secret: "s3cret",
+			Plain:  1.5,
+			hidden: "x",
+		}
+
+		if _, err := client.Get(server.URL, WithQueryStruct(&p)); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		q, err := url.ParseQuery(<-queries)
+		if err != nil {
+			t.Fatalf("Invalid query: %v", err)
+		}
+		want := url.Values{
+			"limit": {"20"},
+			"q":     {"go http"},
+			"tag":   {"a", "b"},
+			"id":    {"1", "2"},
+			"since": {"2024-05-01T12:00:00Z"},
+			"draft": {"true"},
+			"blank": {""},
+			"Plain": {"1.5"},
+		}
+		if len(q) != len(want) {
+			t.Errorf("Expected keys %v, got %v", want, q)
+		}
+		for k, v := range want {
+			if !slices.Equal(q[k], v) {
+				t.Errorf("%s = %q, want %q", k, q[k], v)
+			}
+		}
+
+		if _, err := client.Get(server.URL, WithQueryStruct("not a struct")); err == nil {
+			t.Error("Expected error for non-struct")
+		}
+		if _, err := client.Get(server.URL, WithQueryStruct(struct {
+			M map[string]string `url:"m"`
+		}{M: map[string]string{}})); err == nil {
+			t.Error("Expected error for unsupported field type")
+		}
+		if _, err := client.Get(server.URL, WithQueryStruct((*params)(nil))); err != nil {
+			t.Errorf("Expected nil pointer to add no parameters, got %v", err)
+		}
+		if got := <-queries; got != "" {
+			t.Errorf("Expected empty query for nil struct, got %q", got)
+		}
+	})
+
 	t.Run("WithQueryMap nil", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
@@ -507,76 +956,350 @@ func TestRequest_WithBody(t *testing.T) {
 	}
 }
 
-// ----------------------------------------------------------------------------
-// Note: Cookie tests have been moved to cookie_test.go for better organization
-// ----------------------------------------------------------------------------
+func TestWithRequestTee(t *testing.T) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
 
-// ----------------------------------------------------------------------------
-// Timeout & Retry Options
-// ----------------------------------------------------------------------------
+	client, _ := newTestClient()
+	defer client.Close()
 
-func TestRequest_TimeoutAndRetry(t *testing.T) {
-	t.Run("WithMaxRetries", func(t *testing.T) {
-		attempts := int32(0)
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			count := atomic.AddInt32(&attempts, 1)
-			if count < 2 {
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			w.WriteHeader(http.StatusOK)
-		}))
-		defer server.Close()
+	var tee bytes.Buffer
+	payload := map[string]any{"name": "widget", "tags": []string{"a", "b"}, "price": 9.5}
+	if _, err := client.Post(server.URL, WithJSON(payload), WithRequestTee(&tee)); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	got := <-received
+	if len(got) == 0 || !bytes.Equal(tee.Bytes(), got) {
+		t.Errorf("tee captured %q, server received %q", tee.Bytes(), got)
+	}
 
-		client, _ := newTestClient()
-		defer client.Close()
+	tee.Reset()
+	if _, err := client.Get(server.URL, WithRequestTee(&tee)); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	<-received
+	if tee.Len() != 0 {
+		t.Errorf("expected nothing teed for a bodiless request, got %q", tee.Bytes())
+	}
 
-		resp, err := client.Get(server.URL, WithMaxRetries(3))
-		if err != nil {
-			t.Fatalf("Request failed: %v", err)
-		}
-		if resp.StatusCode() != http.StatusOK {
-			t.Errorf("Expected 200, got %d", resp.StatusCode())
-		}
-		if resp.Meta.Attempts < 2 {
-			t.Errorf("Expected at least 2 attempts with retries, got %d", resp.Meta.Attempts)
-		}
-	})
+	if err := WithRequestTee(nil)(nil); err == nil {
+		t.Error("expected error for nil writer")
+	}
 }
 
-// ----------------------------------------------------------------------------
-// Combined Options
-// ----------------------------------------------------------------------------
-
-func TestRequest_CombinedOptions(t *testing.T) {
+func TestWithBodyReaderLimit(t *testing.T) {
+	type received struct {
+		body          []byte
+		contentLength int64
+		contentType   string
+	}
+	got := make(chan received, 1)
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Verify all options applied
-		if r.Header.Get("X-Custom") != "value" {
-			t.Error("Header not set")
-		}
-		if r.URL.Query().Get("param") != "test" {
-			t.Error("Query param not set")
-		}
-		cookie, err := r.Cookie("session")
-		if err != nil || cookie.Value != "abc123" {
-			t.Error("Cookie not set")
-		}
-		w.WriteHeader(http.StatusOK)
+		body, _ := io.ReadAll(r.Body)
+		got <- received{body, r.ContentLength, r.Header.Get("Content-Type")}
 	}))
 	defer server.Close()
 
 	client, _ := newTestClient()
 	defer client.Close()
 
-	_, err := client.Get(server.URL,
-		WithHeader("X-Custom", "value"),
-		WithQuery("param", "test"),
-		WithCookie(http.Cookie{Name: "session", Value: "abc123"}),
-	)
-	if err != nil {
+	src := strings.NewReader("0123456789abcdef")
+	if _, err := client.Post(server.URL, WithBodyReaderLimit(src, 10, "text/plain")); err != nil {
 		t.Fatalf("Request failed: %v", err)
 	}
-}
+	r := <-got
+	if string(r.body) != "0123456789" || r.contentLength != 10 || r.contentType != "text/plain" {
+		t.Errorf("Expected 10 bytes of text/plain, got %q (Content-Length %d, %q)", r.body, r.contentLength, r.contentType)
+	}
+
+	if _, err := client.Post(server.URL, WithBodyReaderLimit(src, 4, "")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	r = <-got
+	if string(r.body) != "abcd" || r.contentLength != 4 || r.contentType != "application/octet-stream" {
+		t.Errorf("Expected next 4 bytes as octet-stream, got %q (Content-Length %d, %q)", r.body, r.contentLength, r.contentType)
+	}
+
+	if _, err := client.Post(server.URL, WithBodyReaderLimit(strings.NewReader("abc"), 10, "")); err == nil {
+		t.Error("Expected error for a reader shorter than the limit")
+	}
+
+	if err := WithBodyReaderLimit(nil, 1, "")(&engine.Request{}); err == nil {
+		t.Error("Expected error for nil reader")
+	}
+	if err := WithBodyReaderLimit(src, -1, "")(&engine.Request{}); err == nil {
+		t.Error("Expected error for negative limit")
+	}
+}
+
+func TestWithHost(t *testing.T) {
+	hosts := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts <- r.Host
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	if _, err := client.Get(server.URL, WithHost("www.example.com")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := <-hosts; got != "www.example.com" {
+		t.Errorf("Expected Host www.example.com, got %q", got)
+	}
+	if _, err := client.Get(server.URL); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got, want := <-hosts, server.Listener.Addr().String(); got != want {
+		t.Errorf("Expected default Host %q, got %q", want, got)
+	}
+
+	mwClient, _ := newMiddlewareTestClient()
+	defer mwClient.Close()
+	if _, err := mwClient.Get(server.URL, WithHost("mw.example.com")); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := <-hosts; got != "mw.example.com" {
+		t.Errorf("Expected Host mw.example.com with middleware configured, got %q", got)
+	}
+
+	for _, bad := range []string{"", "a b", "evil.com/path", "user@host", "host\r\nX: y"} {
+		if err := WithHost(bad)(&engine.Request{}); err == nil {
+			t.Errorf("Expected error for host %q", bad)
+		}
+	}
+}
+
+func TestWithRawRequest(t *testing.T) {
+	hosts := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts <- r.Host + " " + r.Header.Get("X-First")
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	_, err := client.Get(server.URL,
+		WithRawRequest(func(req *http.Request) error {
+			req.Header.Set("X-First", "1")
+			return nil
+		}),
+		WithRawRequest(func(req *http.Request) error {
+			req.Host = "custom.example"
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := <-hosts; got != "custom.example 1" {
+		t.Errorf("Expected host custom.example with both callbacks applied, got %q", got)
+	}
+
+	mwClient, _ := newMiddlewareTestClient()
+	defer mwClient.Close()
+	_, err = mwClient.Get(server.URL, WithRawRequest(func(req *http.Request) error {
+		req.Header.Set("X-First", "mw")
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := <-hosts; !strings.HasSuffix(got, " mw") {
+		t.Errorf("Expected the callback to run with middleware configured, got %q", got)
+	}
+
+	errBoom := errors.New("boom")
+	if _, err := client.Get(server.URL, WithRawRequest(func(*http.Request) error { return errBoom })); !errors.Is(err, errBoom) {
+		t.Errorf("Expected callback error, got %v", err)
+	}
+	_, err = client.Get(server.URL, WithRawRequest(func(req *http.Request) error {
+		*req = *req.WithContext(context.Background())
+		return nil
+	}))
+	if err == nil || !strings.Contains(err.Error(), "must not replace the request context") {
+		t.Errorf("Expected error for a replaced context, got %v", err)
+	}
+	if err := WithRawRequest(nil)(&engine.Request{}); err == nil {
+		t.Error("Expected error for nil callback")
+	}
+}
+
+func TestWithReadProgress(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 2<<20) // 2MB, read in many chunks
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(payload)))
+		_, _ = w.Write(payload)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	var calls int
+	var last, total int64
+	result, err := client.Get(server.URL, WithReadProgress(func(read, tot int64) {
+		if read <= last {
+			t.Errorf("expected increasing counts, got %d after %d", read, last)
+		}
+		calls++
+		last, total = read, tot
+	}))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if calls < 2 {
+		t.Errorf("expected several progress callbacks, got %d", calls)
+	}
+	if last != int64(len(payload)) || total != int64(len(payload)) || len(result.RawBody()) != len(payload) {
+		t.Errorf("expected final progress %d/%d, got %d/%d", len(payload), len(payload), last, total)
+	}
+
+	if err := WithReadProgress(nil)(nil); err == nil {
+		t.Error("expected error for nil callback")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Note: Cookie tests have been moved to cookie_test.go for better organization
+// ----------------------------------------------------------------------------
+
+// ----------------------------------------------------------------------------
+// Timeout & Retry Options
+// ----------------------------------------------------------------------------
+
+func TestRequest_TimeoutAndRetry(t *testing.T) {
+	t.Run("WithMaxRetries", func(t *testing.T) {
+		attempts := int32(0)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count := atomic.AddInt32(&attempts, 1)
+			if count < 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		resp, err := client.Get(server.URL, WithMaxRetries(3))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			t.Errorf("Expected 200, got %d", resp.StatusCode())
+		}
+		if resp.Meta.Attempts < 2 {
+			t.Errorf("Expected at least 2 attempts with retries, got %d", resp.Meta.Attempts)
+		}
+	})
+
+	t.Run("ConfigOverride", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			if r.URL.Path == "/slow" {
+				time.Sleep(300 * time.Millisecond)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		config := testConfig()
+		config.Retry.Delay = time.Millisecond
+		client, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		retries := 2
+		ctx := WithConfigOverride(context.Background(), ConfigOverride{Timeout: 50 * time.Millisecond, MaxRetries: &retries})
+
+		if _, err := client.Request(ctx, "GET", server.URL+"/slow"); err == nil {
+			t.Error("Expected the context override timeout to fail the slow request")
+		}
+		if _, err := client.Request(ctx, "GET", server.URL+"/slow", WithTimeout(time.Second)); err != nil {
+			t.Errorf("Expected WithTimeout to take precedence over the override, got %v", err)
+		}
+
+		attempts.Store(0)
+		result, err := client.Request(ctx, "GET", server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if result.Meta.Attempts != 3 || attempts.Load() != 3 {
+			t.Errorf("Expected 3 attempts from the override, got meta %d, server %d", result.Meta.Attempts, attempts.Load())
+		}
+		attempts.Store(0)
+		if _, err := client.Request(ctx, "GET", server.URL, WithMaxRetries(0)); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if attempts.Load() != 1 {
+			t.Errorf("Expected WithMaxRetries(0) to take precedence, got %d attempts", attempts.Load())
+		}
+
+		// A middleware can install the override on the request context.
+		config.Middleware.Middlewares = []MiddlewareFunc{
+			func(next Handler) Handler {
+				return func(ctx context.Context, req RequestMutator) (ResponseMutator, error) {
+					req.SetContext(WithConfigOverride(req.Context(), ConfigOverride{Timeout: 50 * time.Millisecond}))
+					return next(ctx, req)
+				}
+			},
+		}
+		mwClient, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer mwClient.Close()
+		if _, err := mwClient.Get(server.URL + "/slow"); err == nil {
+			t.Error("Expected the middleware override timeout to fail the slow request")
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Combined Options
+// ----------------------------------------------------------------------------
+
+func TestRequest_CombinedOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Verify all options applied
+		if r.Header.Get("X-Custom") != "value" {
+			t.Error("Header not set")
+		}
+		if r.URL.Query().Get("param") != "test" {
+			t.Error("Query param not set")
+		}
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != "abc123" {
+			t.Error("Cookie not set")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	_, err := client.Get(server.URL,
+		WithHeader("X-Custom", "value"),
+		WithQuery("param", "test"),
+		WithCookie(http.Cookie{Name: "session", Value: "abc123"}),
+	)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+}
 
 // ----------------------------------------------------------------------------
 // WithFile
@@ -631,6 +1354,414 @@ func TestWithFile(t *testing.T) {
 	})
 }
 
+// ----------------------------------------------------------------------------
+// WithFileReader
+// ----------------------------------------------------------------------------
+
+// multipartPartSizes returns the byte count of each file part by field name.
+func multipartPartSizes(t *testing.T, r *http.Request) map[string]int64 {
+	t.Helper()
+	mr, err := r.MultipartReader()
+	if err != nil {
+		t.Errorf("expected multipart body: %v", err)
+		return nil
+	}
+	sizes := make(map[string]int64)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return sizes
+		}
+		if err != nil {
+			t.Errorf("read part: %v", err)
+			return sizes
+		}
+		n, _ := io.Copy(io.Discard, part)
+		sizes[part.FormName()] = n
+	}
+}
+
+func TestWithFileReader(t *testing.T) {
+	t.Run("nil reader", func(t *testing.T) {
+		if err := WithFileReader("file", "a.bin", nil, "")(nil); err == nil {
+			t.Error("expected error for nil reader")
+		}
+	})
+
+	t.Run("streams large body with bounded allocations", func(t *testing.T) {
+		const size = 100 << 20 // 100MB
+		var received atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength != -1 {
+				t.Errorf("expected chunked upload, got Content-Length %d", r.ContentLength)
+			}
+			received.Store(multipartPartSizes(t, r)["upload"])
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		_, err := client.Post(server.URL, WithFileReader("upload", "big.bin", io.LimitReader(zeroReader{}, size), "application/octet-stream"))
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			t.Fatalf("upload failed: %v", err)
+		}
+		if got := received.Load(); got != size {
+			t.Errorf("server received %d bytes, want %d", got, size)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 16<<20 {
+			t.Errorf("upload allocated %d bytes; expected streaming well below the %d byte body", alloc, size)
+		}
+	})
+
+	t.Run("known size sends Content-Length", func(t *testing.T) {
+		content := strings.Repeat("z", 4096)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength <= int64(len(content)) {
+				t.Errorf("expected Content-Length covering the form, got %d", r.ContentLength)
+			}
+			sizes := multipartPartSizes(t, r)
+			if sizes["doc"] != int64(len(content)) || sizes["inline"] != 3 {
+				t.Errorf("unexpected part sizes: %v", sizes)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		_, err := client.Post(server.URL, WithFormData(&FormData{
+			Fields: map[string]string{"name": "value"},
+			Files: map[string]*FileData{
+				"doc":    {Filename: "doc.txt", Reader: strings.NewReader(content), Size: int64(len(content)), ContentType: "text/plain"},
+				"inline": {Filename: "inline.txt", Content: []byte("abc")},
+			},
+		}))
+		if err != nil {
+			t.Fatalf("upload failed: %v", err)
+		}
+	})
+}
+
+func TestWithFormDataStreaming(t *testing.T) {
+	t.Run("nil data", func(t *testing.T) {
+		if err := WithFormDataStreaming(nil)(nil); err == nil {
+			t.Error("expected error for nil form data")
+		}
+	})
+
+	t.Run("streams large form with bounded allocations", func(t *testing.T) {
+		const size = 64 << 20 // 64MB
+		type upload struct {
+			contentLength int64
+			sizes         map[string]int64
+		}
+		uploads := make(chan upload, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			uploads <- upload{r.ContentLength, multipartPartSizes(t, r)}
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		form := &FormData{
+			Fields: map[string]string{"name": "backup"},
+			Files: map[string]*FileData{
+				"big":   {Filename: "big.bin", Reader: io.LimitReader(zeroReader{}, size), Size: size, ContentType: "application/octet-stream"},
+				"small": {Filename: "small.txt", Content: []byte("hello")},
+			},
+		}
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		result, err := client.Post(server.URL, WithFormDataStreaming(form))
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			t.Fatalf("upload failed: %v", err)
+		}
+		if !result.IsSuccess() {
+			t.Fatalf("expected success, got %d", result.StatusCode())
+		}
+		got := <-uploads
+		sizes, contentLength := got.sizes, got.contentLength
+		if sizes["big"] != size || sizes["small"] != 5 || sizes["name"] != int64(len("backup")) {
+			t.Errorf("unexpected part sizes: %v", sizes)
+		}
+		if contentLength <= size {
+			t.Errorf("expected Content-Length covering the form, got %d", contentLength)
+		}
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 16<<20 {
+			t.Errorf("upload allocated %d bytes; expected streaming well below the %d byte body", alloc, size)
+		}
+		if form.Files["small"].Reader != nil {
+			t.Error("caller's FormData was modified")
+		}
+	})
+
+	t.Run("not retried", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		cfg := testConfig()
+		cfg.Retry.MaxRetries = 3
+		cfg.Retry.Delay = time.Millisecond
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		result, err := client.Post(server.URL, WithFormDataStreaming(&FormData{
+			Files: map[string]*FileData{"f": {Filename: "f.txt", Content: []byte("data")}},
+		}))
+		if err == nil && result.StatusCode() != http.StatusServiceUnavailable {
+			t.Errorf("expected the failed attempt to be reported, got %d", result.StatusCode())
+		}
+		if got := attempts.Load(); got != 1 {
+			t.Errorf("expected 1 attempt, got %d", got)
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// WithFormDataFromDir
+// ----------------------------------------------------------------------------
+
+func TestMultipart_DetectContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+	seen := make(chan map[string]string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := make(map[string]string)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("expected multipart body: %v", err)
+		} else {
+			for {
+				part, err := mr.NextPart()
+				if err != nil {
+					break
+				}
+				data, _ := io.ReadAll(part)
+				got[part.FormName()] = part.Header.Get("Content-Type")
+				if part.FormName() != "empty" && !bytes.Equal(data, png) {
+					t.Errorf("part %s: content altered by detection", part.FormName())
+				}
+			}
+		}
+		seen <- got
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		name string
+		opt  RequestOption
+		want map[string]string
+	}{
+		{"content", WithFile("image", "pixel.png", png), map[string]string{"image": "image/png"}},
+		{"reader", WithFileReader("image", "pixel.png", bytes.NewReader(png), ""), map[string]string{"image": "image/png"}},
+		{"explicit type kept", WithFileReader("image", "pixel.png", bytes.NewReader(png), "application/x-custom"), map[string]string{"image": "application/x-custom"}},
+		{"empty falls back", WithFormData(&FormData{Files: map[string]*FileData{
+			"empty": {Filename: "empty.bin", Reader: strings.NewReader(""), Size: 0},
+		}}), map[string]string{"empty": "application/octet-stream"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Post(server.URL, tt.opt); err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			got := <-seen
+			for field, want := range tt.want {
+				if got[field] != want {
+					t.Errorf("part %s: Content-Type = %q, want %q", field, got[field], want)
+				}
+			}
+		})
+	}
+}
+
+func TestMultipart_Parts(t *testing.T) {
+	type part struct{ contentType, filename, body string }
+	seen := make(chan map[string]part, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := make(map[string]part)
+		mr, err := r.MultipartReader()
+		if err != nil {
+			t.Errorf("expected multipart body: %v", err)
+		} else {
+			for {
+				p, err := mr.NextPart()
+				if err != nil {
+					break
+				}
+				data, _ := io.ReadAll(p)
+				got[p.FormName()] = part{p.Header.Get("Content-Type"), p.FileName(), string(data)}
+			}
+		}
+		seen <- got
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	form := &FormData{
+		Fields: map[string]string{"title": "report"},
+		Parts: map[string]*PartData{
+			"metadata": {ContentType: "application/json", Content: []byte(`{"pages":3}`)},
+			"note":     {Content: []byte("plain")},
+		},
+		Files: map[string]*FileData{
+			"file": {Filename: "a.txt", Content: []byte("hello"), ContentType: "text/plain"},
+		},
+	}
+	want := map[string]part{
+		"metadata": {"application/json", "", `{"pages":3}`},
+		"note":     {"text/plain; charset=utf-8", "", "plain"},
+		"file":     {"text/plain", "a.txt", "hello"},
+	}
+
+	for _, tt := range []struct {
+		name string
+		opt  RequestOption
+	}{
+		{"buffered", WithFormData(form)},
+		{"streaming", WithFormDataStreaming(form)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Post(server.URL, tt.opt); err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			got := <-seen
+			for name, w := range want {
+				if got[name] != w {
+					t.Errorf("part %s = %+v, want %+v", name, got[name], w)
+				}
+			}
+			if got["title"].body != "report" {
+				t.Errorf("field title = %q, want report", got["title"].body)
+			}
+		})
+	}
+}
+
+func TestFormDataFromDir(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"index.html":         "<html><body>hi</body></html>",
+		"notes.txt":          "plain notes",
+		"assets/app.css":     "body { color: red; }",
+		"assets/img/a.txt":   "nested text",
+		"drafts/wip.html":    "<html>wip</html>",
+		"drafts/skip/me.txt": "skipped",
+	}
+	for name, content := range files {
+		full := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("nested directories", func(t *testing.T) {
+		form, err := FormDataFromDir(root, nil, nil)
+		if err != nil {
+			t.Fatalf("FormDataFromDir failed: %v", err)
+		}
+		if len(form.Files) != len(files) {
+			t.Fatalf("expected %d files, got %d", len(files), len(form.Files))
+		}
+		nested := form.Files["assets/img/a.txt"]
+		if nested == nil || nested.Filename != "a.txt" || nested.Size != int64(len("nested text")) {
+			t.Fatalf("unexpected nested file: %+v", nested)
+		}
+		if ct := form.Files["index.html"].ContentType; !strings.HasPrefix(ct, "text/html") {
+			t.Errorf("expected detected text/html, got %q", ct)
+		}
+	})
+
+	t.Run("glob filter", func(t *testing.T) {
+		form, err := FormDataFromDir(root, []string{"*.html", "*.txt"}, []string{"drafts/*", "drafts/*/*"})
+		if err != nil {
+			t.Fatalf("FormDataFromDir failed: %v", err)
+		}
+		var got []string
+		for name := range form.Files {
+			got = append(got, name)
+		}
+		slices.Sort(got)
+		want := []string{"assets/img/a.txt", "index.html", "notes.txt"}
+		if !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		if _, err := FormDataFromDir(root, []string{"["}, nil); err == nil {
+			t.Error("expected error for malformed pattern")
+		}
+	})
+
+	t.Run("symlink escaping root", func(t *testing.T) {
+		outside := filepath.Join(t.TempDir(), "secret.txt")
+		_ = os.WriteFile(outside, []byte("secret"), 0o644)
+		linked := t.TempDir()
+		_ = os.WriteFile(filepath.Join(linked, "ok.txt"), []byte("ok"), 0o644)
+		if err := os.Symlink(outside, filepath.Join(linked, "leak.txt")); err != nil {
+			t.Skipf("symlinks unsupported: %v", err)
+		}
+		if _, err := FormDataFromDir(linked, nil, nil); err == nil || !strings.Contains(err.Error(), "escapes") {
+			t.Errorf("expected symlink escape error, got %v", err)
+		}
+	})
+
+	t.Run("upload", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sizes := multipartPartSizes(t, r)
+			if sizes["assets/app.css"] != int64(len(files["assets/app.css"])) || len(sizes) != len(files) {
+				t.Errorf("unexpected parts: %v", sizes)
+			}
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		if _, err := client.Post(server.URL, WithFormDataFromDir(root, nil, nil)); err != nil {
+			t.Fatalf("upload failed: %v", err)
+		}
+	})
+}
+
+// zeroReader yields an endless stream of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
 // ----------------------------------------------------------------------------
 // WithContext
 // ----------------------------------------------------------------------------
@@ -847,3 +1978,73 @@ func TestConvertToForm(t *testing.T) {
 		}
 	})
 }
+
+// ----------------------------------------------------------------------------
+// WithModifiers
+// ----------------------------------------------------------------------------
+
+func TestWithModifiers(t *testing.T) {
+	t.Parallel()
+
+	record := func(order *[]string, name string) RequestOption {
+		return func(r *engine.Request) error {
+			*order = append(*order, name)
+			r.SetHeader("X-"+name, "1")
+			return nil
+		}
+	}
+
+	t.Run("applies in order", func(t *testing.T) {
+		var gotHeaders http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeaders = r.Header.Clone()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		var order []string
+		_, err := client.Get(server.URL, WithModifiers(
+			record(&order, "Tenant"),
+			record(&order, "Trace"),
+			record(&order, "Sign"),
+		))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if strings.Join(order, ",") != "Tenant,Trace,Sign" {
+			t.Errorf("modifiers ran in order %v", order)
+		}
+		for _, h := range []string{"X-Tenant", "X-Trace", "X-Sign"} {
+			if gotHeaders.Get(h) != "1" {
+				t.Errorf("expected header %s to be set", h)
+			}
+		}
+	})
+
+	t.Run("short-circuits on error", func(t *testing.T) {
+		sentinel := errors.New("signing failed")
+		var order []string
+		opt := WithModifiers(
+			record(&order, "Tenant"),
+			func(r *engine.Request) error { return sentinel },
+			record(&order, "Sign"),
+		)
+
+		err := opt(&engine.Request{})
+		if !errors.Is(err, sentinel) {
+			t.Fatalf("expected sentinel error, got %v", err)
+		}
+		if strings.Join(order, ",") != "Tenant" {
+			t.Errorf("expected only first modifier to run, got %v", order)
+		}
+	})
+
+	t.Run("nil modifier", func(t *testing.T) {
+		if err := WithModifiers(nil)(&engine.Request{}); err == nil {
+			t.Error("expected error for nil modifier")
+		}
+	})
+}
@@ -1,12 +1,24 @@
 package httpc
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/text/encoding/japanese"
 )
 
 // ============================================================================
@@ -182,6 +194,290 @@ func TestResult_Unmarshal(t *testing.T) {
 		if err == nil {
 			t.Error("Expected JSON parsing error")
 		}
+		if errors.Is(err, ErrUnexpectedHTML) {
+			t.Errorf("Non-HTML body reported as HTML: %v", err)
+		}
+	})
+
+	t.Run("HTML body", func(t *testing.T) {
+		page := "\n  <!DOCTYPE html><html><head><title>Sign in</title></head><body>" + strings.Repeat("x", 500) + "</body></html>"
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(page))
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		for _, stream := range []bool{false, true} {
+			result, err := client.Get(server.URL, WithStreamBody(stream))
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			var data map[string]any
+			err = result.Unmarshal(&data)
+			if !errors.Is(err, ErrUnexpectedHTML) {
+				t.Fatalf("stream=%v: expected ErrUnexpectedHTML, got %v", stream, err)
+			}
+			if !strings.Contains(err.Error(), "<title>Sign in</title>") || strings.Contains(err.Error(), strings.Repeat("x", 200)) {
+				t.Errorf("stream=%v: expected a short body snippet, got %q", stream, err)
+			}
+		}
+	})
+}
+
+func TestResult_UnmarshalStrict(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	newResult := func(body string) *Result {
+		return &Result{Response: &ResponseInfo{StatusCode: http.StatusOK, RawBody: []byte(body)}}
+	}
+
+	t.Run("Known fields", func(t *testing.T) {
+		var u user
+		if err := newResult(`{"id":1,"name":"ann"}`).UnmarshalStrict(&u); err != nil {
+			t.Fatalf("UnmarshalStrict failed: %v", err)
+		}
+		if u.ID != 1 || u.Name != "ann" {
+			t.Errorf("Unexpected result: %+v", u)
+		}
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		body := `{"id":1,"name":"ann","role":"admin"}`
+		var lenient user
+		if err := newResult(body).Unmarshal(&lenient); err != nil {
+			t.Fatalf("Unmarshal should ignore unknown fields: %v", err)
+		}
+		var strict user
+		err := newResult(body).UnmarshalStrict(&strict)
+		if err == nil || !strings.Contains(err.Error(), `"role"`) {
+			t.Errorf("Expected error naming field \"role\", got %v", err)
+		}
+	})
+
+	t.Run("Trailing data", func(t *testing.T) {
+		var u user
+		if err := newResult(`{"id":1} {"id":2}`).UnmarshalStrict(&u); err == nil {
+			t.Error("Expected error for trailing data")
+		}
+		if err := newResult("{\"id\":1}\n").UnmarshalStrict(&u); err != nil {
+			t.Errorf("Trailing whitespace should be accepted: %v", err)
+		}
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"id":1,"extra":true}`))
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		result, err := client.Get(server.URL, WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var u user
+		if err := result.UnmarshalStrict(&u); err == nil || !strings.Contains(err.Error(), `"extra"`) {
+			t.Errorf("Expected unknown field error, got %v", err)
+		}
+	})
+
+	t.Run("Empty body", func(t *testing.T) {
+		var u user
+		if err := newResult("").UnmarshalStrict(&u); !errors.Is(err, ErrResponseBodyEmpty) {
+			t.Errorf("Expected ErrResponseBodyEmpty, got %v", err)
+		}
+	})
+}
+
+func TestResult_XML(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		XMLName struct{} `xml:"item"`
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+		Tags    []string `xml:"tags>tag"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	sent := item{ID: 7, Name: "widget", Tags: []string{"a", "b"}}
+
+	t.Run("Round trip", func(t *testing.T) {
+		result, err := client.Post(server.URL, WithXML(sent))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var got item
+		if err := result.XML(&got); err != nil {
+			t.Fatalf("XML failed: %v", err)
+		}
+		if got.ID != sent.ID || got.Name != sent.Name || strings.Join(got.Tags, ",") != "a,b" {
+			t.Errorf("Round trip mismatch: got %+v, want %+v", got, sent)
+		}
+	})
+
+	t.Run("Streaming", func(t *testing.T) {
+		result, err := client.Post(server.URL, WithXML(sent), WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var got item
+		if err := result.XML(&got); err != nil {
+			t.Fatalf("XML failed: %v", err)
+		}
+		if got.Name != sent.Name {
+			t.Errorf("Expected name %q, got %q", sent.Name, got.Name)
+		}
+	})
+
+	t.Run("Empty body", func(t *testing.T) {
+		var got item
+		if err := (&Result{Response: &ResponseInfo{}}).XML(&got); !errors.Is(err, ErrResponseBodyEmpty) {
+			t.Errorf("Expected ErrResponseBodyEmpty, got %v", err)
+		}
+		var nilResult *Result
+		if err := nilResult.XML(&got); !errors.Is(err, ErrResponseBodyEmpty) {
+			t.Errorf("Expected ErrResponseBodyEmpty for nil result, got %v", err)
+		}
+	})
+
+	t.Run("Invalid XML", func(t *testing.T) {
+		var got item
+		result := &Result{Response: &ResponseInfo{RawBody: []byte("<item><name>x</item>")}}
+		if err := result.XML(&got); err == nil {
+			t.Error("Expected error for malformed XML")
+		}
+	})
+}
+
+func TestResult_Charset(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		XMLName struct{} `xml:"item"`
+		Name    string   `xml:"name" json:"name"`
+	}
+	const name = "東京タワー"
+	sjis := func(s string) []byte {
+		b, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/xml":
+			w.Header().Set("Content-Type", "application/xml; charset=Shift_JIS")
+			_, _ = w.Write(sjis(`<?xml version="1.0" encoding="Shift_JIS"?><item><name>` + name + `</name></item>`))
+		case "/xml-declared":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write(sjis(`<?xml version="1.0" encoding="Shift_JIS"?><item><name>` + name + `</name></item>`))
+		case "/json":
+			w.Header().Set("Content-Type", "application/json; charset=shift_jis")
+			_, _ = w.Write(sjis(`{"name":"` + name + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	for _, path := range []string{"/xml", "/xml-declared", "/json"} {
+		for _, stream := range []bool{false, true} {
+			result, err := client.Get(server.URL+path, WithStreamBody(stream))
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			var got item
+			if err := result.Into(&got); err != nil {
+				t.Fatalf("%s stream=%v: decode failed: %v", path, stream, err)
+			}
+			if got.Name != name {
+				t.Errorf("%s stream=%v: expected %q, got %q", path, stream, name, got.Name)
+			}
+		}
+	}
+}
+
+func TestResult_Into(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		XMLName struct{} `json:"-" xml:"item"`
+		Name    string   `json:"name" xml:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("ct"))
+		switch r.URL.Path {
+		case "/json":
+			_, _ = w.Write([]byte(`{"name":"from-json"}`))
+		case "/xml":
+			_, _ = w.Write([]byte(`<item><name>from-xml</name></item>`))
+		default:
+			_, _ = w.Write([]byte("name=plain"))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	tests := []struct {
+		path, contentType, want string
+	}{
+		{"/json", "application/json", "from-json"},
+		{"/json", "application/problem+json; charset=utf-8", "from-json"},
+		{"/xml", "application/xml", "from-xml"},
+		{"/xml", "text/xml; charset=utf-8", "from-xml"},
+		{"/xml", "application/atom+xml", "from-xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			result, err := client.Get(server.URL+tt.path, WithQuery("ct", tt.contentType))
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			var got item
+			if err := result.Into(&got); err != nil {
+				t.Fatalf("Into failed: %v", err)
+			}
+			if got.Name != tt.want {
+				t.Errorf("Expected name %q, got %q", tt.want, got.Name)
+			}
+		})
+	}
+
+	t.Run("Unsupported", func(t *testing.T) {
+		for _, ct := range []string{"text/plain", ""} {
+			result, err := client.Get(server.URL+"/text", WithQuery("ct", ct))
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			var got item
+			if err := result.Into(&got); !errors.Is(err, ErrUnsupportedContentType) {
+				t.Errorf("Content-Type %q: expected ErrUnsupportedContentType, got %v", ct, err)
+			}
+		}
 	})
 }
 
@@ -193,6 +489,58 @@ func TestResult_Unmarshal(t *testing.T) {
 // Nil Safety
 // ----------------------------------------------------------------------------
 
+func TestResult_AuthChallenges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		headers []string
+		want    []AuthChallenge
+	}{
+		{
+			name: "Digest",
+			headers: []string{`Digest realm="api@example.com", qop="auth,auth-int", ` +
+				`nonce="dcd98b7102dd2f0e", opaque="5ccc069c", algorithm=SHA-256, stale=FALSE`},
+			want: []AuthChallenge{{Scheme: "Digest", Params: map[string]string{
+				"realm": "api@example.com", "qop": "auth,auth-int", "nonce": "dcd98b7102dd2f0e",
+				"opaque": "5ccc069c", "algorithm": "SHA-256", "stale": "FALSE",
+			}}},
+		},
+		{
+			name:    "Basic and Bearer",
+			headers: []string{`Basic realm="simple", Bearer realm="example", error="invalid_token", error_description="say \"hi\""`},
+			want: []AuthChallenge{
+				{Scheme: "Basic", Params: map[string]string{"realm": "simple"}},
+				{Scheme: "Bearer", Params: map[string]string{
+					"realm": "example", "error": "invalid_token", "error_description": `say "hi"`,
+				}},
+			},
+		},
+		{
+			name:    "Token68 and multiple headers",
+			headers: []string{"Negotiate YIIabc==", "NTLM, Basic Realm=x"},
+			want: []AuthChallenge{
+				{Scheme: "Negotiate", Params: map[string]string{}, Token68: "YIIabc=="},
+				{Scheme: "NTLM", Params: map[string]string{}},
+				{Scheme: "Basic", Params: map[string]string{"realm": "x"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &Result{Response: &ResponseInfo{Headers: http.Header{"Www-Authenticate": tt.headers}}}
+			got := result.AuthChallenges()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AuthChallenges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := (&Result{Response: &ResponseInfo{Headers: http.Header{}}}).AuthChallenges(); got != nil {
+		t.Errorf("Expected nil without WWW-Authenticate, got %+v", got)
+	}
+}
+
 func TestResult_NilSafety(t *testing.T) {
 	t.Parallel()
 
@@ -217,6 +565,9 @@ func TestResult_NilSafety(t *testing.T) {
 	if result.IsServerError() {
 		t.Error("Nil result IsServerError should be false")
 	}
+	if result.Duration() != 0 || result.Attempts() != 0 || result.RedirectCount() != 0 {
+		t.Error("Nil result Meta accessors should be zero")
+	}
 
 	var data map[string]interface{}
 	if err := result.Unmarshal(&data); err == nil {
@@ -256,6 +607,61 @@ func TestResult_IsRedirect(t *testing.T) {
 	}
 }
 
+func TestResult_Location(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if loc := r.URL.Query().Get("to"); loc != "" {
+			w.Header().Set("Location", loc)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	tests := []struct {
+		location, want string
+	}{
+		{"/login?next=1", server.URL + "/login?next=1"},
+		{"../other", server.URL + "/other"},
+		{"sibling", server.URL + "/a/sibling"},
+		{"https://example.com/x", "https://example.com/x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			result, err := client.Get(server.URL+"/a/b", WithQuery("to", tt.location), WithFollowRedirects(false))
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			loc, err := result.Location()
+			if err != nil {
+				t.Fatalf("Location failed: %v", err)
+			}
+			if loc.String() != tt.want {
+				t.Errorf("Location() = %q, want %q", loc, tt.want)
+			}
+		})
+	}
+
+	t.Run("Missing", func(t *testing.T) {
+		result, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if _, err := result.Location(); !errors.Is(err, http.ErrNoLocation) {
+			t.Errorf("Expected http.ErrNoLocation, got %v", err)
+		}
+		var nilResult *Result
+		if _, err := nilResult.Location(); !errors.Is(err, http.ErrNoLocation) {
+			t.Errorf("Expected http.ErrNoLocation for nil result, got %v", err)
+		}
+	})
+}
+
 // ----------------------------------------------------------------------------
 // Nil and Empty Accessors (table-driven)
 // ----------------------------------------------------------------------------
@@ -282,6 +688,38 @@ func TestResult_NilAndEmptyAccessors(t *testing.T) {
 		}
 	})
 
+	t.Run("Header", func(t *testing.T) {
+		h := http.Header{}
+		h.Add("Content-Type", "application/json; charset=utf-8")
+		h.Add("X-Multi", "a")
+		h.Add("X-Multi", "b")
+		r := &Result{Response: &ResponseInfo{Headers: h}}
+
+		if got := r.Header("content-type"); got != "application/json; charset=utf-8" {
+			t.Errorf("Header(content-type) = %q", got)
+		}
+		if got := r.ContentType(); got != "application/json; charset=utf-8" {
+			t.Errorf("ContentType() = %q", got)
+		}
+		if got := r.Header("x-multi"); got != "a" {
+			t.Errorf("Header(x-multi) = %q, want first value", got)
+		}
+		if got := r.HeaderValues("X-MULTI"); !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("HeaderValues(X-MULTI) = %v", got)
+		}
+		if got := r.Header("X-Missing"); got != "" {
+			t.Errorf("Header(X-Missing) = %q, want empty", got)
+		}
+		if got := r.HeaderValues("X-Missing"); got != nil {
+			t.Errorf("HeaderValues(X-Missing) = %v, want nil", got)
+		}
+		for _, nr := range []*Result{nil, {}} {
+			if nr.Header("A") != "" || nr.HeaderValues("A") != nil || nr.ContentType() != "" {
+				t.Errorf("Expected empty header accessors for %#v", nr)
+			}
+		}
+	})
+
 	t.Run("RequestCookies", func(t *testing.T) {
 		tests := []struct {
 			name string
@@ -568,3 +1006,606 @@ func TestResult_SaveToFile_Boundaries(t *testing.T) {
 		}
 	})
 }
+
+// ----------------------------------------------------------------------------
+// Decompression
+// ----------------------------------------------------------------------------
+
+// TestResult_DecompressedOnce checks that decompression is done by the
+// response processor alone: the transport's transparent gzip handling is
+// always off, so a gzip body is decoded exactly once whether Accept-Encoding
+// was added by the client or set by the caller.
+func TestResult_DecompressedOnce(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{"message":"decoded once"}`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(payload)); err != nil {
+		t.Fatalf("gzip write failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close failed: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(compressed.Bytes())
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	tests := []struct {
+		name       string
+		opts       []RequestOption
+		wantAccept string
+	}{
+		{"client default", nil, "gzip, deflate"},
+		{"caller header", []RequestOption{WithHeader("Accept-Encoding", "gzip")}, "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.Get(server.URL, tt.opts...)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if got := result.Response.Headers.Get("X-Accept-Encoding"); got != tt.wantAccept {
+				t.Errorf("Accept-Encoding sent = %q, want %q", got, tt.wantAccept)
+			}
+			if got := result.Body(); got != payload {
+				t.Errorf("Body = %q, want %q", got, payload)
+			}
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// KeepCompressedBody
+// ----------------------------------------------------------------------------
+
+func TestResult_KeepCompressedBody(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{"message":"hello compressed world"}`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(payload)); err != nil {
+		t.Fatalf("gzip write failed: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close failed: %v", err)
+	}
+	wire := compressed.Bytes()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(wire)
+	}))
+	defer server.Close()
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Connection.KeepCompressedBody = true
+		client, err := New(cfg)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		defer client.Close()
+
+		result, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got := string(result.RawBody()); got != payload {
+			t.Errorf("RawBody = %q, want %q", got, payload)
+		}
+		if !bytes.Equal(result.Response.CompressedRawBody, wire) {
+			t.Errorf("CompressedRawBody mismatch: got %d bytes, want %d", len(result.Response.CompressedRawBody), len(wire))
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client, _ := newTestClient()
+		defer client.Close()
+
+		result, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got := string(result.RawBody()); got != payload {
+			t.Errorf("RawBody = %q, want %q", got, payload)
+		}
+		if result.Response.CompressedRawBody != nil {
+			t.Error("CompressedRawBody should be nil when KeepCompressedBody is disabled")
+		}
+	})
+}
+
+func TestResult_SniffCompression(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{"message":"gzip without a header"}`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write([]byte(payload))
+	_ = gz.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream") // no Content-Encoding
+		switch r.URL.Path {
+		case "/gzip":
+			_, _ = w.Write(compressed.Bytes())
+		case "/short":
+			_, _ = w.Write([]byte{0x1f})
+		}
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Connection.SniffCompression = true
+	sniffing, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer sniffing.Close()
+	plain, _ := newTestClient()
+	defer plain.Close()
+
+	result, err := sniffing.Get(server.URL + "/gzip")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if got := string(result.RawBody()); got != payload {
+		t.Errorf("Expected sniffed body to be decompressed, got %q", got)
+	}
+
+	result, err = plain.Get(server.URL + "/gzip")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if !bytes.Equal(result.RawBody(), compressed.Bytes()) {
+		t.Error("Expected the body untouched without SniffCompression")
+	}
+
+	result, err = sniffing.Get(server.URL + "/short")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if !bytes.Equal(result.RawBody(), []byte{0x1f}) {
+		t.Errorf("Expected a body shorter than the magic to be kept, got %v", result.RawBody())
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Streaming Unmarshal honors the request context
+// ----------------------------------------------------------------------------
+
+func TestResult_Unmarshal_StreamingContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("complete stream decodes", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"stream","count":3}`))
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		result, err := client.Get(server.URL, WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var data struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		}
+		if err := result.Unmarshal(&data); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if data.Name != "stream" || data.Count != 3 {
+			t.Errorf("unexpected decode result: %+v", data)
+		}
+	})
+
+	t.Run("slow stream aborts on deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items":[1,`))
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		result, err := client.Get(server.URL, WithContext(ctx), WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+
+		start := time.Now()
+		var data map[string]any
+		err = result.Unmarshal(&data)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("decode took %v, expected to abort near the deadline", elapsed)
+		}
+	})
+
+	t.Run("oversized stream reports too large", func(t *testing.T) {
+		chunk := bytes.Repeat([]byte("x"), 64*1024)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`"`))
+			for written := 0; written <= maxJSONSize; written += len(chunk) {
+				if _, err := w.Write(chunk); err != nil {
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`"`))
+		}))
+		defer server.Close()
+
+		cfg := testConfig()
+		cfg.Security.MaxResponseBodySize = 2 * maxJSONSize
+		client, _ := New(cfg)
+		defer client.Close()
+
+		result, err := client.Get(server.URL, WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		var s string
+		if err := result.Unmarshal(&s); !errors.Is(err, ErrResponseBodyTooLarge) {
+			t.Errorf("expected ErrResponseBodyTooLarge, got %v", err)
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Streaming body via Result.Stream()
+// ----------------------------------------------------------------------------
+
+// Not parallel: the allocation ceiling is measured process-wide.
+func TestResult_Stream(t *testing.T) {
+	const totalSize = 50 * 1024 * 1024
+	chunk := bytes.Repeat([]byte("x"), 32*1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(totalSize))
+		for written := 0; written < totalSize; written += len(chunk) {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Security.MaxResponseBodySize = 64 * 1024 * 1024
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Close()
+
+	t.Run("reads large body incrementally", func(t *testing.T) {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		result, err := client.Get(server.URL, WithStreamBody(true))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if len(result.RawBody()) != 0 {
+			t.Errorf("RawBody should not be buffered in streaming mode, got %d bytes", len(result.RawBody()))
+		}
+
+		body := result.Stream()
+		if body == nil {
+			t.Fatal("Stream() returned nil for streaming request")
+		}
+		n, err := io.CopyBuffer(io.Discard, body, make([]byte, 32*1024))
+		if err != nil {
+			t.Fatalf("stream read failed: %v", err)
+		}
+		if err := body.Close(); err != nil {
+			t.Errorf("Close failed: %v", err)
+		}
+		if n != totalSize {
+			t.Errorf("read %d bytes, want %d", n, totalSize)
+		}
+
+		runtime.ReadMemStats(&after)
+		// Buffering the body would allocate at least totalSize bytes.
+		const ceiling = 8 * 1024 * 1024
+		if allocated := after.TotalAlloc - before.TotalAlloc; allocated > ceiling {
+			t.Errorf("allocated %d bytes while streaming, want under %d", allocated, ceiling)
+		}
+	})
+
+	t.Run("nil without streaming", func(t *testing.T) {
+		small := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("buffered"))
+		}))
+		defer small.Close()
+
+		result, err := client.Get(small.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if result.Stream() != nil {
+			t.Error("Stream() should be nil when the body was buffered")
+		}
+		if result.Body() != "buffered" {
+			t.Errorf("Body = %q, want 'buffered'", result.Body())
+		}
+	})
+}
+
+func TestResult_Close(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 128*1024))
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient()
+	defer client.Close()
+
+	first, err := client.Get(server.URL, WithStreamBody(true))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if first.Stream() != nil {
+		t.Error("Expected Stream() to be nil after Close")
+	}
+	if err := first.Close(); err != nil {
+		t.Errorf("Expected a second Close to be a no-op, got %v", err)
+	}
+
+	second, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if first.Meta.ConnectionID == "" || second.Meta.ConnectionID != first.Meta.ConnectionID {
+		t.Errorf("Expected the connection to be reused after Close, got %q then %q",
+			first.Meta.ConnectionID, second.Meta.ConnectionID)
+	}
+	if err := second.Close(); err != nil {
+		t.Errorf("Expected Close on a buffered result to be a no-op, got %v", err)
+	}
+	if err := (*Result)(nil).Close(); err != nil {
+		t.Errorf("Expected nil Result Close to be a no-op, got %v", err)
+	}
+
+	// A server that stops sending mid-body must not hold Close past the
+	// request context, and cancelling it mid-drain must be safe.
+	stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer stalled.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result, err := client.Get(stalled.URL, WithStreamBody(true), WithContext(ctx))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	start := time.Now()
+	_ = result.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Close to stop at the request deadline, took %v", elapsed)
+	}
+}
+
+func TestResult_MetaTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	cfg := testConfig()
+	cfg.Security.TLSConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	info := result.Meta.TLS
+	if info == nil {
+		t.Fatal("Expected Meta.TLS for HTTPS response")
+	}
+	if info.Version != tls.VersionTLS12 && info.Version != tls.VersionTLS13 {
+		t.Errorf("Expected TLS 1.2 or 1.3, got %s", info.VersionName())
+	}
+	if info.CipherSuite == 0 || info.CipherSuiteName() == "" {
+		t.Errorf("Expected a negotiated cipher suite, got %d", info.CipherSuite)
+	}
+	if want := server.Certificate().Subject.String(); len(info.PeerSubjects) == 0 || info.PeerSubjects[0] != want {
+		t.Errorf("Expected leaf subject %q, got %v", want, info.PeerSubjects)
+	}
+	if result.TLS() != info {
+		t.Error("Expected TLS() to return Meta.TLS")
+	}
+
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer plain.Close()
+	result, err = client.Get(plain.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.TLS != nil || result.TLS() != nil {
+		t.Errorf("Expected nil Meta.TLS for plain HTTP, got %+v", result.Meta.TLS)
+	}
+	if (&Result{}).TLS() != nil || (*Result)(nil).TLS() != nil {
+		t.Error("Expected nil TLS() for a Result without Meta")
+	}
+}
+
+func TestResult_MetaEarlyHints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Link", "</style.css>; rel=preload; as=style")
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Header().Del("Link")
+		w.Write([]byte("final"))
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Get(server.URL, WithEarlyHints())
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.StatusCode() != http.StatusOK || result.Body() != "final" {
+		t.Fatalf("Expected final 200 response, got %d %q", result.StatusCode(), result.Body())
+	}
+	if got := result.Meta.EarlyHints.Get("Link"); got != "</style.css>; rel=preload; as=style" {
+		t.Errorf("Expected Link hint, got %q", got)
+	}
+	if got := result.Response.Headers.Get("Link"); got != "" {
+		t.Errorf("Expected no Link on final response, got %q", got)
+	}
+
+	result, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.EarlyHints != nil {
+		t.Errorf("Expected nil EarlyHints without WithEarlyHints, got %v", result.Meta.EarlyHints)
+	}
+}
+
+func TestResult_MetaBytes(t *testing.T) {
+	payload := strings.Repeat("x", 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	reqBody := strings.Repeat("y", 1000)
+	result, err := client.Post(server.URL, WithBody(reqBody))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.BytesSent <= int64(len(reqBody)) {
+		t.Errorf("Expected BytesSent > %d (headers + body), got %d", len(reqBody), result.Meta.BytesSent)
+	}
+	if result.Meta.BytesReceived <= int64(len(payload)) {
+		t.Errorf("Expected BytesReceived > %d (headers + body), got %d", len(payload), result.Meta.BytesReceived)
+	}
+
+	result, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.BytesSent <= 0 || result.Meta.BytesSent >= int64(len(reqBody)) {
+		t.Errorf("Expected BytesSent to count only headers for GET, got %d", result.Meta.BytesSent)
+	}
+}
+
+func TestResult_MetaTiming(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for _, stream := range []bool{false, true} {
+		result, err := client.Get(server.URL, WithStreamBody(stream))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if s := result.Stream(); s != nil {
+			s.Close()
+		}
+		tm := result.Meta.Timing
+		if tm.DNS < 0 || tm.Connect < 0 || tm.TLS < 0 {
+			t.Errorf("stream=%v: expected non-negative phases, got %+v", stream, tm)
+		}
+		if tm.TTFB < delay || tm.TTFB > tm.Total {
+			t.Errorf("stream=%v: expected %v <= TTFB <= Total, got %+v", stream, delay, tm)
+		}
+		if tm.Total > result.Meta.Duration {
+			t.Errorf("stream=%v: expected Total within Duration %v, got %+v", stream, result.Meta.Duration, tm)
+		}
+	}
+}
+
+func TestResult_MetaConnectionID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client, err := newTestClient()
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	first, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	second, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	wantRemote := "->" + server.Listener.Addr().String()
+	if !strings.HasSuffix(first.Meta.ConnectionID, wantRemote) {
+		t.Errorf("Expected ConnectionID ending in %q, got %q", wantRemote, first.Meta.ConnectionID)
+	}
+	if second.Meta.ConnectionID != first.Meta.ConnectionID {
+		t.Errorf("Expected keep-alive reuse to report the same ID, got %q and %q",
+			first.Meta.ConnectionID, second.Meta.ConnectionID)
+	}
+}
@@ -0,0 +1,112 @@
+// Package resptest provides fluent assertions on httpc results for use in
+// tests:
+//
+//	result, err := client.Get(server.URL + "/users/1")
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	var user User
+//	resptest.Expect(t, result).
+//	    Status(http.StatusOK).
+//	    Header("Content-Type", "application/json").
+//	    JSON(&user)
+//
+// Each check calls t.Fatalf on failure, naming the expectation and quoting
+// the start of the response body, so a chain stops at the first mismatch.
+package resptest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cybergodev/httpc"
+)
+
+// maxQuotedBody caps how much of the response body a failure message quotes.
+const maxQuotedBody = 256
+
+// Assertion checks a single Result. Create one with Expect; every method
+// returns the same Assertion so checks can be chained.
+type Assertion struct {
+	tb     testing.TB
+	result *httpc.Result
+}
+
+// Expect starts a chain of checks on result, failing tb immediately if
+// result is nil.
+func Expect(tb testing.TB, result *httpc.Result) *Assertion {
+	tb.Helper()
+	if result == nil || result.Response == nil {
+		tb.Fatalf("resptest: expected a response, got nil")
+	}
+	return &Assertion{tb: tb, result: result}
+}
+
+// Status fails unless the response status code is code.
+func (a *Assertion) Status(code int) *Assertion {
+	a.tb.Helper()
+	if got := a.result.StatusCode(); got != code {
+		a.fatalf("status %d, got %d", code, got)
+	}
+	return a
+}
+
+// Success fails unless the response status code is 2xx.
+func (a *Assertion) Success() *Assertion {
+	a.tb.Helper()
+	if !a.result.IsSuccess() {
+		a.fatalf("2xx status, got %d", a.result.StatusCode())
+	}
+	return a
+}
+
+// Header fails unless the response header name has value. Values are
+// compared exactly; use HeaderContains for parameterised headers such as
+// "application/json; charset=utf-8".
+func (a *Assertion) Header(name, value string) *Assertion {
+	a.tb.Helper()
+	if got := a.result.Response.Headers.Get(name); got != value {
+		a.fatalf("header %s: %q, got %q", name, value, got)
+	}
+	return a
+}
+
+// HeaderContains fails unless the response header name contains substr.
+func (a *Assertion) HeaderContains(name, substr string) *Assertion {
+	a.tb.Helper()
+	if got := a.result.Response.Headers.Get(name); !strings.Contains(got, substr) {
+		a.fatalf("header %s containing %q, got %q", name, substr, got)
+	}
+	return a
+}
+
+// BodyContains fails unless the response body contains substr.
+func (a *Assertion) BodyContains(substr string) *Assertion {
+	a.tb.Helper()
+	if !strings.Contains(a.result.Body(), substr) {
+		a.fatalf("body containing %q", substr)
+	}
+	return a
+}
+
+// JSON decodes the response body into v with Result.Unmarshal, failing if
+// the body is empty or not valid JSON for v.
+func (a *Assertion) JSON(v any) *Assertion {
+	a.tb.Helper()
+	if err := a.result.Unmarshal(v); err != nil {
+		a.fatalf("JSON body: %v", err)
+	}
+	return a
+}
+
+// fatalf fails the test with "expected <what>" followed by the response
+// status and the start of its body.
+func (a *Assertion) fatalf(format string, args ...any) {
+	a.tb.Helper()
+	body := a.result.Body()
+	if len(body) > maxQuotedBody {
+		body = body[:maxQuotedBody] + "..."
+	}
+	args = append(args, a.result.StatusCode(), body)
+	a.tb.Fatalf("resptest: expected "+format+"\nresponse: %d %q", args...)
+}
@@ -0,0 +1,121 @@
+package resptest
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cybergodev/httpc"
+)
+
+// recorder is a testing.TB that records the first failure and stops the
+// calling goroutine, as testing.T does.
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// run executes check against a recorder on its own goroutine so Fatalf can
+// stop it, and returns the recorder.
+func run(t *testing.T, check func(tb testing.TB)) *recorder {
+	rec := &recorder{TB: t}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		check(rec)
+	}()
+	wg.Wait()
+	return rec
+}
+
+func newResult(status int, contentType, body string) *httpc.Result {
+	return &httpc.Result{Response: &httpc.ResponseInfo{
+		StatusCode: status,
+		Headers:    http.Header{"Content-Type": {contentType}},
+		Body:       body,
+		RawBody:    []byte(body),
+	}}
+}
+
+func TestExpect(t *testing.T) {
+	ok := newResult(http.StatusOK, "application/json; charset=utf-8", `{"id":7,"name":"ada"}`)
+
+	t.Run("Pass", func(t *testing.T) {
+		var user struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		rec := run(t, func(tb testing.TB) {
+			Expect(tb, ok).
+				Status(http.StatusOK).
+				Success().
+				Header("Content-Type", "application/json; charset=utf-8").
+				HeaderContains("Content-Type", "json").
+				BodyContains(`"ada"`).
+				JSON(&user)
+		})
+		if rec.failed {
+			t.Fatalf("Expected all checks to pass, got %s", rec.msg)
+		}
+		if user.ID != 7 || user.Name != "ada" {
+			t.Errorf("Expected decoded user, got %+v", user)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		result  *httpc.Result
+		check   func(a *Assertion)
+		wantMsg string
+	}{
+		{"Status", ok, func(a *Assertion) { a.Status(http.StatusCreated) }, "expected status 201, got 200"},
+		{"Success", newResult(http.StatusNotFound, "text/plain", "missing"), func(a *Assertion) { a.Success() }, "expected 2xx status, got 404"},
+		{"Header", ok, func(a *Assertion) { a.Header("Content-Type", "text/html") }, `expected header Content-Type: "text/html"`},
+		{"HeaderContains", ok, func(a *Assertion) { a.HeaderContains("X-Request-Id", "abc") }, `expected header X-Request-Id containing "abc", got ""`},
+		{"BodyContains", ok, func(a *Assertion) { a.BodyContains("grace") }, `expected body containing "grace"`},
+		{"JSON", newResult(http.StatusOK, "application/json", "not json"), func(a *Assertion) { a.JSON(&struct{}{}) }, "expected JSON body: invalid character"},
+		{"StopsAtFirstFailure", ok, func(a *Assertion) { a.Status(http.StatusAccepted).BodyContains("grace") }, "expected status 202"},
+	}
+	for _, tt := range tests {
+		t.Run("Fail/"+tt.name, func(t *testing.T) {
+			rec := run(t, func(tb testing.TB) { tt.check(Expect(tb, tt.result)) })
+			if !rec.failed {
+				t.Fatal("Expected check to fail")
+			}
+			if !strings.Contains(rec.msg, tt.wantMsg) {
+				t.Errorf("Expected message containing %q, got %q", tt.wantMsg, rec.msg)
+			}
+			if !strings.Contains(rec.msg, "response: ") {
+				t.Errorf("Expected message to quote the response, got %q", rec.msg)
+			}
+		})
+	}
+
+	t.Run("Fail/NilResult", func(t *testing.T) {
+		rec := run(t, func(tb testing.TB) { Expect(tb, nil) })
+		if !rec.failed || !strings.Contains(rec.msg, "expected a response") {
+			t.Errorf("Expected nil result to fail, got failed=%v %q", rec.failed, rec.msg)
+		}
+	})
+
+	t.Run("Fail/LongBodyTruncated", func(t *testing.T) {
+		long := newResult(http.StatusOK, "text/plain", strings.Repeat("x", 4*maxQuotedBody))
+		rec := run(t, func(tb testing.TB) { Expect(tb, long).Status(http.StatusNoContent) })
+		if len(rec.msg) > 2*maxQuotedBody || !strings.Contains(rec.msg, `..."`) {
+			t.Errorf("Expected truncated body in message, got %d bytes", len(rec.msg))
+		}
+	})
+}
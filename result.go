@@ -1,14 +1,23 @@
 package httpc
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"golang.org/x/text/encoding"
 )
 
 // resultBuilderPool reduces allocations for strings.Builder used in Result.String().
@@ -55,6 +64,11 @@ type Result struct {
 	Request  *RequestInfo
 	Response *ResponseInfo
 	Meta     *RequestMeta
+
+	// stream holds the unread body when the request used WithStreamBody(true).
+	// streamCtx is the request context that bounds reads from it.
+	stream    io.ReadCloser
+	streamCtx context.Context
 }
 
 // RequestInfo contains details about the HTTP request that was sent.
@@ -83,6 +97,10 @@ type ResponseInfo struct {
 	Body string
 	// RawBody is the raw response body bytes.
 	RawBody []byte
+	// CompressedRawBody is the body as received on the wire, before decompression.
+	// Only set when Connection.KeepCompressedBody is enabled and the response
+	// carried a Content-Encoding.
+	CompressedRawBody []byte
 	// ContentLength is the Content-Length from the response.
 	ContentLength int64
 	// Cookies contains the response cookies.
@@ -99,6 +117,86 @@ type RequestMeta struct {
 	RedirectChain []string
 	// RedirectCount is the number of redirects followed.
 	RedirectCount int
+	// RedirectHops records each followed redirect in order: the URL that
+	// redirected, where it pointed, the 3xx status code, and how long the
+	// hop took. Nil when no redirects were followed.
+	RedirectHops []RedirectHop
+	// TLS describes the negotiated TLS connection. Nil for plain HTTP
+	// and for responses served from the cache.
+	TLS *TLSInfo
+	// EarlyHints holds the headers of any 103 Early Hints responses received
+	// before the final response, such as "Link: </style.css>; rel=preload".
+	// Only captured when the request uses WithEarlyHints; nil otherwise.
+	EarlyHints http.Header
+	// BytesSent is the size of the final request as framed by HTTP/1.1:
+	// request line, header fields and body. BytesReceived is the same for
+	// the final response. Chunked framing and HTTP/2 header compression are
+	// not accounted for; for streamed responses BytesReceived covers only
+	// the status line and headers. Zero for responses served from the cache.
+	BytesSent     int64
+	BytesReceived int64
+	// ConnectionID identifies the connection the final request was sent on
+	// by its local and remote addresses, e.g. "127.0.0.1:52814->127.0.0.1:8080".
+	// Requests reporting the same ID shared a pooled connection. Empty for
+	// responses served from the cache.
+	ConnectionID string
+	// RetryHistory records each attempt that was retried, oldest first: its
+	// status code or error, and the delay before the next attempt. Its
+	// length is Attempts-1; nil when the first attempt was final.
+	RetryHistory []RetryAttempt
+	// Timing breaks the final request down into DNS lookup, connect, TLS
+	// handshake, time to first byte and total time, for finding where a
+	// slow request spends its time. Zero for responses served from the cache.
+	Timing Timing
+}
+
+// TLSInfo reports the negotiated parameters of a TLS connection,
+// for security auditing.
+type TLSInfo struct {
+	// Version is the TLS version, e.g. tls.VersionTLS13.
+	Version uint16
+	// CipherSuite is the negotiated cipher suite ID, e.g. tls.TLS_AES_128_GCM_SHA256.
+	CipherSuite uint16
+	// Resumed reports whether the session was resumed from a previous connection.
+	Resumed bool
+	// PeerSubjects holds the subject distinguished name of each certificate
+	// the server presented, leaf first, e.g. "CN=api.example.com,O=Example".
+	PeerSubjects []string
+}
+
+// VersionName returns the TLS version as a string, e.g. "TLS 1.3".
+func (t *TLSInfo) VersionName() string {
+	if t == nil {
+		return ""
+	}
+	return tls.VersionName(t.Version)
+}
+
+// CipherSuiteName returns the cipher suite name, e.g. "TLS_AES_128_GCM_SHA256".
+func (t *TLSInfo) CipherSuiteName() string {
+	if t == nil {
+		return ""
+	}
+	return tls.CipherSuiteName(t.CipherSuite)
+}
+
+// newTLSInfo extracts TLSInfo from a connection state. Returns nil if state is nil.
+func newTLSInfo(state *tls.ConnectionState) *TLSInfo {
+	if state == nil {
+		return nil
+	}
+	info := &TLSInfo{
+		Version:     state.Version,
+		CipherSuite: state.CipherSuite,
+		Resumed:     state.DidResume,
+	}
+	if len(state.PeerCertificates) > 0 {
+		info.PeerSubjects = make([]string, len(state.PeerCertificates))
+		for i, cert := range state.PeerCertificates {
+			info.PeerSubjects[i] = cert.Subject.String()
+		}
+	}
+	return info
 }
 
 // Body returns the response body as a string.
@@ -136,6 +234,66 @@ func (r *Result) Proto() string {
 	return r.Response.Proto
 }
 
+// Header returns the first value of the named response header. The name is
+// case-insensitive. Returns an empty string if the header is absent.
+func (r *Result) Header(name string) string {
+	if r == nil || r.Response == nil {
+		return ""
+	}
+	return r.Response.Headers.Get(name)
+}
+
+// HeaderValues returns all values of the named response header. The name is
+// case-insensitive. Returns nil if the header is absent.
+func (r *Result) HeaderValues(name string) []string {
+	if r == nil || r.Response == nil {
+		return nil
+	}
+	return r.Response.Headers.Values(name)
+}
+
+// ContentType returns the response's Content-Type header, including any
+// parameters such as charset. Returns an empty string if it is absent.
+func (r *Result) ContentType() string {
+	return r.Header("Content-Type")
+}
+
+// TLS returns the negotiated TLS connection details from Meta.TLS.
+// Returns nil for plain HTTP, cached responses, or a nil Result or Meta.
+func (r *Result) TLS() *TLSInfo {
+	if r == nil || r.Meta == nil {
+		return nil
+	}
+	return r.Meta.TLS
+}
+
+// Duration returns Meta.Duration, the total time the request took
+// including retries. Returns 0 if the Result or Meta is nil.
+func (r *Result) Duration() time.Duration {
+	if r == nil || r.Meta == nil {
+		return 0
+	}
+	return r.Meta.Duration
+}
+
+// Attempts returns Meta.Attempts, the number of attempts made including
+// retries. Returns 0 if the Result or Meta is nil.
+func (r *Result) Attempts() int {
+	if r == nil || r.Meta == nil {
+		return 0
+	}
+	return r.Meta.Attempts
+}
+
+// RedirectCount returns Meta.RedirectCount, the number of redirects
+// followed. Returns 0 if the Result or Meta is nil.
+func (r *Result) RedirectCount() int {
+	if r == nil || r.Meta == nil {
+		return 0
+	}
+	return r.Meta.RedirectCount
+}
+
 // RequestCookies returns the cookies that were sent with the request.
 // Returns nil if the Result or Request is nil.
 func (r *Result) RequestCookies() []*http.Cookie {
@@ -154,26 +312,302 @@ func (r *Result) ResponseCookies() []*http.Cookie {
 	return r.Response.Cookies
 }
 
+// Stream returns the unread response body when the request was made with
+// WithStreamBody(true), or nil otherwise. The body is not buffered: bytes are
+// read directly from the connection, still capped by MaxResponseBodySize.
+//
+// The caller owns the returned reader and must close it, or call
+// Result.Close, to release the connection and the request context; a
+// streamed Result that is neither closed nor decoded with Unmarshal, XML or
+// Into leaks both.
+//
+// Example:
+//
+//	result, err := client.Get(url, httpc.WithStreamBody(true))
+//	if err != nil {
+//	    return err
+//	}
+//	body := result.Stream()
+//	defer body.Close()
+//	_, err = io.Copy(dst, body)
+func (r *Result) Stream() io.ReadCloser {
+	if r == nil {
+		return nil
+	}
+	return r.stream
+}
+
+// maxCloseDrain bounds how much of an unread streamed body Close discards
+// to keep the connection reusable; larger remainders close the connection.
+const maxCloseDrain = 256 * 1024
+
+// Close releases a streamed body that was not consumed: up to 256KB of
+// unread data is discarded so the connection can be reused, then the body
+// and its request context are closed. It is safe to call more than once, on
+// a nil Result, and after Stream's reader was closed or the body decoded.
+// For buffered responses it does nothing.
+func (r *Result) Close() error {
+	if r == nil || r.stream == nil {
+		return nil
+	}
+	body := r.stream
+	r.stream = nil
+
+	ctx := r.streamCtx
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+	// Closing the body on cancellation unblocks a drain stalled by the
+	// server; a streamed body is safe to close mid-read.
+	stop := context.AfterFunc(ctx, func() { _ = body.Close() })
+	defer stop()
+	_, _ = io.CopyN(io.Discard, body, maxCloseDrain)
+	return body.Close()
+}
+
 // Unmarshal parses the JSON-encoded response body and stores the result
 // in the value pointed to by v. It follows the same conventions as json.Unmarshal.
+// A body in a non-UTF-8 charset declared by the Content-Type, e.g.
+// "application/json; charset=shift_jis", is transcoded to UTF-8 first.
+//
+// In streaming mode (WithStreamBody), the body is decoded directly from the
+// connection and closed afterwards. Decoding is bound to the request context:
+// if it is cancelled or its deadline passes, Unmarshal returns the context error.
 //
 // Returns ErrResponseBodyEmpty if the body is nil or empty.
 // Returns ErrResponseBodyTooLarge if the body exceeds 50MB.
+// Returns ErrUnexpectedHTML, with the start of the body, if decoding fails
+// on an HTML document, such as a login page served by an auth proxy.
 func (r *Result) Unmarshal(v any) error {
 	if r == nil || r.Response == nil {
 		return ErrResponseBodyEmpty
 	}
 
+	cs := r.charsetDecoder()
+	if r.stream != nil {
+		return r.decodeStream(v, cs, detectHTML(decodeJSON))
+	}
+
+	body, err := r.utf8Body(cs)
+	if err != nil {
+		return err
+	}
+	return htmlBodyError(body, json.Unmarshal(body, v))
+}
+
+// UnmarshalStrict is like Unmarshal but fails when the body contains an
+// object key that does not match a field of v, or data after the JSON value.
+// The error names the offending field, e.g. `json: unknown field "extra"`,
+// which makes it useful for catching API drift in tests and clients that
+// must not silently drop data.
+//
+// Returns ErrResponseBodyEmpty if the body is nil or empty.
+// Returns ErrResponseBodyTooLarge if the body exceeds 50MB.
+func (r *Result) UnmarshalStrict(v any) error {
+	if r == nil || r.Response == nil {
+		return ErrResponseBodyEmpty
+	}
+
+	cs := r.charsetDecoder()
+	if r.stream != nil {
+		return r.decodeStream(v, cs, detectHTML(decodeJSONStrict))
+	}
+
+	body, err := r.utf8Body(cs)
+	if err != nil {
+		return err
+	}
+	return htmlBodyError(body, decodeJSONStrict(bytes.NewReader(body), v))
+}
+
+// XML parses the XML-encoded response body and stores the result in the
+// value pointed to by v. It follows the same conventions as xml.Unmarshal and
+// handles streaming mode, context cancellation and size limits like Unmarshal.
+// The body is transcoded to UTF-8 from the charset in the Content-Type or,
+// failing that, the encoding in the XML declaration.
+//
+// Returns ErrResponseBodyEmpty if the body is nil or empty.
+// Returns ErrResponseBodyTooLarge if the body exceeds 50MB.
+func (r *Result) XML(v any) error {
+	if r == nil || r.Response == nil {
+		return ErrResponseBodyEmpty
+	}
+
+	cs := r.charsetDecoder()
+	decode := decodeXML
+	if cs != nil {
+		decode = decodeXMLTranscoded
+	}
+	if r.stream != nil {
+		return r.decodeStream(v, cs, decode)
+	}
+
+	body, err := r.utf8Body(cs)
+	if err != nil {
+		return err
+	}
+	return decode(bytes.NewReader(body), v)
+}
+
+// Into decodes the response body into v according to the response
+// Content-Type: application/json and "+json" types use Unmarshal;
+// application/xml, text/xml and "+xml" types use XML. A declared charset is
+// honored as described on those methods.
+//
+// Returns ErrUnsupportedContentType for any other or missing Content-Type,
+// plus the errors documented on Unmarshal and XML.
+func (r *Result) Into(v any) error {
+	if r == nil || r.Response == nil {
+		return ErrResponseBodyEmpty
+	}
+
+	ct := r.Response.Headers.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, ct)
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return r.Unmarshal(v)
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return r.XML(v)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentType, mediaType)
+	}
+}
+
+// htmlSnippetLen is the number of body bytes quoted by ErrUnexpectedHTML.
+const htmlSnippetLen = 120
+
+// htmlBodyError returns ErrUnexpectedHTML in place of err, a JSON decoding
+// error, when body starts like an HTML document. A nil err is returned as is.
+func htmlBodyError(body []byte, err error) error {
+	if err == nil {
+		return nil
+	}
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	head := bytes.ToLower(trimmed[:min(len(trimmed), len("<!doctype"))])
+	if !bytes.HasPrefix(head, []byte("<!doctype")) && !bytes.HasPrefix(head, []byte("<html")) {
+		return err
+	}
+	snippet := trimmed[:min(len(trimmed), htmlSnippetLen)]
+	return fmt.Errorf("%w (likely an auth or redirect page): body starts with %q",
+		ErrUnexpectedHTML, strings.ToValidUTF8(string(snippet), ""))
+}
+
+// detectHTML wraps a JSON decode func so that a failure on an HTML body
+// returns ErrUnexpectedHTML; see htmlBodyError. Only the first
+// htmlSnippetLen bytes read are kept.
+func detectHTML(decode func(io.Reader, any) error) func(io.Reader, any) error {
+	return func(src io.Reader, v any) error {
+		rec := &headRecorder{r: src}
+		return htmlBodyError(rec.head, decode(rec, v))
+	}
+}
+
+// headRecorder is a reader that keeps a copy of the first htmlSnippetLen
+// bytes read through it.
+type headRecorder struct {
+	r    io.Reader
+	head []byte
+}
+
+func (h *headRecorder) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if room := htmlSnippetLen - len(h.head); room > 0 {
+		h.head = append(h.head, p[:min(n, room)]...)
+	}
+	return n, err
+}
+
+// decodeJSON decodes a single JSON value from src into v.
+func decodeJSON(src io.Reader, v any) error {
+	return json.NewDecoder(src).Decode(v)
+}
+
+// utf8Body returns the buffered body, transcoded to UTF-8 by cs when it is
+// non-nil, after checking it is neither empty nor over the 50MB limit.
+func (r *Result) utf8Body(cs *encoding.Decoder) ([]byte, error) {
 	bodyLen := len(r.Response.RawBody)
 	if bodyLen == 0 {
-		return ErrResponseBodyEmpty
+		return nil, ErrResponseBodyEmpty
 	}
 
 	if bodyLen > maxJSONSize {
-		return fmt.Errorf("%w: %d bytes exceeds 50MB", ErrResponseBodyTooLarge, bodyLen)
+		return nil, fmt.Errorf("%w: %d bytes exceeds 50MB", ErrResponseBodyTooLarge, bodyLen)
 	}
 
-	return json.Unmarshal(r.Response.RawBody, v)
+	if cs == nil {
+		return r.Response.RawBody, nil
+	}
+	return cs.Bytes(r.Response.RawBody)
+}
+
+// decodeXML decodes a single XML element from src into v.
+func decodeXML(src io.Reader, v any) error {
+	dec := xml.NewDecoder(src)
+	dec.CharsetReader = xmlCharsetReader
+	return dec.Decode(v)
+}
+
+// decodeXMLTranscoded is decodeXML for a src already transcoded to UTF-8.
+func decodeXMLTranscoded(src io.Reader, v any) error {
+	dec := xml.NewDecoder(src)
+	dec.CharsetReader = utf8CharsetReader
+	return dec.Decode(v)
+}
+
+// decodeJSONStrict decodes a single JSON value from src into v, rejecting
+// unknown object keys and trailing data.
+func decodeJSONStrict(src io.Reader, v any) error {
+	dec := json.NewDecoder(src)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("json: unexpected data after top-level value")
+	}
+	return nil
+}
+
+// decodeStream decodes the streaming body with decode, transcoding it with
+// cs when non-nil, and aborts when the request context is done. Closing the
+// body on cancellation unblocks a pending Read from a server that trickles data.
+func (r *Result) decodeStream(v any, cs *encoding.Decoder, decode func(io.Reader, any) error) error {
+	body := r.stream
+	r.stream = nil
+	defer body.Close()
+
+	ctx := r.streamCtx
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+	stop := context.AfterFunc(ctx, func() { _ = body.Close() })
+	defer stop()
+
+	limited := &io.LimitedReader{R: body, N: maxJSONSize + 1}
+	var src io.Reader = limited
+	if cs != nil {
+		src = cs.Reader(src)
+	}
+	err := decode(src, v)
+	if limited.N == 0 {
+		// The byte past the limit was read: the body is too large, and
+		// any decode error is only a symptom of the truncation.
+		return ErrResponseBodyTooLarge
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err == io.EOF {
+			return ErrResponseBodyEmpty
+		}
+		return err
+	}
+	return nil
 }
 
 // statusInRange returns true if the response status code is in [lo, hi).
@@ -194,6 +628,29 @@ func (r *Result) IsRedirect() bool {
 	return r.statusInRange(300, 400)
 }
 
+// Location returns the response's Location header resolved against the URL
+// of the request that produced it, so relative locations such as "/next" or
+// "../other" become absolute. It is useful for 3xx responses when redirects
+// are not followed, and for 201 Created.
+// Returns http.ErrNoLocation if the header is absent.
+func (r *Result) Location() (*url.URL, error) {
+	if r == nil || r.Response == nil {
+		return nil, http.ErrNoLocation
+	}
+	loc := r.Response.Headers.Get("Location")
+	if loc == "" {
+		return nil, http.ErrNoLocation
+	}
+	if r.Request == nil || r.Request.URL == "" {
+		return url.Parse(loc)
+	}
+	base, err := url.Parse(r.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+	return base.Parse(loc)
+}
+
 // IsClientError returns true if the response status code indicates a client error (4xx).
 func (r *Result) IsClientError() bool {
 	return r.statusInRange(400, 500)
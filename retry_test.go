@@ -2,12 +2,19 @@ package httpc
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/cybergodev/httpc/internal/engine"
 )
 
 // ============================================================================
@@ -158,6 +165,448 @@ func TestRetry_StatusCodes(t *testing.T) {
 	})
 }
 
+// ----------------------------------------------------------------------------
+// Retryable Status Codes and Methods
+// ----------------------------------------------------------------------------
+
+func TestRetry_Policy(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		status           int
+		statusCodes      []int
+		methods          []string
+		opts             []RequestOption
+		expectedAttempts int32
+	}{
+		{"GETRetriedByDefault", http.MethodGet, http.StatusServiceUnavailable, nil, nil, nil, 3},
+		{"POSTNotRetriedByDefault", http.MethodPost, http.StatusServiceUnavailable, nil, nil, nil, 1},
+		{"PATCHNotRetriedByDefault", http.MethodPatch, http.StatusServiceUnavailable, nil, nil, nil, 1},
+		{"POSTRetriedWhenConfigured", http.MethodPost, http.StatusServiceUnavailable, nil, []string{"GET", "POST"}, nil, 3},
+		{"GETExcludedByConfig", http.MethodGet, http.StatusServiceUnavailable, nil, []string{"PUT"}, nil, 1},
+		{"CustomStatusRetried", http.MethodGet, http.StatusTeapot, []int{http.StatusTeapot}, nil, nil, 3},
+		{"DefaultStatusNotInCustomList", http.MethodGet, http.StatusServiceUnavailable, []int{http.StatusTeapot}, nil, nil, 1},
+		{"PerRequestMethodOverride", http.MethodPost, http.StatusServiceUnavailable, nil, nil,
+			[]RequestOption{WithRetryPolicy(nil, []string{"POST"})}, 3},
+		{"PerRequestStatusOverride", http.MethodGet, http.StatusServiceUnavailable, nil, nil,
+			[]RequestOption{WithRetryPolicy([]int{http.StatusBadGateway}, nil)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			config := testConfig()
+			config.Retry.MaxRetries = 2
+			config.Retry.Delay = time.Millisecond
+			config.Retry.RetryableStatusCodes = tt.statusCodes
+			config.Retry.RetryableMethods = tt.methods
+			client, err := New(config)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			result, err := client.Request(context.Background(), tt.method, server.URL, tt.opts...)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if result.StatusCode() != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, result.StatusCode())
+			}
+			if got := attempts.Load(); got != tt.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.expectedAttempts, got)
+			}
+		})
+	}
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		config := testConfig()
+		config.Retry.RetryableStatusCodes = []int{42}
+		if _, err := New(config); !errors.Is(err, ErrInvalidRetry) {
+			t.Errorf("Expected ErrInvalidRetry for status 42, got %v", err)
+		}
+		if err := WithRetryPolicy(nil, []string{"BAD METHOD"})(nil); !errors.Is(err, ErrInvalidRetry) {
+			t.Errorf("Expected ErrInvalidRetry for invalid method, got %v", err)
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// RetryIf Callback
+// ----------------------------------------------------------------------------
+
+func TestRetry_RetryIf(t *testing.T) {
+	pending := func(resp ResponseReader, err error, attempt int) bool {
+		return err == nil && strings.Contains(resp.Body(), `"status":"pending"`)
+	}
+
+	newPollServer := func(readyAfter int32) (*httptest.Server, *atomic.Int32) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if attempts.Add(1) < readyAfter {
+				w.Write([]byte(`{"status":"pending"}`))
+				return
+			}
+			w.Write([]byte(`{"status":"done"}`))
+		}))
+		return server, &attempts
+	}
+
+	newClient := func(t *testing.T, retryIf RetryIfFunc) Client {
+		t.Helper()
+		config := testConfig()
+		config.Retry.MaxRetries = 3
+		config.Retry.Delay = time.Millisecond
+		config.Retry.RetryIf = retryIf
+		client, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client
+	}
+
+	t.Run("ConfigRetriesSuccessfulResponse", func(t *testing.T) {
+		server, attempts := newPollServer(3)
+		defer server.Close()
+		client := newClient(t, pending)
+		defer client.Close()
+
+		result, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if !strings.Contains(result.Body(), "done") {
+			t.Errorf("Expected final done response, got %s", result.Body())
+		}
+		if got := attempts.Load(); got != 3 || result.Meta.Attempts != 3 {
+			t.Errorf("Expected 3 attempts, server saw %d, meta %d", got, result.Meta.Attempts)
+		}
+	})
+
+	t.Run("BoundedByMaxRetries", func(t *testing.T) {
+		server, attempts := newPollServer(100)
+		defer server.Close()
+		client := newClient(t, pending)
+		defer client.Close()
+
+		result, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if !strings.Contains(result.Body(), "pending") {
+			t.Errorf("Expected last pending response, got %s", result.Body())
+		}
+		if got := attempts.Load(); got != 4 {
+			t.Errorf("Expected 4 attempts (1 + MaxRetries), got %d", got)
+		}
+	})
+
+	t.Run("PerRequestOverridesConfig", func(t *testing.T) {
+		server, attempts := newPollServer(2)
+		defer server.Close()
+		client := newClient(t, func(ResponseReader, error, int) bool { return false })
+		defer client.Close()
+
+		if _, err := client.Get(server.URL, WithRetryIf(pending)); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got := attempts.Load(); got != 2 {
+			t.Errorf("Expected 2 attempts, got %d", got)
+		}
+	})
+
+	t.Run("OverridesBuiltInStatusRetry", func(t *testing.T) {
+		var attempts atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		client := newClient(t, pending)
+		defer client.Close()
+
+		if _, err := client.Get(server.URL); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if got := attempts.Load(); got != 1 {
+			t.Errorf("Expected 1 attempt when RetryIf declines, got %d", got)
+		}
+	})
+
+	t.Run("HonorsContextCancellation", func(t *testing.T) {
+		server, attempts := newPollServer(100)
+		defer server.Close()
+		config := testConfig()
+		config.Retry.MaxRetries = 10
+		config.Retry.Delay = 50 * time.Millisecond
+		config.Retry.EnableJitter = false
+		client, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		defer client.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 80*time.Millisecond)
+		defer cancel()
+		_, err = client.Request(ctx, http.MethodGet, server.URL, WithRetryIf(func(ResponseReader, error, int) bool { return true }))
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context deadline error, got %v", err)
+		}
+		if got := attempts.Load(); got >= 10 {
+			t.Errorf("Expected retries to stop at cancellation, got %d attempts", got)
+		}
+	})
+
+	t.Run("NilCallback", func(t *testing.T) {
+		if err := WithRetryIf(nil)(nil); err == nil {
+			t.Error("Expected error for nil callback")
+		}
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Retry History
+// ----------------------------------------------------------------------------
+
+func TestRetry_JSONField(t *testing.T) {
+	newClient := func(t *testing.T, field string, value any) Client {
+		t.Helper()
+		config := testConfig()
+		config.Retry.MaxRetries = 3
+		config.Retry.Delay = time.Millisecond
+		config.Retry.RetryJSONField = field
+		config.Retry.RetryJSONValue = value
+		client, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		return client
+	}
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if attempts.Add(1) == 1 {
+			w.Write([]byte(`{"status":"error","retryable":true,"error":{"code":503}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		field        string
+		value        any
+		wantAttempts int32
+	}{
+		{"TrueWithoutValue", "retryable", nil, 2},
+		{"StringValue", "status", "error", 2},
+		{"NestedNumber", "error.code", 503, 2},
+		{"ValueMismatch", "status", "pending", 1},
+		{"MissingField", "error.reason", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts.Store(0)
+			client := newClient(t, tt.field, tt.value)
+			defer client.Close()
+
+			result, err := client.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+			wantBody := "ok"
+			if tt.wantAttempts == 1 {
+				wantBody = "error"
+			}
+			if !strings.Contains(result.Body(), wantBody) {
+				t.Errorf("Expected body containing %q, got %s", wantBody, result.Body())
+			}
+		})
+	}
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		config := testConfig()
+		config.Retry.RetryJSONField = "status"
+		config.Retry.RetryJSONValue = func() {}
+		if _, err := New(config); !errors.Is(err, ErrInvalidRetry) {
+			t.Errorf("Expected ErrInvalidRetry, got %v", err)
+		}
+	})
+}
+
+func TestRetry_ReplaysBody(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(b))
+		n := len(bodies)
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Retry.MaxRetries = 2
+	config.Retry.Delay = time.Millisecond
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	payload := strings.Repeat("payload-", 1000)
+	result, err := client.Post(server.URL, WithBody(strings.NewReader(payload)), WithRetryPolicy(nil, []string{"POST"}))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.StatusCode() != http.StatusOK || len(bodies) != 2 {
+		t.Fatalf("Expected success on the second attempt, got %d after %d attempts", result.StatusCode(), len(bodies))
+	}
+	for i, b := range bodies {
+		if b != payload {
+			t.Errorf("Attempt %d sent %d bytes, want the full %d", i+1, len(b), len(payload))
+		}
+	}
+
+	// A 307 redirect resends an in-memory body to the new location.
+	result, err = client.Post(server.URL+"/moved", WithBody(payload), WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.StatusCode() != http.StatusOK || bodies[len(bodies)-1] != payload {
+		t.Errorf("Expected the redirected request to carry the body, got %d", result.StatusCode())
+	}
+}
+
+func TestRetry_IdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		n := len(keys)
+		mu.Unlock()
+		if n%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Retry.MaxRetries = 3
+	config.Retry.Delay = time.Millisecond
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	uuidRe := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for _, key := range []string{"order-42", "", ""} {
+		mu.Lock()
+		keys = nil
+		mu.Unlock()
+		if _, err := client.Post(server.URL, WithBody("{}"), WithIdempotencyKey(key),
+			WithRetryPolicy(nil, []string{"POST"})); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		mu.Lock()
+		got := slices.Clone(keys)
+		mu.Unlock()
+		if len(got) != 3 || got[0] != got[1] || got[1] != got[2] {
+			t.Fatalf("Expected the same key on 3 attempts, got %q", got)
+		}
+		if key != "" && got[0] != key {
+			t.Errorf("Expected key %q, got %q", key, got[0])
+		}
+		if key == "" && !uuidRe.MatchString(got[0]) {
+			t.Errorf("Expected a generated UUID, got %q", got[0])
+		}
+	}
+
+	if err := WithIdempotencyKey("bad\r\nkey")(&engine.Request{}); err == nil {
+		t.Error("Expected error for key with CRLF")
+	}
+}
+
+func TestRetry_History(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Retry.MaxRetries = 3
+	config.Retry.Delay = 5 * time.Millisecond
+	config.Retry.EnableJitter = false
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	result, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.Attempts != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", result.Meta.Attempts)
+	}
+	if result.Attempts() != result.Meta.Attempts || result.Duration() != result.Meta.Duration ||
+		result.RedirectCount() != result.Meta.RedirectCount || result.Duration() <= 0 {
+		t.Errorf("Accessors disagree with Meta %+v: Attempts=%d Duration=%v RedirectCount=%d",
+			result.Meta, result.Attempts(), result.Duration(), result.RedirectCount())
+	}
+	history := result.Meta.RetryHistory
+	if len(history) != result.Meta.Attempts-1 {
+		t.Fatalf("Expected %d history entries, got %d: %+v", result.Meta.Attempts-1, len(history), history)
+	}
+	want := []RetryAttempt{
+		{StatusCode: http.StatusServiceUnavailable, Delay: 5 * time.Millisecond},
+		{StatusCode: http.StatusBadGateway, Delay: 10 * time.Millisecond},
+	}
+	for i, w := range want {
+		if history[i] != w {
+			t.Errorf("history[%d] = %+v, want %+v", i, history[i], w)
+		}
+	}
+
+	result, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if result.Meta.RetryHistory != nil {
+		t.Errorf("Expected nil history without retries, got %+v", result.Meta.RetryHistory)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Backoff Behavior
 // ----------------------------------------------------------------------------
@@ -218,6 +667,79 @@ func TestRetry_Backoff(t *testing.T) {
 	}
 }
 
+// fakeClock is a Clock whose After fires immediately, advancing Now by the
+// requested duration and recording it.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRetry_FakeClock(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 3 {
+			// An HTTP-date is measured against the clock, not the wall time.
+			w.Header().Set("Retry-After", clock.Now().Add(5*time.Second).Format(http.TimeFormat))
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.Clock = clock
+	cfg.Retry.MaxRetries = 3
+	cfg.Retry.Delay = 10 * time.Second
+	cfg.Retry.BackoffFactor = 2.0
+	cfg.Retry.EnableJitter = false
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	start := time.Now()
+	result, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected no real sleeping, took %v", elapsed)
+	}
+
+	want := []time.Duration{10 * time.Second, 20 * time.Second, 5 * time.Second}
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	if len(clock.sleeps) != len(want) {
+		t.Fatalf("Expected backoff delays %v, got %v", want, clock.sleeps)
+	}
+	for i, d := range want {
+		if clock.sleeps[i] != d {
+			t.Errorf("Delay %d: expected %v, got %v", i+1, d, clock.sleeps[i])
+		}
+	}
+	if result.Meta.Duration != 35*time.Second {
+		t.Errorf("Expected Duration measured on the clock (35s), got %v", result.Meta.Duration)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Context Cancellation
 // ----------------------------------------------------------------------------
@@ -270,6 +792,121 @@ func TestRetry_ContextCancellation(t *testing.T) {
 	t.Logf("Request cancelled after %d attempts in %v", attempts, duration)
 }
 
+func TestRetry_Deadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Retry.MaxRetries = 5
+	config.Retry.Delay = 200 * time.Millisecond
+	config.Retry.BackoffFactor = 1
+	config.Retry.EnableJitter = false
+	client, _ := New(config)
+	defer client.Close()
+
+	// Five 200ms delays would take 1s; the deadline allows 300ms.
+	start := time.Now()
+	deadline := start.Add(300 * time.Millisecond)
+	_, err := client.Get(server.URL, WithDeadline(deadline))
+	elapsed := time.Since(start)
+	if err == nil {
+		t.Fatal("Expected the deadline to abort the retries")
+	}
+	if elapsed < 250*time.Millisecond || elapsed > 600*time.Millisecond {
+		t.Errorf("Expected the request to stop at the deadline (~300ms), took %v", elapsed)
+	}
+	if n := attempts.Load(); n < 2 || n > 3 {
+		t.Errorf("Expected 2-3 attempts before the deadline, got %d", n)
+	}
+
+	if _, err := client.Get(server.URL, WithDeadline(time.Now().Add(-time.Second))); err == nil {
+		t.Error("Expected a passed deadline to fail at once")
+	}
+	if _, err := client.Get(server.URL, WithDeadline(time.Time{})); !errors.Is(err, ErrInvalidTimeout) {
+		t.Errorf("Expected ErrInvalidTimeout for a zero deadline, got %v", err)
+	}
+
+	config.Middleware.Middlewares = []MiddlewareFunc{func(next Handler) Handler { return next }}
+	mwClient, _ := New(config)
+	defer mwClient.Close()
+	start = time.Now()
+	if _, err := mwClient.Get(server.URL, WithDeadline(start.Add(300*time.Millisecond))); err == nil {
+		t.Fatal("Expected the deadline to abort the retries with middleware configured")
+	}
+	if elapsed := time.Since(start); elapsed > 600*time.Millisecond {
+		t.Errorf("Expected the middleware request to stop at the deadline (~300ms), took %v", elapsed)
+	}
+}
+
+func TestRetry_Budget(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := testConfig()
+	config.Retry.MaxRetries = 10
+	config.Retry.Delay = 100 * time.Millisecond
+	config.Retry.BackoffFactor = 1
+	config.Retry.EnableJitter = false
+	config.Retry.Budget = 350 * time.Millisecond
+	client, _ := New(config)
+	defer client.Close()
+
+	// Retries start at ~100ms, ~200ms and ~300ms; a fourth would end past
+	// the budget, so the last 503 is returned.
+	start := time.Now()
+	result, err := client.Get(server.URL)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("Expected the last response, got %v", err)
+	}
+	if elapsed >= config.Retry.Budget {
+		t.Errorf("Expected to finish within the %v budget, took %v", config.Retry.Budget, elapsed)
+	}
+	if result.StatusCode() != http.StatusServiceUnavailable || attempts.Load() != 4 || result.Attempts() != 4 {
+		t.Errorf("Expected 4 attempts ending in 503, got %d server attempts, %d reported, status %d",
+			attempts.Load(), result.Attempts(), result.StatusCode())
+	}
+
+	// Each slow attempt fits the per-attempt timeout, but the second one
+	// would end past the budget, so it is cut off.
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(150 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer slow.Close()
+	slowConfig := testConfig()
+	slowConfig.Timeouts.Request = time.Second
+	slowConfig.Retry.MaxRetries = 3
+	slowConfig.Retry.Delay = 10 * time.Millisecond
+	slowConfig.Retry.EnableJitter = false
+	slowConfig.Retry.Budget = 250 * time.Millisecond
+	slowClient, _ := New(slowConfig)
+	defer slowClient.Close()
+	start = time.Now()
+	if _, err := slowClient.Get(slow.URL); err == nil {
+		t.Error("Expected the budget to cut off the running attempt")
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("Expected the request to stop at the %v budget, took %v", slowConfig.Retry.Budget, elapsed)
+	}
+
+	config.Retry.Budget = -time.Second
+	if _, err := New(config); !errors.Is(err, ErrInvalidRetry) {
+		t.Errorf("Expected ErrInvalidRetry for a negative budget, got %v", err)
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Retry-After Header
 // ----------------------------------------------------------------------------
@@ -330,3 +967,126 @@ func TestRetry_RetryAfterHeader(t *testing.T) {
 
 	t.Logf("Request completed in %v with %d attempts", duration, resp.Meta.Attempts)
 }
+
+func TestRetry_RetryAfterAnyStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		opts   []RequestOption
+	}{
+		{"config status", http.StatusRequestEntityTooLarge, nil},
+		{"per-request status", http.StatusMovedPermanently, []RequestOption{
+			WithRetryPolicy([]int{http.StatusMovedPermanently}, nil),
+			WithFollowRedirects(false),
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", "7")
+					w.WriteHeader(tc.status)
+					return
+				}
+				_, _ = w.Write([]byte("ok"))
+			}))
+			defer server.Close()
+
+			clock := &fakeClock{now: time.Now()}
+			cfg := testConfig()
+			cfg.Clock = clock
+			cfg.Retry.MaxRetries = 1
+			cfg.Retry.Delay = time.Second
+			cfg.Retry.RetryableStatusCodes = []int{http.StatusRequestEntityTooLarge}
+			client, err := New(cfg)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			defer client.Close()
+
+			result, err := client.Get(server.URL, tc.opts...)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if result.StatusCode() != http.StatusOK {
+				t.Fatalf("Expected the retry to succeed, got %d", result.StatusCode())
+			}
+			if h := result.Meta.RetryHistory; len(h) != 1 || h[0].StatusCode != tc.status || h[0].Delay != 7*time.Second {
+				t.Errorf("Expected one %d retry delayed by Retry-After (7s), got %+v", tc.status, h)
+			}
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Tracer Callbacks
+// ----------------------------------------------------------------------------
+
+type recordingTracer struct {
+	mu         sync.Mutex
+	starts     []string
+	retries    []int
+	finishes   int
+	lastStatus int
+	lastErr    error
+}
+
+func (r *recordingTracer) OnStart(method, url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.starts = append(r.starts, method+" "+url)
+}
+
+func (r *recordingTracer) OnRetry(attempt int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries = append(r.retries, attempt)
+}
+
+func (r *recordingTracer) OnFinish(statusCode int, err error, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finishes++
+	r.lastStatus = statusCode
+	r.lastErr = err
+}
+
+func TestRetry_Tracer(t *testing.T) {
+	var attemptCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attemptCount, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	config := testConfig()
+	config.Retry.MaxRetries = 3
+	config.Retry.Delay = 5 * time.Millisecond
+	config.Retry.EnableJitter = false
+	config.Middleware.Tracer = tracer
+	client, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Get(server.URL); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	if len(tracer.starts) != 1 || tracer.starts[0] != "GET "+server.URL {
+		t.Errorf("OnStart calls = %v", tracer.starts)
+	}
+	if len(tracer.retries) != 2 || tracer.retries[0] != 1 || tracer.retries[1] != 2 {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", tracer.retries)
+	}
+	if tracer.finishes != 1 || tracer.lastStatus != http.StatusOK || tracer.lastErr != nil {
+		t.Errorf("OnFinish calls = %d, status = %d, err = %v", tracer.finishes, tracer.lastStatus, tracer.lastErr)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -72,6 +73,50 @@ func Test_SSRF_RedirectProtection(t *testing.T) {
 	}
 }
 
+// Test_SSRF_PerRequestOverride verifies that WithAllowPrivateIP lifts SSRF
+// protection for one request without weakening later ones.
+func Test_SSRF_PerRequestOverride(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Security.AllowPrivateIPs = false
+
+	client, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var hopClosed atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			http.Redirect(w, r, "/ready", http.StatusFound)
+			return
+		}
+		hopClosed.Store(r.Close)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if _, err := client.Get(server.URL+"/health", WithTimeout(5*time.Second)); err == nil {
+		t.Fatal("SECURITY ISSUE: Expected private IP to be blocked by default")
+	}
+
+	resp, err := client.Get(server.URL+"/health", WithAllowPrivateIP(), WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("Expected override to permit private IP, got: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK || resp.Meta.RedirectCount != 1 {
+		t.Errorf("Expected 200 after one redirect, got %d after %d", resp.StatusCode(), resp.Meta.RedirectCount)
+	}
+	if !hopClosed.Load() {
+		t.Error("SECURITY ISSUE: Expected the redirect hop's connection to be closed, not pooled")
+	}
+
+	// Neither the validated URL nor the connection carries over.
+	if _, err := client.Get(server.URL+"/health", WithTimeout(5*time.Second)); err == nil {
+		t.Error("SECURITY ISSUE: Override leaked into a later request")
+	}
+}
+
 // Test_SSRF_BlocksIPv6Localhost verifies that IPv6 localhost addresses are also blocked
 func Test_SSRF_BlocksIPv6Localhost(t *testing.T) {
 	if testing.Short() {
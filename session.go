@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"maps"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 
 	"github.com/cybergodev/httpc/internal/engine"
@@ -134,6 +136,62 @@ func (s *SessionManager) ClearHeaders() {
 	s.headers = make(map[string]string)
 }
 
+// SetBasicAuth sets the session's Authorization header to Basic
+// authentication with username and password, replacing any previous
+// Authorization header. It is sent with every request until ClearAuth.
+// Returns an error if the credentials are invalid, as with WithBasicAuth.
+func (s *SessionManager) SetBasicAuth(username, password string) error {
+	if s == nil {
+		return fmt.Errorf("session manager is nil")
+	}
+	value, err := basicAuthValue(username, password)
+	if err != nil {
+		return err
+	}
+	s.setAuthorization(value)
+	return nil
+}
+
+// SetBearerToken sets the session's Authorization header to
+// "Bearer <token>", replacing any previous Authorization header. It is sent
+// with every request until ClearAuth.
+// Returns an error if token is empty or invalid, as with WithBearerToken.
+func (s *SessionManager) SetBearerToken(token string) error {
+	if s == nil {
+		return fmt.Errorf("session manager is nil")
+	}
+	value, err := bearerAuthValue(token)
+	if err != nil {
+		return err
+	}
+	s.setAuthorization(value)
+	return nil
+}
+
+// ClearAuth removes the session's Authorization header, however it was set.
+func (s *SessionManager) ClearAuth() {
+	if s == nil {
+		return
+	}
+	s.setAuthorization("")
+}
+
+// setAuthorization replaces the Authorization header, in any letter case,
+// with value, or removes it when value is empty.
+func (s *SessionManager) setAuthorization(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.headers {
+		if strings.EqualFold(k, "Authorization") {
+			delete(s.headers, k)
+		}
+	}
+	if value != "" {
+		s.headers["Authorization"] = value
+	}
+}
+
 // GetHeaders returns a copy of all session headers.
 func (s *SessionManager) GetHeaders() map[string]string {
 	if s == nil {
@@ -148,6 +206,8 @@ func (s *SessionManager) GetHeaders() map[string]string {
 }
 
 // SetCookie adds or updates a cookie in the session.
+// A cookie with a Domain is only sent to matching hosts: ".example.com"
+// covers the domain and its subdomains, "example.com" that host only.
 // Returns an error if the cookie is nil or invalid.
 // If cookie security is configured, validates against security requirements.
 func (s *SessionManager) SetCookie(cookie *http.Cookie) error {
@@ -207,6 +267,25 @@ func (s *SessionManager) SetCookies(cookies []*http.Cookie) error {
 	return nil
 }
 
+// LoadCookiesNetscape imports all cookies from a Netscape-format cookie file
+// (as exported by browsers or curl) into the session. Expired entries are skipped.
+// Each cookie keeps its domain scope and is only sent to matching hosts:
+// host-only entries (include-subdomains FALSE) to that exact host. See
+// ParseNetscapeCookies for the file format.
+//
+// Returns an error if the file cannot be read or parsed, or if any cookie fails
+// validation; in that case no cookies are stored.
+func (s *SessionManager) LoadCookiesNetscape(path string) error {
+	if s == nil {
+		return fmt.Errorf("session manager is nil")
+	}
+	cookies, err := readNetscapeCookieFile(path)
+	if err != nil {
+		return err
+	}
+	return s.SetCookies(cookies)
+}
+
 // DeleteCookie removes a cookie from the session by name.
 func (s *SessionManager) DeleteCookie(name string) {
 	if s == nil {
@@ -272,6 +351,7 @@ func (s *SessionManager) GetCookie(name string) *http.Cookie {
 
 // prepareOptions creates RequestOptions from the current session state.
 // This is used internally by DomainClient to apply session cookies and headers to outgoing requests.
+// Cookies with a Domain are only sent to hosts it matches (see cookieDomainMatches).
 func (s *SessionManager) prepareOptions() []RequestOption {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -292,8 +372,17 @@ func (s *SessionManager) prepareOptions() []RequestOption {
 			cookies = append(cookies, *cookie)
 		}
 		options = append(options, func(r *engine.Request) error {
+			host := ""
+			if u, err := url.Parse(r.URL()); err == nil {
+				host = u.Hostname()
+			}
 			existing := r.Cookies()
-			r.SetCookies(append(existing, cookies...))
+			for _, c := range cookies {
+				if host == "" || cookieDomainMatches(c.Domain, host) {
+					existing = append(existing, c)
+				}
+			}
+			r.SetCookies(existing)
 			return nil
 		})
 	}
@@ -363,6 +452,11 @@ func (s *SessionManager) storeCookies(cookies []*http.Cookie) {
 			continue
 		}
 		cp := *cookie
+		if cp.Domain != "" && !strings.HasPrefix(cp.Domain, ".") {
+			// A Set-Cookie Domain attribute always covers subdomains
+			// (RFC 6265 section 5.2.3); a bare domain means host-only here.
+			cp.Domain = "." + cp.Domain
+		}
 		s.cookies[cp.Name] = &cp
 	}
 }
@@ -286,6 +286,8 @@ func TestSessionManager_prepareOptions(t *testing.T) {
 
 	// Add cookies and headers
 	_ = session.SetCookie(&http.Cookie{Name: "session", Value: "abc123"})
+	_ = session.SetCookie(&http.Cookie{Name: "local", Value: "1", Domain: "127.0.0.1"})
+	_ = session.SetCookie(&http.Cookie{Name: "foreign", Value: "1", Domain: "sub.127.0.0.1"})
 	_ = session.SetHeader("Authorization", "Bearer token")
 
 	options = session.prepareOptions()
@@ -302,6 +304,12 @@ func TestSessionManager_prepareOptions(t *testing.T) {
 		if err != nil || cookie.Value != "abc123" {
 			t.Errorf("Session cookie not applied: %v", err)
 		}
+		if _, err := r.Cookie("local"); err != nil {
+			t.Error("Cookie scoped to the request host not applied")
+		}
+		if _, err := r.Cookie("foreign"); err == nil {
+			t.Error("Cookie scoped to another host should not be sent")
+		}
 		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
@@ -0,0 +1,195 @@
+package httpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// sseContentType is the media type of a Server-Sent Events stream.
+	sseContentType = "text/event-stream"
+	// maxSSELineSize caps a single line of an event stream to bound memory use.
+	maxSSELineSize = 1024 * 1024 // 1MB
+)
+
+// SSEEvent is a single event parsed from a text/event-stream response.
+type SSEEvent struct {
+	// Event is the event type ("event:" field). Empty means the default "message" type.
+	Event string
+	// Data is the event payload. Multiple "data:" lines are joined with "\n".
+	Data string
+	// ID is the last event ID seen on the stream ("id:" field). Per the SSE
+	// specification it persists across events until the server changes it.
+	ID string
+	// Retry is the reconnection delay requested by the server ("retry:" field),
+	// or 0 if the event did not carry one.
+	Retry time.Duration
+}
+
+// SSEHandler is called for each event received on the stream.
+// Returning an error stops consumption and is returned by StreamEvents.
+type SSEHandler func(event SSEEvent) error
+
+// StreamEvents issues a GET request for a Server-Sent Events stream and invokes
+// handler for each event until the stream ends, ctx is done, or handler
+// returns an error. The response body is streamed, never buffered.
+//
+// It returns the last event ID seen, which callers can send back in a
+// Last-Event-ID header when reconnecting:
+//
+//	lastID, err := httpc.StreamEvents(ctx, client, url, func(ev httpc.SSEEvent) error {
+//	    fmt.Println(ev.Event, ev.Data)
+//	    return nil
+//	})
+//	// reconnect later
+//	lastID, err = httpc.StreamEvents(ctx, client, url, handler,
+//	    httpc.WithHeader("Last-Event-ID", lastID))
+//
+// The client's request timeout bounds the whole stream; use WithTimeout for
+// long-lived streams.
+//
+// Returns ctx.Err() when the context is cancelled mid-stream, an error for
+// non-2xx statuses or a Content-Type other than text/event-stream, or the
+// handler's error.
+func StreamEvents(ctx context.Context, doer Doer, url string, handler SSEHandler, options ...RequestOption) (string, error) {
+	if doer == nil {
+		return "", fmt.Errorf("client cannot be nil")
+	}
+	if handler == nil {
+		return "", fmt.Errorf("SSE handler cannot be nil")
+	}
+	if ctx == nil {
+		ctx = backgroundCtx
+	}
+
+	opts := make([]RequestOption, 0, len(options)+3)
+	opts = append(opts,
+		WithHeader("Accept", sseContentType),
+		WithHeader("Cache-Control", "no-cache"),
+	)
+	opts = append(opts, options...)
+	opts = append(opts, WithStreamBody(true))
+
+	result, err := doer.Request(ctx, "GET", url, opts...)
+	if err != nil {
+		return "", err
+	}
+	body := result.Stream()
+	if body == nil {
+		return "", fmt.Errorf("SSE response body is not streamed")
+	}
+	defer body.Close()
+
+	if !result.IsSuccess() {
+		return "", fmt.Errorf("SSE request failed: HTTP %d", result.StatusCode())
+	}
+	if ct := result.Response.Headers.Get("Content-Type"); !strings.HasPrefix(strings.ToLower(ct), sseContentType) {
+		return "", fmt.Errorf("unexpected SSE content type %q", ct)
+	}
+
+	// Closing the body when ctx is cancelled closes the connection under a
+	// pending Read and unblocks it; a streamed body is safe to close mid-read.
+	stop := context.AfterFunc(ctx, func() { _ = body.Close() })
+	defer stop()
+
+	lastID, err := readSSE(body, handler)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return lastID, ctxErr
+	}
+	return lastID, err
+}
+
+// readSSE parses an event stream from r, dispatching each complete event to
+// handler. Returns the last event ID seen.
+func readSSE(r io.Reader, handler SSEHandler) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxSSELineSize)
+	scanner.Split(scanSSELines)
+
+	var (
+		lastID  string
+		event   string
+		data    strings.Builder
+		hasData bool
+		retry   time.Duration
+	)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A blank line dispatches the buffered event.
+		if line == "" {
+			if hasData {
+				ev := SSEEvent{Event: event, Data: data.String(), ID: lastID, Retry: retry}
+				if err := handler(ev); err != nil {
+					return lastID, err
+				}
+			}
+			event, hasData, retry = "", false, 0
+			data.Reset()
+			continue
+		}
+		if line[0] == ':' {
+			continue // comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				lastID = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 32); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	// An incomplete event at end of stream is discarded per the SSE specification.
+	return lastID, scanner.Err()
+}
+
+// scanSSELines is a bufio.SplitFunc that splits on "\r\n", "\n", or "\r",
+// the three line terminators allowed in an event stream.
+func scanSSELines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		// '\r': need one more byte to tell "\r" from "\r\n".
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
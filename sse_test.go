@@ -0,0 +1,177 @@
+package httpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// SERVER-SENT EVENTS TESTS
+// ============================================================================
+
+func TestReadSSE(t *testing.T) {
+	t.Parallel()
+
+	stream := ": keep-alive\n" +
+		"event: greeting\n" +
+		"id: 1\n" +
+		"data: hello\n" +
+		"\n" +
+		"data: line1\r\n" +
+		"data:line2\r\n" +
+		"retry: 1500\r\n" +
+		"\r\n" +
+		"id: 2\r" +
+		"data: cr only\r" +
+		"\r" +
+		"event: ignored\n" +
+		"\n" +
+		"data: incomplete"
+
+	var events []SSEEvent
+	lastID, err := readSSE(strings.NewReader(stream), func(ev SSEEvent) error {
+		events = append(events, ev)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("readSSE failed: %v", err)
+	}
+	if lastID != "2" {
+		t.Errorf("lastID = %q, want %q", lastID, "2")
+	}
+
+	want := []SSEEvent{
+		{Event: "greeting", Data: "hello", ID: "1"},
+		{Data: "line1\nline2", ID: "1", Retry: 1500 * time.Millisecond},
+		{Data: "cr only", ID: "2"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, events[i], want[i])
+		}
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dispatches events and returns last id", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") != "text/event-stream" {
+				t.Errorf("Accept = %q", r.Header.Get("Accept"))
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: a\ndata: one\n\nid: b\ndata: two\n\n")
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		var got []string
+		lastID, err := StreamEvents(context.Background(), client, server.URL, func(ev SSEEvent) error {
+			got = append(got, ev.Data)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("StreamEvents failed: %v", err)
+		}
+		if lastID != "b" || strings.Join(got, ",") != "one,two" {
+			t.Errorf("lastID = %q, events = %v", lastID, got)
+		}
+	})
+
+	t.Run("handler error stops stream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 1\ndata: one\n\nid: 2\ndata: two\n\n")
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		stopErr := errors.New("stop")
+		calls := 0
+		lastID, err := StreamEvents(context.Background(), client, server.URL, func(ev SSEEvent) error {
+			calls++
+			return stopErr
+		})
+		if !errors.Is(err, stopErr) || calls != 1 || lastID != "1" {
+			t.Errorf("err = %v, calls = %d, lastID = %q", err, calls, lastID)
+		}
+	})
+
+	t.Run("context cancellation mid-stream", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 7\ndata: first\n\n")
+			w.(http.Flusher).Flush()
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		lastID, err := StreamEvents(ctx, client, server.URL, func(ev SSEEvent) error {
+			cancel()
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		if lastID != "7" {
+			t.Errorf("lastID = %q, want %q", lastID, "7")
+		}
+
+		// The cancelled stream must not leave state behind that a later
+		// stream on the same client reads through.
+		next := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "id: 8\ndata: second\n\n")
+		}))
+		defer next.Close()
+		var got []string
+		lastID, err = StreamEvents(context.Background(), client, next.URL, func(ev SSEEvent) error {
+			got = append(got, ev.Data)
+			return nil
+		})
+		if err != nil || lastID != "8" || len(got) != 1 || got[0] != "second" {
+			t.Errorf("next stream: err = %v, lastID = %q, events = %q", err, lastID, got)
+		}
+	})
+
+	t.Run("rejects non event-stream response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, "{}")
+		}))
+		defer server.Close()
+
+		client, _ := newTestClient()
+		defer client.Close()
+
+		_, err := StreamEvents(context.Background(), client, server.URL, func(SSEEvent) error { return nil })
+		if err == nil || !strings.Contains(err.Error(), "content type") {
+			t.Errorf("expected content type error, got %v", err)
+		}
+	})
+}
@@ -1,11 +1,13 @@
 package httpc
 
 import (
+	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"net"
-	"net/http"
-	"net/http/cookiejar"
 	"net/url"
 	"strconv"
 	"strings"
@@ -64,6 +66,20 @@ type TimeoutConfig struct {
 	// IdleConn is the maximum time an idle connection remains open.
 	// Default: 90s.
 	IdleConn time.Duration
+
+	// PerMB extends the request timeout (Request or WithTimeout) by this much
+	// per megabyte of request body, taken from an explicit Content-Length header
+	// or the body itself when its size is known. Large uploads thus get
+	// proportionally more time. Has no effect when the timeout is 0.
+	// Default: 0 (fixed timeout).
+	PerMB time.Duration
+
+	// Jitter randomizes each request's timeout (Request or WithTimeout) by a
+	// uniform offset in [-Jitter, +Jitter], drawn once per request, so many
+	// clients sharing a timeout do not all give up at the same instant. A
+	// timeout is never shortened below half its value. Has no effect when
+	// the timeout is 0. Default: 0 (no jitter).
+	Jitter time.Duration
 }
 
 // ConnectionConfig configures connection pooling and proxy behavior.
@@ -80,6 +96,38 @@ type ConnectionConfig struct {
 	// Takes precedence over EnableSystemProxy. Default: "" (no proxy).
 	ProxyURL string
 
+	// ProxyFunc chooses the proxy for each request URL, like
+	// http.Transport.Proxy: return nil to connect directly, e.g. to route
+	// *.internal hosts around a corporate proxy. Takes precedence over
+	// ProxyURL and EnableSystemProxy. As with ProxyURL, the proxies it returns
+	// are exempt from SSRF checks. Default: nil.
+	ProxyFunc func(*url.URL) (*url.URL, error)
+
+	// UnixSocket routes every connection to this Unix domain socket path
+	// (e.g. "/var/run/docker.sock") instead of dialing the request host.
+	// The request URL still supplies the Host header and scheme, so
+	// "http://docker/v1.43/info" talks HTTP to the daemon. SSRF checks do
+	// not apply to the socket itself. Default: "" (dial over TCP).
+	UnixSocket string
+
+	// Resolver overrides DNS resolution, e.g. to query a specific DNS server
+	// or a split-horizon view. Resolved addresses are still checked against
+	// AllowPrivateIPs. Ignored when EnableDoH is set. Default: nil (system
+	// resolver).
+	Resolver *net.Resolver
+
+	// DialContext overrides how connections are dialed, without replacing
+	// the transport. It receives the address after DNS resolution and SSRF
+	// validation, so with AllowPrivateIPs false it is an already-vetted
+	// "ip:port". Default: nil (a net.Dialer using Timeouts.Dial).
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// DialPreference orders a host's resolved addresses by IP family:
+	// DialPreferIPv4 or DialPreferIPv6 try that family first and fall back
+	// to the other, which helps on networks where one family is broken.
+	// Default: DialDualStack (resolver order, Happy Eyeballs).
+	DialPreference DialPreference
+
 	// EnableSystemProxy enables automatic detection of system proxy settings.
 	// Default: false.
 	EnableSystemProxy bool
@@ -88,6 +136,13 @@ type ConnectionConfig struct {
 	// Default: true.
 	EnableHTTP2 bool
 
+	// AllowH2C sends http:// requests over HTTP/2 cleartext (h2c) with prior
+	// knowledge, for internal services that speak HTTP/2 without TLS. The
+	// server must support h2c; HTTP/1.1-only servers fail. Traffic is
+	// unencrypted and unauthenticated, so use it only on trusted internal
+	// networks. https:// requests are unaffected. Default: false.
+	AllowH2C bool
+
 	// EnableCookies enables automatic cookie handling with a cookie jar.
 	// Default: false.
 	EnableCookies bool
@@ -104,12 +159,73 @@ type ConnectionConfig struct {
 	// This protects against malicious servers sending excessively large headers.
 	// Default: 0 (uses Go stdlib default of 10MB).
 	MaxResponseHeaderBytes int64
+
+	// MaxStatusLineLength limits the length of a response's status line
+	// (e.g. "HTTP/1.1 200 OK"), failing the request with ErrStatusLineTooLong
+	// as soon as a server exceeds it rather than buffering the line. Enforced
+	// for cleartext HTTP/1.x; over TLS the status line is bounded by
+	// MaxResponseHeaderBytes. Default: 0 (no separate limit).
+	MaxStatusLineLength int
+
+	// KeepCompressedBody retains the encoded response bytes in
+	// ResponseInfo.CompressedRawBody alongside the decompressed RawBody.
+	// Useful for caching the compressed form. Default: false.
+	KeepCompressedBody bool
+
+	// SniffCompression decompresses responses that carry no Content-Encoding
+	// but start with the gzip magic number (0x1f 0x8b), as sent by some
+	// misconfigured servers. Off by default so bodies are never altered
+	// unexpectedly. Default: false.
+	SniffCompression bool
+
+	// RateLimit caps requests per second to each host using a token bucket.
+	// Requests over the limit block until a token is available or the request
+	// context/timeout ends. Override per request with WithRateLimit.
+	// Default: 0 (unlimited).
+	RateLimit float64
+
+	// RateLimitBurst is the number of requests allowed in a burst before
+	// RateLimit applies. Default: 0 (treated as 1).
+	RateLimitBurst int
+
+	// EnableCache enables an in-memory LRU cache for GET and HEAD responses
+	// that honors Cache-Control (max-age, no-cache, no-store, private) and
+	// Expires, and revalidates stale entries with ETag/Last-Modified.
+	// Responses reached by following redirects are not stored. A request's
+	// own Cache-Control no-cache or max-age forces revalidation, and
+	// WithNoCache bypasses the cache. Default: false.
+	EnableCache bool
+
+	// CacheMaxEntries caps the number of cached responses.
+	// Default: 0 (1000 entries).
+	CacheMaxEntries int
+
+	// CacheMaxBytes caps the total size of cached bodies and headers.
+	// Default: 0 (32MB).
+	CacheMaxBytes int64
+
+	// RetainResponseHeaders lists the response headers to keep; all others
+	// are dropped before the response is returned, saving the allocation of
+	// headers the caller never reads. Names are case-insensitive. Headers the
+	// client itself consults after the response (Retry-After, Cache-Control,
+	// ETag) must be listed to keep their effect. Set-Cookie is still applied
+	// to the cookie jar. Default: nil (keep all).
+	RetainResponseHeaders []string
+
+	// WarmupURLs lists absolute http or https URLs that New sends a HEAD
+	// request to before returning, so the pool already holds a connection
+	// to each host and the first real requests skip DNS, TCP and TLS setup.
+	// Warmup is best-effort: failures are ignored, and New waits at most 2s.
+	// Warmup requests bypass middleware, the cache and the rate limiter, and
+	// are not counted in Stats. Default: nil.
+	WarmupURLs []string
 }
 
 // SecurityConfig configures TLS, validation, and SSRF protection.
 type SecurityConfig struct {
 	// TLSConfig provides custom TLS configuration. If set, MinTLSVersion and
-	// MaxTLSVersion are ignored. Default: nil.
+	// MaxTLSVersion are ignored; client certificates and RootCAFile still
+	// apply unless TLSConfig sets its own. Default: nil.
 	TLSConfig *tls.Config
 
 	// MinTLSVersion is the minimum TLS version. Default: TLS 1.2.
@@ -122,6 +238,27 @@ type SecurityConfig struct {
 	// WARNING: Only use in testing. Default: false.
 	InsecureSkipVerify bool
 
+	// ClientCertFile and ClientKeyFile name PEM files holding a client
+	// certificate and its private key, presented to servers that request
+	// one (mutual TLS). Both must be set together; the files are read by
+	// New. Default: "" (no client certificate).
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// ClientCertificates are presented to servers that request a client
+	// certificate, after any loaded from ClientCertFile. Default: nil.
+	ClientCertificates []tls.Certificate
+
+	// RootCAFile names a PEM file of CA certificates used instead of the
+	// system roots to verify servers, e.g. a private CA. Read by New.
+	// Default: "" (system roots).
+	RootCAFile string
+
+	// RequireOCSPStapling fails the TLS handshake unless the server staples a
+	// valid OCSP response for its certificate. Revoked, unknown, and expired
+	// responses are rejected. Default: false.
+	RequireOCSPStapling bool
+
 	// MaxResponseBodySize limits response body size in bytes. Default: 10MB.
 	MaxResponseBodySize int64
 
@@ -155,9 +292,35 @@ type SecurityConfig struct {
 	// ValidateHeaders enables header validation. Default: true.
 	ValidateHeaders bool
 
+	// AllowHopByHopHeaders permits requests to set hop-by-hop headers
+	// (Transfer-Encoding, Upgrade, TE, Trailer, Keep-Alive, Proxy-Connection,
+	// and Connection tokens other than keep-alive and close). These control
+	// message framing and connection reuse; set by callers they can corrupt
+	// requests or enable smuggling. Default: false (such requests are rejected).
+	AllowHopByHopHeaders bool
+
+	// AllowGetBody permits a request body (e.g. from WithJSON) on GET and
+	// HEAD requests. Such bodies have no defined semantics and are rejected
+	// or dropped by many servers and proxies. Default: false (the request
+	// fails before it is sent).
+	AllowGetBody bool
+
+	// StrictFraming rejects responses that carry both Content-Length and
+	// Transfer-Encoding with ErrAmbiguousFraming. Go's HTTP stack resolves
+	// such responses by ignoring Content-Length, but a proxy in the path
+	// may have framed them differently, which is how response smuggling
+	// works. Enforced for cleartext HTTP/1.x; over TLS only the endpoints
+	// see the raw headers. Default: false.
+	StrictFraming bool
+
 	// StrictContentLength enables strict Content-Length validation. Default: true.
 	StrictContentLength bool
 
+	// StrictEncoding rejects responses whose Content-Encoding was not
+	// advertised in the request's Accept-Encoding header or cannot be decoded,
+	// instead of passing the encoded bytes through as the body. Default: false.
+	StrictEncoding bool
+
 	// CookieSecurity enables cookie security attribute validation.
 	// Default: nil (no validation).
 	CookieSecurity *validation.CookieSecurityConfig
@@ -181,12 +344,57 @@ type RetryConfig struct {
 	// EnableJitter enables jitter in retry delay. Default: true.
 	EnableJitter bool
 
+	// JitterStrategy selects how delays are randomized. JitterFull or
+	// JitterDecorrelated spread the retries of many clients more widely than
+	// the ±10% of JitterProportional. Default: JitterAuto (JitterProportional
+	// when EnableJitter is set, JitterNone otherwise).
+	JitterStrategy JitterStrategy
+
+	// JitterRand is the random source for jitter. Inject a seeded source,
+	// e.g. rand.New(rand.NewPCG(1, 2)), for reproducible delays in tests.
+	// The client serializes access to it. Default: nil (global random source).
+	JitterRand *rand.Rand
+
 	// MaxRetryDelay caps the maximum delay between retry attempts.
 	// Default: 30s. Set to 0 for no cap (not recommended).
 	MaxRetryDelay time.Duration
 
+	// Budget caps the total time spent on a request across all attempts,
+	// including retry delays. A retry is not started when its delay would
+	// end past the budget; the last response or error is returned instead,
+	// even if MaxRetries is not used up. An attempt still running when the
+	// budget is spent is cancelled. Default: 0 (no budget).
+	Budget time.Duration
+
+	// RetryableStatusCodes lists the response statuses that are retried.
+	// Default: nil (408, 429, 500, 502, 503, 504). Ignored by CustomPolicy.
+	RetryableStatusCodes []int
+
+	// RetryableMethods lists the request methods that may be retried, for
+	// both error and status retries. Default: nil (the idempotent methods
+	// GET, HEAD, OPTIONS, TRACE, PUT, and DELETE; POST and PATCH are not
+	// retried). Include "POST" to opt in, e.g. []string{"GET", "POST"}.
+	RetryableMethods []string
+
 	// CustomPolicy overrides the built-in retry logic. Default: nil.
 	CustomPolicy RetryPolicy
+
+	// RetryIf decides whether to retry instead of the built-in (or custom)
+	// policy's ShouldRetry, e.g. to retry a 200 whose body reports
+	// "pending". Retries remain bounded by MaxRetries and RetryableMethods,
+	// and stop when the request context is done. Default: nil.
+	RetryIf RetryIfFunc
+
+	// RetryJSONField retries a response, whatever its status, when its JSON
+	// body holds this field (a dot-separated path such as "error.retryable")
+	// equal to RetryJSONValue. With RetryJSONValue nil, any value other than
+	// null or false matches. This is checked in addition to the status and
+	// RetryIf decisions; streamed bodies are never inspected. Default: "".
+	RetryJSONField string
+
+	// RetryJSONValue is the value RetryJSONField must equal, compared after a
+	// JSON round trip so that 1 matches 1.0. Default: nil.
+	RetryJSONValue any
 }
 
 // MiddlewareConfig configures middleware, default headers, and redirect behavior.
@@ -206,6 +414,33 @@ type MiddlewareConfig struct {
 
 	// MaxRedirects limits automatic redirects. Default: 10.
 	MaxRedirects int
+
+	// MaxRedirectTime bounds the cumulative time spent following redirects,
+	// measured from the first redirect response until the final response's
+	// headers arrive. A chain that takes longer is aborted with a timeout
+	// error wrapping ErrRedirectTimeout. Default: 0 (no limit beyond
+	// Timeouts.Request).
+	MaxRedirectTime time.Duration
+
+	// DisableRefererOnRedirect stops setting the Referer header of each
+	// redirected request to the URL that issued the redirect, which is done
+	// by default as browsers do. A Referer set by the caller is kept either
+	// way. No Referer is sent when a redirect downgrades from https to http.
+	// Default: false.
+	DisableRefererOnRedirect bool
+
+	// CheckRedirect, when set, is called before each redirect is followed,
+	// after the built-in checks (SSRF protection, whitelist, loop and limit
+	// detection) have passed. Authorization, Proxy-Authorization and Cookie
+	// are already removed from req when the redirect changes host. Return
+	// http.ErrUseLastResponse to stop and receive the 3xx response, or any
+	// other error to fail the request. Overridden per request by
+	// WithCheckRedirect. Default: nil.
+	CheckRedirect CheckRedirectFunc
+
+	// Tracer receives OnStart/OnRetry/OnFinish callbacks for every request,
+	// e.g. to create tracing spans. Default: nil (no tracing).
+	Tracer Tracer
 }
 
 // Config defines the HTTP client configuration organized into logical groups.
@@ -226,6 +461,18 @@ type Config struct {
 	Retry      *RetryConfig
 	Middleware *MiddlewareConfig
 
+	// Clock is the time source for retry backoff delays, Retry-After dates
+	// and request durations. Tests can supply a fake clock to verify backoff
+	// without sleeping; timeouts still run on real time. Default: nil (the
+	// time package).
+	Clock Clock
+
+	// TokenSource, when set, supplies a bearer token for every request that
+	// has no Authorization header of its own. On a 401 the source is asked
+	// once more and the request is resent if it returns a different token.
+	// Default: nil.
+	TokenSource TokenSource
+
 	// parsedCIDRs caches parsed SSRFExemptCIDRs to avoid double parsing.
 	// Filled by parseSSRFExemptCIDRs; consumed by convertToEngineConfig.
 	parsedCIDRs []*net.IPNet
@@ -239,6 +486,89 @@ type RequestOption = engine.RequestOption
 // Alias for types.RetryPolicy to avoid importing the internal package.
 type RetryPolicy = types.RetryPolicy
 
+// RetryIfFunc decides whether to retry after an attempt; see RetryConfig.RetryIf.
+// Alias for types.RetryIfFunc to avoid importing the internal package.
+type RetryIfFunc = types.RetryIfFunc
+
+// CheckRedirectFunc decides whether to follow a redirect; see
+// MiddlewareConfig.CheckRedirect.
+// Alias for types.CheckRedirectFunc to avoid importing the internal package.
+type CheckRedirectFunc = types.CheckRedirectFunc
+
+// RetryAttempt describes one retried attempt in RequestMeta.RetryHistory.
+// Alias for types.RetryAttempt to avoid importing the internal package.
+type RetryAttempt = types.RetryAttempt
+
+// Stats is a snapshot of a client's request counters; see Client.Stats.
+// Alias for types.Stats to avoid importing the internal package.
+type Stats = types.Stats
+
+// Timing breaks down the final request into phases; see RequestMeta.Timing.
+// Alias for types.Timing to avoid importing the internal package.
+type Timing = types.Timing
+
+// ConfigOverride holds per-request defaults carried in a context; see
+// WithConfigOverride.
+// Alias for types.ConfigOverride to avoid importing the internal package.
+type ConfigOverride = types.ConfigOverride
+
+// RedirectHop describes one followed redirect in RequestMeta.RedirectHops.
+// Alias for types.RedirectHop to avoid importing the internal package.
+type RedirectHop = types.RedirectHop
+
+// ResponseReader provides read-only access to response data.
+// Alias for types.ResponseReader to avoid importing the internal package.
+type ResponseReader = types.ResponseReader
+
+// Tracer receives request lifecycle callbacks for observability.
+// Alias for types.Tracer to avoid importing the internal package.
+type Tracer = types.Tracer
+
+// DialPreference orders resolved addresses by IP family; see
+// ConnectionConfig.DialPreference.
+// Alias for types.DialPreference to avoid importing the internal package.
+type DialPreference = types.DialPreference
+
+// Dial preferences for ConnectionConfig.DialPreference.
+const (
+	// DialDualStack dials addresses in resolver order (the default).
+	DialDualStack DialPreference = types.DialDualStack
+	// DialPreferIPv4 dials IPv4 addresses first, falling back to IPv6.
+	DialPreferIPv4 DialPreference = types.DialPreferIPv4
+	// DialPreferIPv6 dials IPv6 addresses first, falling back to IPv4.
+	DialPreferIPv6 DialPreference = types.DialPreferIPv6
+)
+
+// JitterStrategy selects how retry delays are randomized; see
+// RetryConfig.JitterStrategy.
+// Alias for types.JitterStrategy to avoid importing the internal package.
+type JitterStrategy = types.JitterStrategy
+
+// Jitter strategies for RetryConfig.JitterStrategy.
+const (
+	// JitterAuto follows RetryConfig.EnableJitter (the default).
+	JitterAuto JitterStrategy = types.JitterAuto
+	// JitterNone uses the backoff delay as is.
+	JitterNone JitterStrategy = types.JitterNone
+	// JitterProportional varies the delay by up to ±10%.
+	JitterProportional JitterStrategy = types.JitterProportional
+	// JitterFull picks a delay between 0 and the backoff delay.
+	JitterFull JitterStrategy = types.JitterFull
+	// JitterEqual keeps half the backoff delay and randomizes the rest.
+	JitterEqual JitterStrategy = types.JitterEqual
+	// JitterDecorrelated picks a delay between Retry.Delay and three times
+	// the previous backoff delay, capped at Retry.MaxRetryDelay.
+	JitterDecorrelated JitterStrategy = types.JitterDecorrelated
+)
+
+// Clock is the time source for retry backoff; see Config.Clock.
+// Alias for types.Clock to avoid importing the internal package.
+type Clock = types.Clock
+
+// TokenSource supplies bearer tokens; see Config.TokenSource.
+// Alias for types.TokenSource to avoid importing the internal package.
+type TokenSource = types.TokenSource
+
 // CookieSecurityConfig configures cookie security attribute validation.
 // Use DefaultCookieSecurityConfig() or StrictCookieSecurityConfig() to create instances.
 // Alias for validation.CookieSecurityConfig to avoid importing the internal package.
@@ -279,6 +609,10 @@ type FormData = types.FormData
 // Alias for types.FileData to avoid importing the internal package.
 type FileData = types.FileData
 
+// PartData represents a non-file multipart field with an explicit Content-Type.
+// Alias for types.PartData to avoid importing the internal package.
+type PartData = types.PartData
+
 // RequestMutator provides read-write access to request data for middleware.
 // Alias for types.RequestMutator to avoid importing the internal package.
 type RequestMutator = types.RequestMutator
@@ -384,13 +718,6 @@ func DefaultConfig() *Config {
 	}
 }
 
-// newCookieJar creates a new cookie jar for cookie management.
-func newCookieJar() (http.CookieJar, error) {
-	return cookiejar.New(&cookiejar.Options{
-		PublicSuffixList: nil,
-	})
-}
-
 // validateDuration validates that a duration is within [0, max].
 func validateDuration(field string, d, max time.Duration) error {
 	if d < 0 || d > max {
@@ -407,6 +734,21 @@ func validateRange(field string, v, max int) error {
 	return nil
 }
 
+// validateRetryPolicy validates retryable status codes and methods.
+func validateRetryPolicy(field string, statusCodes []int, methods []string) error {
+	for _, code := range statusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("%w: %sRetryableStatusCodes must be 100-599, got %d", ErrInvalidRetry, field, code)
+		}
+	}
+	for _, m := range methods {
+		if m == "" || strings.ContainsFunc(m, func(r rune) bool { return r <= ' ' || r >= 0x7f }) {
+			return fmt.Errorf("%w: %sRetryableMethods contains invalid method %q", ErrInvalidRetry, field, m)
+		}
+	}
+	return nil
+}
+
 // ValidateConfig validates the configuration and returns an error if invalid.
 // This is called internally by New() but can also be called explicitly.
 func ValidateConfig(cfg *Config) error {
@@ -422,6 +764,8 @@ func ValidateConfig(cfg *Config) error {
 			validateDuration("Timeouts.TLSHandshake", cfg.Timeouts.TLSHandshake, maxTimeout),
 			validateDuration("Timeouts.ResponseHeader", cfg.Timeouts.ResponseHeader, maxTimeout),
 			validateDuration("Timeouts.IdleConn", cfg.Timeouts.IdleConn, maxTimeout),
+			validateDuration("Timeouts.PerMB", cfg.Timeouts.PerMB, maxTimeout),
+			validateDuration("Timeouts.Jitter", cfg.Timeouts.Jitter, maxTimeout),
 		} {
 			if err != nil {
 				return err
@@ -444,12 +788,32 @@ func ValidateConfig(cfg *Config) error {
 				return fmt.Errorf("%w: Connection.ProxyURL invalid: %w", ErrInvalidConnection, err)
 			}
 		}
+		for _, raw := range cfg.Connection.WarmupURLs {
+			if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: Connection.WarmupURLs must be absolute http or https URLs, got %q", ErrInvalidConnection, raw)
+			}
+		}
 		if cfg.Connection.DoHCacheTTL < 0 {
 			return fmt.Errorf("%w: Connection.DoHCacheTTL cannot be negative, got %v", ErrInvalidConnection, cfg.Connection.DoHCacheTTL)
 		}
 		if cfg.Connection.MaxResponseHeaderBytes < 0 {
 			return fmt.Errorf("%w: Connection.MaxResponseHeaderBytes cannot be negative, got %d", ErrInvalidConnection, cfg.Connection.MaxResponseHeaderBytes)
 		}
+		if cfg.Connection.MaxStatusLineLength < 0 {
+			return fmt.Errorf("%w: Connection.MaxStatusLineLength cannot be negative, got %d", ErrInvalidConnection, cfg.Connection.MaxStatusLineLength)
+		}
+		if cfg.Connection.RateLimit < 0 || math.IsNaN(cfg.Connection.RateLimit) || math.IsInf(cfg.Connection.RateLimit, 0) {
+			return fmt.Errorf("%w: Connection.RateLimit must be a non-negative number, got %v", ErrInvalidConnection, cfg.Connection.RateLimit)
+		}
+		if cfg.Connection.RateLimitBurst < 0 {
+			return fmt.Errorf("%w: Connection.RateLimitBurst cannot be negative, got %d", ErrInvalidConnection, cfg.Connection.RateLimitBurst)
+		}
+		if cfg.Connection.CacheMaxEntries < 0 {
+			return fmt.Errorf("%w: Connection.CacheMaxEntries cannot be negative, got %d", ErrInvalidConnection, cfg.Connection.CacheMaxEntries)
+		}
+		if cfg.Connection.CacheMaxBytes < 0 {
+			return fmt.Errorf("%w: Connection.CacheMaxBytes cannot be negative, got %d", ErrInvalidConnection, cfg.Connection.CacheMaxBytes)
+		}
 	}
 
 	// Validate security settings
@@ -471,6 +835,10 @@ func ValidateConfig(cfg *Config) error {
 			}
 		}
 
+		if (cfg.Security.ClientCertFile == "") != (cfg.Security.ClientKeyFile == "") {
+			return fmt.Errorf("%w: Security.ClientCertFile and ClientKeyFile must be set together", ErrInvalidSecurity)
+		}
+
 		// Validate CIDR format only — parsing deferred to parseSSRFExemptCIDRs
 		// to avoid mutating the caller's Config (parsedCIDRs field).
 		for _, cidr := range cfg.Security.SSRFExemptCIDRs {
@@ -494,6 +862,20 @@ func ValidateConfig(cfg *Config) error {
 		if cfg.Retry.MaxRetryDelay < 0 || cfg.Retry.MaxRetryDelay > maxTimeout {
 			return fmt.Errorf("%w: Retry.MaxRetryDelay must be 0-%v, got %v", ErrInvalidRetry, maxTimeout, cfg.Retry.MaxRetryDelay)
 		}
+		if cfg.Retry.JitterStrategy < JitterAuto || cfg.Retry.JitterStrategy > JitterDecorrelated {
+			return fmt.Errorf("%w: unknown Retry.JitterStrategy %d", ErrInvalidRetry, cfg.Retry.JitterStrategy)
+		}
+		if cfg.Retry.Budget < 0 {
+			return fmt.Errorf("%w: Retry.Budget cannot be negative, got %v", ErrInvalidRetry, cfg.Retry.Budget)
+		}
+		if err := validateRetryPolicy("Retry.", cfg.Retry.RetryableStatusCodes, cfg.Retry.RetryableMethods); err != nil {
+			return err
+		}
+		if cfg.Retry.RetryJSONValue != nil {
+			if _, err := json.Marshal(cfg.Retry.RetryJSONValue); err != nil {
+				return fmt.Errorf("%w: Retry.RetryJSONValue must be JSON-encodable: %v", ErrInvalidRetry, err)
+			}
+		}
 	}
 
 	// Validate middleware settings
@@ -501,6 +883,9 @@ func ValidateConfig(cfg *Config) error {
 		if cfg.Middleware.MaxRedirects < 0 || cfg.Middleware.MaxRedirects > maxRedirectLimit {
 			return fmt.Errorf("%w: Middleware.MaxRedirects must be 0-50, got %d", ErrInvalidMiddleware, cfg.Middleware.MaxRedirects)
 		}
+		if cfg.Middleware.MaxRedirectTime < 0 {
+			return fmt.Errorf("%w: Middleware.MaxRedirectTime cannot be negative, got %v", ErrInvalidMiddleware, cfg.Middleware.MaxRedirectTime)
+		}
 		if len(cfg.Middleware.UserAgent) > maxUserAgentLen || !validation.IsValidHeaderString(cfg.Middleware.UserAgent) {
 			return fmt.Errorf("%w: Middleware.UserAgent invalid: max %d chars, no control characters", ErrInvalidMiddleware, maxUserAgentLen)
 		}